@@ -0,0 +1,313 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+// the period types of BSS renewal
+const (
+	bssPeriodTypeMonth = 2
+	bssPeriodTypeYear  = 3
+)
+
+// SchemaChargingModeUpdatable returns the schema of charging_mode which can be changed from postPaid to prePaid.
+func SchemaChargingModeUpdatable(conflicts []string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ValidateFunc: validation.StringInSlice([]string{
+			"prePaid", "postPaid",
+		}, false),
+		ConflictsWith: conflicts,
+	}
+}
+
+// SchemaChargingModeWithSpot returns the schema of charging_mode which also supports the spot pricing,
+// the spot resources can not be converted to or from the other charging modes.
+func SchemaChargingModeWithSpot(conflicts []string) *schema.Schema {
+	s := SchemaChargingModeUpdatable(conflicts)
+	s.ValidateFunc = validation.StringInSlice([]string{
+		"prePaid", "postPaid", "spot",
+	}, false)
+	return s
+}
+
+// SchemaPeriodUnitUpdatable returns the schema of period_unit, lengthening it renews the prePaid resource.
+func SchemaPeriodUnitUpdatable(conflicts []string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		RequiredWith: []string{"period"},
+		ValidateFunc: validation.StringInSlice([]string{
+			"month", "year",
+		}, false),
+		ConflictsWith: conflicts,
+	}
+}
+
+// SchemaPeriodUpdatable returns the schema of period, lengthening it renews the prePaid resource.
+func SchemaPeriodUpdatable(conflicts []string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeInt,
+		Optional:      true,
+		RequiredWith:  []string{"period_unit"},
+		ValidateFunc:  validation.IntBetween(1, 9),
+		ConflictsWith: conflicts,
+	}
+}
+
+// SchemaAutoRenewUpdatable returns the schema of auto_renew which can be switched on and off.
+func SchemaAutoRenewUpdatable(conflicts []string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ValidateFunc: validation.StringInSlice([]string{
+			"true", "false",
+		}, false),
+		ConflictsWith: conflicts,
+	}
+}
+
+// SchemaExpireTime returns the schema of expire_time, the expiration time of the prePaid resource.
+func SchemaExpireTime() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// ChangeToPrePaidOpts is the charge info used to convert a postPaid resource to prePaid.
+type ChangeToPrePaidOpts struct {
+	// PeriodUnit is month or year
+	PeriodUnit string
+	Period     int
+	AutoRenew  bool
+}
+
+// ChangeToPrePaidFunc converts the postPaid resource to prePaid through the API of the service,
+// the order should be paid automatically and its ID is returned.
+type ChangeToPrePaidFunc func(opts ChangeToPrePaidOpts) (string, error)
+
+// UpdateChargeInfo updates the charging_mode, period_unit, period and auto_renew of the resource:
+//   - changing charging_mode from postPaid to prePaid converts the resource through changeToPrePaid,
+//     which is nil if the service does not support the conversion;
+//   - lengthening period_unit or period of a prePaid resource renews it by the difference between the new and
+//     the old periods, shortening them is refused as the billing center can not unsubscribe part of the period;
+//   - changing auto_renew of a prePaid resource switches the auto-renewal on or off.
+func UpdateChargeInfo(ctx context.Context, d *schema.ResourceData, config *config.Config, resourceID string,
+	changeToPrePaid ChangeToPrePaidFunc) error {
+	if !d.HasChanges("charging_mode", "period_unit", "period", "auto_renew") {
+		return nil
+	}
+
+	oldMode, newMode := d.GetChange("charging_mode")
+	if newMode.(string) != "prePaid" {
+		if oldMode.(string) == "prePaid" {
+			return fmtp.Errorf("the prePaid resource (%s) can not be converted to postPaid, "+
+				"please change it in the billing center", resourceID)
+		}
+		return nil
+	}
+
+	if err := ValidatePrePaidChargeInfo(d); err != nil {
+		return err
+	}
+
+	if oldMode.(string) != "prePaid" {
+		if changeToPrePaid == nil {
+			return fmtp.Errorf("the resource (%s) does not support converting postPaid to prePaid", resourceID)
+		}
+
+		opts := ChangeToPrePaidOpts{
+			PeriodUnit: d.Get("period_unit").(string),
+			Period:     d.Get("period").(int),
+			AutoRenew:  d.Get("auto_renew").(string) == "true",
+		}
+		orderID, err := changeToPrePaid(opts)
+		if err != nil {
+			return fmtp.Errorf("Error converting the resource (%s) to prePaid: %s", resourceID, err)
+		}
+		return waitOrderComplete(ctx, d, config, orderID, d.Timeout(schema.TimeoutUpdate))
+	}
+
+	// the period is missing in the state of the imported resources, setting it is not regarded as a renewal
+	if oldPeriod, _ := d.GetChange("period"); oldPeriod.(int) == 0 {
+		logp.Printf("[DEBUG] the period of the prePaid resource (%s) is not in the state, skip renewing", resourceID)
+	} else if d.HasChanges("period_unit", "period") {
+		oldUnit, newUnit := d.GetChange("period_unit")
+		periods, err := buildRenewPeriods(oldUnit.(string), oldPeriod.(int), newUnit.(string), d.Get("period").(int))
+		if err != nil {
+			return fmtp.Errorf("Error renewing the prePaid resource (%s): %s", resourceID, err)
+		}
+		for _, period := range periods {
+			if err := RenewPrePaidResource(ctx, d, config, []string{resourceID}, period); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.HasChange("auto_renew") {
+		if err := UpdateAutoRenew(d, config, d.Get("auto_renew").(string) == "true", resourceID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func periodInMonths(periodUnit string, period int) int {
+	if periodUnit == "year" {
+		return period * 12
+	}
+	return period
+}
+
+// RenewPeriod is the period of a BSS renewal.
+type RenewPeriod struct {
+	// PeriodType is 2 (month) or 3 (year)
+	PeriodType int
+	PeriodNum  int
+}
+
+// buildRenewPeriods returns the renewals which lengthen the old period to the new one. The difference is split
+// into the whole years and the remaining months, as a single renewal is either by year or by month.
+// An error is returned if the new period is shorter than the old one.
+func buildRenewPeriods(oldUnit string, oldPeriod int, newUnit string, newPeriod int) ([]RenewPeriod, error) {
+	months := periodInMonths(newUnit, newPeriod) - periodInMonths(oldUnit, oldPeriod)
+	if months < 0 {
+		return nil, fmt.Errorf("the period can not be shortened from %d %s to %d %s, "+
+			"please unsubscribe the resource in the billing center", oldPeriod, oldUnit, newPeriod, newUnit)
+	}
+
+	periods := make([]RenewPeriod, 0, 2)
+	if months/12 > 0 {
+		periods = append(periods, RenewPeriod{PeriodType: bssPeriodTypeYear, PeriodNum: months / 12})
+	}
+	if months%12 > 0 {
+		periods = append(periods, RenewPeriod{PeriodType: bssPeriodTypeMonth, PeriodNum: months % 12})
+	}
+	return periods, nil
+}
+
+// RenewPrePaidResource renews the prePaid resources for the period, and waits for the order to complete.
+func RenewPrePaidResource(ctx context.Context, d *schema.ResourceData, config *config.Config,
+	resourceIDs []string, period RenewPeriod) error {
+	bssV2Client, err := config.BssV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud bss V2 client: %s", err)
+	}
+
+	renewOpts := map[string]interface{}{
+		"resource_ids": resourceIDs,
+		"period_type":  period.PeriodType,
+		"period_num":   period.PeriodNum,
+		// 0: the resource is retained after the renewal period expires
+		"expire_policy": 0,
+		// 1: pay the order automatically
+		"is_auto_pay": 1,
+	}
+	logp.Printf("[DEBUG] Renew options of the prePaid resources: %#v", renewOpts)
+
+	var r struct {
+		OrderIDs []string `json:"order_ids"`
+	}
+	_, err = bssV2Client.Post(bssV2Client.ServiceURL("orders/subscriptions/resources/renew"), renewOpts, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		return fmtp.Errorf("Error renewing the prePaid resources %v: %s", resourceIDs, err)
+	}
+
+	for _, orderID := range r.OrderIDs {
+		if err := waitOrderComplete(ctx, d, config, orderID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateAutoRenew switches the auto-renewal of the prePaid resource on or off.
+func UpdateAutoRenew(d *schema.ResourceData, config *config.Config, enable bool, resourceID string) error {
+	bssV2Client, err := config.BssV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud bss V2 client: %s", err)
+	}
+
+	autoRenewURL := bssV2Client.ServiceURL("orders/subscriptions/resources/autorenew", resourceID)
+	if enable {
+		_, err = bssV2Client.Post(autoRenewURL, nil, nil, &golangsdk.RequestOpts{OkCodes: []int{200, 204}})
+	} else {
+		_, err = bssV2Client.Delete(autoRenewURL+"?action_id=delete_auto_renew",
+			&golangsdk.RequestOpts{OkCodes: []int{200, 204}})
+	}
+	if err != nil {
+		return fmtp.Errorf("Error updating the auto-renewal of the prePaid resource (%s) to %t: %s",
+			resourceID, enable, err)
+	}
+	return nil
+}
+
+// GetPrePaidExpireTime returns the expiration time of the prePaid resource in RFC3339 format.
+func GetPrePaidExpireTime(d *schema.ResourceData, config *config.Config, resourceID string) (string, error) {
+	bssV2Client, err := config.BssV2Client(GetRegion(d, config))
+	if err != nil {
+		return "", fmtp.Errorf("Error creating HuaweiCloud bss V2 client: %s", err)
+	}
+
+	queryOpts := map[string]interface{}{
+		"resource_ids":       []string{resourceID},
+		"only_main_resource": 1,
+	}
+	var r struct {
+		Data []struct {
+			ResourceID string `json:"resource_id"`
+			ExpireTime string `json:"expire_time"`
+		} `json:"data"`
+	}
+	// the path of the API is "suscriptions" rather than "subscriptions"
+	_, err = bssV2Client.Post(bssV2Client.ServiceURL("orders/suscriptions/resources/query"), queryOpts, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		return "", fmtp.Errorf("Error querying the prePaid resource (%s): %s", resourceID, err)
+	}
+
+	for _, item := range r.Data {
+		if item.ResourceID == resourceID {
+			return item.ExpireTime, nil
+		}
+	}
+	return "", fmt.Errorf("the prePaid resource (%s) is not found in the billing center", resourceID)
+}
+
+// SetPrePaidExpireTime reads back the expire_time of the prePaid resource, it's empty for the postPaid resources.
+// The errors of BSS are logged rather than returned, as the resource can be read without the billing permissions.
+func SetPrePaidExpireTime(d *schema.ResourceData, config *config.Config, resourceID string) error {
+	if d.Get("charging_mode").(string) != "prePaid" {
+		return d.Set("expire_time", nil)
+	}
+
+	expireTime, err := GetPrePaidExpireTime(d, config, resourceID)
+	if err != nil {
+		logp.Printf("[WARN] %s", err)
+		return nil
+	}
+	return d.Set("expire_time", expireTime)
+}
+
+func waitOrderComplete(ctx context.Context, d *schema.ResourceData, config *config.Config, orderNum string,
+	timeout time.Duration) error {
+	bssV2Client, err := config.BssV2Client(GetRegion(d, config))
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud bss V2 client: %s", err)
+	}
+	return waitOrderStatus(ctx, bssV2Client, orderNum, timeout)
+}
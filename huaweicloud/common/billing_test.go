@@ -0,0 +1,45 @@
+package common
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildRenewPeriods(t *testing.T) {
+	cases := []struct {
+		oldUnit   string
+		oldPeriod int
+		newUnit   string
+		newPeriod int
+		expected  []RenewPeriod
+	}{
+		{"month", 3, "month", 5, []RenewPeriod{{PeriodType: bssPeriodTypeMonth, PeriodNum: 2}}},
+		{"year", 1, "year", 3, []RenewPeriod{{PeriodType: bssPeriodTypeYear, PeriodNum: 2}}},
+		{"month", 6, "year", 1, []RenewPeriod{{PeriodType: bssPeriodTypeMonth, PeriodNum: 6}}},
+		{"month", 1, "year", 2, []RenewPeriod{
+			{PeriodType: bssPeriodTypeYear, PeriodNum: 1},
+			{PeriodType: bssPeriodTypeMonth, PeriodNum: 11},
+		}},
+		{"year", 1, "month", 12, []RenewPeriod{}},
+	}
+
+	for _, tc := range cases {
+		periods, err := buildRenewPeriods(tc.oldUnit, tc.oldPeriod, tc.newUnit, tc.newPeriod)
+		if err != nil {
+			t.Fatalf("unexpected error renewing %d %s to %d %s: %s",
+				tc.oldPeriod, tc.oldUnit, tc.newPeriod, tc.newUnit, err)
+		}
+		if !reflect.DeepEqual(periods, tc.expected) {
+			t.Fatalf("expected the renewals of %d %s to %d %s to be %v, but got %v",
+				tc.oldPeriod, tc.oldUnit, tc.newPeriod, tc.newUnit, tc.expected, periods)
+		}
+	}
+}
+
+func TestBuildRenewPeriods_shortened(t *testing.T) {
+	_, err := buildRenewPeriods("year", 1, "month", 6)
+	if err == nil || !strings.Contains(err.Error(), "can not be shortened from 1 year to 6 month") {
+		t.Fatalf("expected an error of the shortened period, but got %v", err)
+	}
+}
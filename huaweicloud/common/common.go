@@ -11,8 +11,6 @@ package common
 
 import (
 	"context"
-	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
@@ -24,7 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/waiter"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
 )
@@ -78,37 +76,36 @@ func GetEipIDbyAddress(client *golangsdk.ServiceClient, address string) (string,
 
 // CheckDeleted checks the error to see if it's a 404 (Not Found) and, if so,
 // sets the resource ID to the empty string instead of throwing an error.
+// The request ID of the response is included in the returned error.
 func CheckDeleted(d *schema.ResourceData, err error, msg string) error {
-	if _, ok := err.(golangsdk.ErrDefault404); ok {
+	apiErr := ParseAPIError(err)
+	if apiErr == nil {
+		return fmtp.Errorf("%s: %s", msg, err)
+	}
+	if apiErr.Kind == ErrorKindNotFound {
 		d.SetId("")
 		return nil
 	}
 
-	return fmtp.Errorf("%s: %s", msg, err)
+	return fmtp.Errorf("%s: %w", msg, apiErr)
 }
 
 // CheckDeletedDiag checks the error to see if it's a 404 (Not Found) and, if so,
 // sets the resource ID to the empty string instead of throwing an error.
+// The returned diagnostic includes the error code and the request ID of the response.
 func CheckDeletedDiag(d *schema.ResourceData, err error, msg string) diag.Diagnostics {
-	if _, ok := err.(golangsdk.ErrDefault404); ok {
+	if IsNotFound(err) {
 		d.SetId("")
 		return nil
 	}
 
-	return fmtp.DiagErrorf("%s: %s", msg, err)
+	return DiagAPIError(err, "%s", msg)
 }
 
 // CheckDeletedError checks the error raised by **huaweicloud-sdk-go-v3** is 404 (Not Found),
 // if so, sets the resource ID to the empty string instead of throwing an error.
 func CheckDeletedError(d *schema.ResourceData, err error, msg string) diag.Diagnostics {
-	if responseErr, ok := err.(*sdkerr.ServiceResponseError); ok {
-		if responseErr.StatusCode == http.StatusNotFound {
-			d.SetId("")
-			return nil
-		}
-	}
-
-	return fmtp.DiagErrorf("%s: %s", msg, err)
+	return CheckDeletedDiag(d, err, msg)
 }
 
 // UnsubscribePrePaidResource impl the action of unsubscribe resource
@@ -126,20 +123,13 @@ func UnsubscribePrePaidResource(d *schema.ResourceData, config *config.Config, r
 	return err
 }
 
+// CheckForRetryableError checks whether the request can be retried by the classification of the error,
+// the throttled requests, the conflicts and the server errors are retryable.
 func CheckForRetryableError(err error) *resource.RetryError {
-	switch errCode := err.(type) {
-	case golangsdk.ErrDefault500:
+	if IsRetryable(err) {
 		return resource.RetryableError(err)
-	case golangsdk.ErrUnexpectedResponseCode:
-		switch errCode.Actual {
-		case 409, 503:
-			return resource.RetryableError(err)
-		default:
-			return resource.NonRetryableError(err)
-		}
-	default:
-		return resource.NonRetryableError(err)
 	}
+	return resource.NonRetryableError(err)
 }
 
 func WaitOrderComplete(ctx context.Context, d *schema.ResourceData, config *config.Config, orderNum string) error {
@@ -147,29 +137,15 @@ func WaitOrderComplete(ctx context.Context, d *schema.ResourceData, config *conf
 	if err != nil {
 		return fmtp.Errorf("Error creating HuaweiCloud bss V2 client: %s", err)
 	}
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"3"}, // 3: Processing; 6: Pending payment.
-		Target:       []string{"5"}, // 5: Completed.
-		Refresh:      refreshOrderStatus(bssV2Client, orderNum),
-		Timeout:      d.Timeout(schema.TimeoutCreate),
-		Delay:        5 * time.Second,
-		PollInterval: 10 * time.Second,
-	}
-	_, err = stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return fmtp.Errorf("Error while waiting for the order (%s) to complete payment: %#v", orderNum, err)
-	}
-	return nil
+	return waitOrderStatus(ctx, bssV2Client, orderNum, d.Timeout(schema.TimeoutCreate))
 }
 
-func refreshOrderStatus(c *golangsdk.ServiceClient, orderNum string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		r, err := orders.Get(c, orderNum).Extract()
-		if err != nil {
-			return nil, "Error", err
-		}
-		return r, strconv.Itoa(r.OrderInfo.Status), nil
+func waitOrderStatus(ctx context.Context, bssV2Client *golangsdk.ServiceClient, orderNum string,
+	timeout time.Duration) error {
+	if err := waiter.WaitForOrder(ctx, bssV2Client, orderNum, timeout); err != nil {
+		return fmtp.Errorf("Error while waiting for the order (%s) to complete payment: %s", orderNum, err)
 	}
+	return nil
 }
 
 func CaseInsensitiveFunc() schema.SchemaDiffSuppressFunc {
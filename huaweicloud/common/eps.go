@@ -0,0 +1,132 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+// MigrateResourceOpts is the options to migrate a resource to another enterprise project.
+type MigrateResourceOpts struct {
+	// the resource ID
+	ResourceId string `json:"resource_id"`
+	// the resource type in EPS, e.g. ecs, disk, eip, rds, dcs, cce-cluster, bucket
+	ResourceType string `json:"resource_type"`
+	// the region ID, it's the region of the resource by default
+	RegionId string `json:"region_id,omitempty"`
+	// the project ID, it's the project of the region by default
+	ProjectId string `json:"project_id,omitempty"`
+	// whether to migrate the associated resources, e.g. the disks and EIPs of an ECS
+	Associated bool `json:"associated,omitempty"`
+}
+
+type epsResourceFilterOpts struct {
+	ResourceTypes []string `json:"resource_types"`
+	Projects      []string `json:"projects,omitempty"`
+	Offset        int      `json:"offset"`
+	Limit         int      `json:"limit"`
+}
+
+type epsResourceList struct {
+	Resources []struct {
+		ResourceId string `json:"resource_id"`
+	} `json:"resources"`
+	TotalCount int `json:"total_count"`
+}
+
+// the maximum number of the resources in one page of the EPS filter API
+const epsResourceFilterLimit = 1000
+
+// MigrateEnterpriseProject migrates the resource to the enterprise project specified by enterprise_project_id
+// and waits for the resource to appear in the target enterprise project.
+// It is used to update enterprise_project_id in place rather than replacing the resource.
+func MigrateEnterpriseProject(ctx context.Context, d *schema.ResourceData, config *config.Config,
+	opts MigrateResourceOpts) error {
+	targetEpsId := d.Get("enterprise_project_id").(string)
+	if targetEpsId == "" {
+		targetEpsId = "0"
+	}
+
+	region := GetRegion(d, config)
+	epsClient, err := config.EnterpriseProjectClient(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud EPS client: %s", err)
+	}
+
+	if opts.RegionId == "" {
+		opts.RegionId = region
+	}
+	if opts.ProjectId == "" {
+		if opts.ProjectId, err = config.GetProjectID(region); err != nil {
+			return fmtp.Errorf("Error getting the project ID of region %s: %s", region, err)
+		}
+	}
+
+	migrateURL := epsClient.ServiceURL("enterprise-projects", targetEpsId, "resources-migrate")
+	_, err = epsClient.Post(migrateURL, opts, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{204},
+	})
+	if err != nil {
+		return fmtp.Errorf("Error migrating %s (%s) to the enterprise project %s: %s",
+			opts.ResourceType, opts.ResourceId, targetEpsId, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PENDING"},
+		Target:       []string{"SUCCESS"},
+		Refresh:      refreshEpsResourceStatus(epsClient, targetEpsId, opts),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        2 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+		return fmtp.Errorf("Error waiting for %s (%s) to be migrated to the enterprise project %s: %s",
+			opts.ResourceType, opts.ResourceId, targetEpsId, err)
+	}
+
+	logp.Printf("[DEBUG] %s (%s) has been migrated to the enterprise project %s",
+		opts.ResourceType, opts.ResourceId, targetEpsId)
+	return nil
+}
+
+func refreshEpsResourceStatus(client *golangsdk.ServiceClient, epsId string,
+	opts MigrateResourceOpts) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		filterURL := client.ServiceURL("enterprise-projects", epsId, "resources", "filter")
+		filterOpts := epsResourceFilterOpts{
+			ResourceTypes: []string{opts.ResourceType},
+			Limit:         epsResourceFilterLimit,
+		}
+		if opts.ProjectId != "" {
+			filterOpts.Projects = []string{opts.ProjectId}
+		}
+
+		for {
+			var r epsResourceList
+			_, err := client.Post(filterURL, filterOpts, &r, &golangsdk.RequestOpts{
+				OkCodes: []int{200},
+			})
+			if err != nil {
+				return nil, "ERROR", err
+			}
+
+			for _, item := range r.Resources {
+				if item.ResourceId == opts.ResourceId {
+					return item, "SUCCESS", nil
+				}
+			}
+
+			filterOpts.Offset += len(r.Resources)
+			if len(r.Resources) == 0 || filterOpts.Offset >= r.TotalCount {
+				return r, "PENDING", nil
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// ErrorKind is the classification of the errors returned by the cloud services.
+type ErrorKind string
+
+const (
+	ErrorKindUnknown          ErrorKind = "Unknown"
+	ErrorKindNotFound         ErrorKind = "NotFound"
+	ErrorKindConflict         ErrorKind = "Conflict"
+	ErrorKindThrottled        ErrorKind = "Throttled"
+	ErrorKindQuotaExceeded    ErrorKind = "QuotaExceeded"
+	ErrorKindAuth             ErrorKind = "Auth"
+	ErrorKindInvalidParameter ErrorKind = "InvalidParameter"
+	ErrorKindServerError      ErrorKind = "ServerError"
+)
+
+// the short descriptions of the error kinds used in the diagnostic summaries
+var errorKindDescriptions = map[ErrorKind]string{
+	ErrorKindNotFound:         "resource not found",
+	ErrorKindConflict:         "resource conflict",
+	ErrorKindThrottled:        "request throttled",
+	ErrorKindQuotaExceeded:    "quota exceeded",
+	ErrorKindAuth:             "authentication or authorization failed",
+	ErrorKindInvalidParameter: "invalid parameter",
+	ErrorKindServerError:      "server error",
+	ErrorKindUnknown:          "unexpected response",
+}
+
+// the error codes of the API gateway which mean the request is throttled
+var throttledErrorCodes = []string{"APIGW.0308"}
+
+// APIError is the unified error of golangsdk and huaweicloud-sdk-go-v3 responses.
+type APIError struct {
+	Kind         ErrorKind
+	StatusCode   int
+	ErrorCode    string
+	ErrorMessage string
+	// RequestID is required by the support tickets, it's the X-Request-Id header of the huaweicloud-sdk-go-v3
+	// responses, or the request_id in the body of the golangsdk responses as golangsdk does not keep the headers
+	RequestID string
+	// Err is the original error returned by the SDK
+	Err error
+}
+
+func (e *APIError) Error() string {
+	msg := e.Err.Error()
+	if e.RequestID != "" && !strings.Contains(msg, e.RequestID) {
+		msg = fmt.Sprintf("%s (request ID: %s)", msg, e.RequestID)
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// ParseAPIError converts the error responses of golangsdk and huaweicloud-sdk-go-v3 to *APIError,
+// the wrapped errors are also parsed. It returns nil if err is not an error response.
+func ParseAPIError(err error) *APIError {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if apiErr, ok := e.(*APIError); ok {
+			return apiErr
+		}
+		if apiErr := parseSDKError(e); apiErr != nil {
+			apiErr.Err = err
+			apiErr.Kind = classifyAPIError(apiErr)
+			return apiErr
+		}
+	}
+	return nil
+}
+
+func parseSDKError(err error) *APIError {
+	var respErr golangsdk.ErrUnexpectedResponseCode
+	switch e := err.(type) {
+	case *sdkerr.ServiceResponseError:
+		return &APIError{
+			StatusCode:   e.StatusCode,
+			ErrorCode:    e.ErrorCode,
+			ErrorMessage: e.ErrorMessage,
+			RequestID:    e.RequestId,
+		}
+	case golangsdk.ErrUnexpectedResponseCode:
+		respErr = e
+	case *golangsdk.ErrUnexpectedResponseCode:
+		respErr = *e
+	case golangsdk.ErrDefault400:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault401:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault403:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault404:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault405:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault408:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault429:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault500:
+		respErr = e.ErrUnexpectedResponseCode
+	case golangsdk.ErrDefault503:
+		respErr = e.ErrUnexpectedResponseCode
+	default:
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: respErr.Actual}
+	parseErrorBody(respErr.Body, apiErr)
+	return apiErr
+}
+
+// parseErrorBody parses the error code, message and request ID from the response body, the formats are:
+// {"error_code": "xxx", "error_msg": "xxx", "request_id": "xxx"} and {"error": {"code": "xxx", "message": "xxx"}}
+func parseErrorBody(body []byte, apiErr *APIError) {
+	var r struct {
+		ErrorCode string `json:"error_code"`
+		ErrorMsg  string `json:"error_msg"`
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
+		Error     *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return
+	}
+
+	apiErr.RequestID = r.RequestID
+	switch {
+	case r.ErrorCode != "" || r.ErrorMsg != "":
+		apiErr.ErrorCode, apiErr.ErrorMessage = r.ErrorCode, r.ErrorMsg
+	case r.Error != nil:
+		apiErr.ErrorCode, apiErr.ErrorMessage = r.Error.Code, r.Error.Message
+	default:
+		apiErr.ErrorCode, apiErr.ErrorMessage = r.Code, r.Message
+	}
+}
+
+func classifyAPIError(apiErr *APIError) ErrorKind {
+	if utils.StrSliceContains(throttledErrorCodes, apiErr.ErrorCode) {
+		return ErrorKindThrottled
+	}
+
+	code := apiErr.StatusCode
+	// the quota errors are returned with 400, 403 or 409 depending on the service
+	if code == http.StatusBadRequest || code == http.StatusForbidden || code == http.StatusConflict {
+		if strings.Contains(strings.ToLower(apiErr.ErrorMessage), "quota") ||
+			strings.Contains(strings.ToLower(apiErr.ErrorCode), "quota") {
+			return ErrorKindQuotaExceeded
+		}
+	}
+
+	switch {
+	case code == http.StatusNotFound:
+		return ErrorKindNotFound
+	case code == http.StatusConflict:
+		return ErrorKindConflict
+	case code == http.StatusTooManyRequests:
+		return ErrorKindThrottled
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrorKindAuth
+	case code == http.StatusBadRequest:
+		return ErrorKindInvalidParameter
+	case code >= http.StatusInternalServerError:
+		return ErrorKindServerError
+	}
+	return ErrorKindUnknown
+}
+
+// GetErrorKind returns the classification of the error, it's ErrorKindUnknown if err is not an error response.
+func GetErrorKind(err error) ErrorKind {
+	if apiErr := ParseAPIError(err); apiErr != nil {
+		return apiErr.Kind
+	}
+	return ErrorKindUnknown
+}
+
+// IsNotFound returns true if the resource does not exist.
+func IsNotFound(err error) bool {
+	return GetErrorKind(err) == ErrorKindNotFound
+}
+
+// IsConflict returns true if the resource is in a conflicting state, e.g. it's still in use.
+func IsConflict(err error) bool {
+	return GetErrorKind(err) == ErrorKindConflict
+}
+
+// IsThrottled returns true if the request is rejected by the flow control.
+func IsThrottled(err error) bool {
+	return GetErrorKind(err) == ErrorKindThrottled
+}
+
+// IsQuotaExceeded returns true if the quota of the resource is insufficient.
+func IsQuotaExceeded(err error) bool {
+	return GetErrorKind(err) == ErrorKindQuotaExceeded
+}
+
+// IsAuthError returns true if the authentication or authorization fails.
+func IsAuthError(err error) bool {
+	return GetErrorKind(err) == ErrorKindAuth
+}
+
+// IsInvalidParameter returns true if the request parameters are invalid.
+func IsInvalidParameter(err error) bool {
+	return GetErrorKind(err) == ErrorKindInvalidParameter
+}
+
+// IsRetryable returns true if the request may succeed when it's sent again.
+func IsRetryable(err error) bool {
+	switch GetErrorKind(err) {
+	case ErrorKindThrottled, ErrorKindConflict, ErrorKindServerError:
+		return true
+	}
+	return false
+}
+
+// DiagAPIError builds the error diagnostic of err, the summary is built from format and a, and the detail includes
+// the error message, the HTTP status, the error code and the request ID of the response.
+func DiagAPIError(err error, format string, a ...interface{}) diag.Diagnostics {
+	summary := fmt.Sprintf(utils.BuildNewFormatByConfig(format), a...)
+	apiErr := ParseAPIError(err)
+	if apiErr == nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s: %s", summary, err),
+			},
+		}
+	}
+
+	message := apiErr.ErrorMessage
+	if message == "" {
+		message = apiErr.Err.Error()
+	}
+	details := []string{message, "", fmt.Sprintf("HTTP status: %d", apiErr.StatusCode)}
+	if apiErr.ErrorCode != "" {
+		details = append(details, fmt.Sprintf("Error code: %s", apiErr.ErrorCode))
+	}
+	if apiErr.RequestID != "" {
+		details = append(details, fmt.Sprintf("Request ID: %s", apiErr.RequestID))
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s: %s", summary, errorKindDescriptions[apiErr.Kind]),
+			Detail:   strings.Join(details, "\n"),
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+)
+
+func unexpectedResponse(statusCode int, body string) golangsdk.ErrUnexpectedResponseCode {
+	return golangsdk.ErrUnexpectedResponseCode{
+		URL:      "https://ecs.example.com/v1/project/cloudservers/server-1",
+		Method:   "GET",
+		Expected: []int{200},
+		Actual:   statusCode,
+		Body:     []byte(body),
+	}
+}
+
+func TestParseAPIError_kinds(t *testing.T) {
+	cases := []struct {
+		err  error
+		kind ErrorKind
+	}{
+		{golangsdk.ErrDefault404{ErrUnexpectedResponseCode: unexpectedResponse(404, `{}`)}, ErrorKindNotFound},
+		{unexpectedResponse(409, `{"error_code": "VPC.0111", "error_msg": "the VPC is in use"}`), ErrorKindConflict},
+		{golangsdk.ErrDefault429{ErrUnexpectedResponseCode: unexpectedResponse(429, `{}`)}, ErrorKindThrottled},
+		{unexpectedResponse(400, `{"error_code": "APIGW.0308", "error_msg": "the request is throttled"}`),
+			ErrorKindThrottled},
+		{unexpectedResponse(403, `{"error": {"code": "Ecs.0605", "message": "Insufficient quota of instances"}}`),
+			ErrorKindQuotaExceeded},
+		{golangsdk.ErrDefault404{ErrUnexpectedResponseCode: unexpectedResponse(404,
+			`{"error_code": "VPC.0601", "error_msg": "the quota rule does not exist"}`)}, ErrorKindNotFound},
+		{golangsdk.ErrDefault401{ErrUnexpectedResponseCode: unexpectedResponse(401, ``)}, ErrorKindAuth},
+		{golangsdk.ErrDefault400{ErrUnexpectedResponseCode: unexpectedResponse(400, `{}`)}, ErrorKindInvalidParameter},
+		{golangsdk.ErrDefault503{ErrUnexpectedResponseCode: unexpectedResponse(503, `{}`)}, ErrorKindServerError},
+		{&sdkerr.ServiceResponseError{StatusCode: 404, ErrorCode: "DCS.4001"}, ErrorKindNotFound},
+		{fmt.Errorf("connection refused"), ErrorKindUnknown},
+	}
+
+	for i, c := range cases {
+		if kind := GetErrorKind(c.err); kind != c.kind {
+			t.Errorf("case %d: expect the kind %s, but got %s", i, c.kind, kind)
+		}
+	}
+}
+
+func TestParseAPIError_wrapped(t *testing.T) {
+	body := `{"error_code": "Ecs.0114", "error_msg": "Instance not found", "request_id": "req-1"}`
+	err := fmt.Errorf("Error retrieving the instance: %w",
+		golangsdk.ErrDefault404{ErrUnexpectedResponseCode: unexpectedResponse(404, body)})
+
+	apiErr := ParseAPIError(err)
+	if apiErr == nil {
+		t.Fatalf("expect the wrapped error to be parsed")
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.ErrorCode != "Ecs.0114" ||
+		apiErr.ErrorMessage != "Instance not found" || apiErr.RequestID != "req-1" {
+		t.Fatalf("unexpected API error: %#v", apiErr)
+	}
+	if !IsNotFound(err) || IsRetryable(err) {
+		t.Fatalf("expect the error to be a non-retryable NotFound error")
+	}
+}
+
+func TestParseAPIError_requestIDHeader(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusConflict,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"error_code": "VPC.0111", "error_msg": "the VPC is in use"}`)),
+	}
+	resp.Header.Set("X-Request-Id", "req-3")
+
+	apiErr := ParseAPIError(sdkerr.NewServiceResponseError(resp))
+	if apiErr == nil || apiErr.RequestID != "req-3" || apiErr.ErrorCode != "VPC.0111" {
+		t.Fatalf("expect the request ID to be read from the header, but got %#v", apiErr)
+	}
+}
+
+func TestDiagAPIError(t *testing.T) {
+	err := &sdkerr.ServiceResponseError{
+		StatusCode:   409,
+		RequestId:    "req-2",
+		ErrorCode:    "DCS.4007",
+		ErrorMessage: "the instance is being modified",
+	}
+
+	diags := DiagAPIError(err, "Error updating DCS instance (%s)", "dcs-1")
+	if len(diags) != 1 || diags[0].Severity != diag.Error {
+		t.Fatalf("expect one error diagnostic, but got %#v", diags)
+	}
+	if diags[0].Summary != "Error updating DCS instance (dcs-1): resource conflict" {
+		t.Errorf("unexpected summary: %s", diags[0].Summary)
+	}
+	for _, expected := range []string{"the instance is being modified", "HTTP status: 409", "Error code: DCS.4007",
+		"Request ID: req-2"} {
+		if !strings.Contains(diags[0].Detail, expected) {
+			t.Errorf("expect %q in the detail: %s", expected, diags[0].Detail)
+		}
+	}
+}
+
+func TestCheckForRetryableError(t *testing.T) {
+	retryable := []error{
+		unexpectedResponse(409, `{}`),
+		golangsdk.ErrDefault429{ErrUnexpectedResponseCode: unexpectedResponse(429, `{}`)},
+		golangsdk.ErrDefault500{ErrUnexpectedResponseCode: unexpectedResponse(500, `{}`)},
+		golangsdk.ErrDefault503{ErrUnexpectedResponseCode: unexpectedResponse(503, `{}`)},
+	}
+	for _, err := range retryable {
+		if !CheckForRetryableError(err).Retryable {
+			t.Errorf("expect the error to be retryable: %s", err)
+		}
+	}
+
+	nonRetryable := []error{
+		unexpectedResponse(409, `{"error_code": "VPC.0108", "error_msg": "Quota exceeded for resources"}`),
+		golangsdk.ErrDefault400{ErrUnexpectedResponseCode: unexpectedResponse(400, `{}`)},
+		fmt.Errorf("connection refused"),
+	}
+	for _, err := range nonRetryable {
+		if CheckForRetryableError(err).Retryable {
+			t.Errorf("expect the error to be non-retryable: %s", err)
+		}
+	}
+}
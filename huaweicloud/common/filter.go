@@ -0,0 +1,105 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// the filter name prefix used to match the tags, e.g. "tags.foo"
+const filterTagsPrefix = "tags."
+
+// FilterSchema returns the schema of the `filter` blocks shared by the list data sources.
+// The names are the attributes which can be filtered, if "tags" is one of them, the tags can be
+// filtered by the name `tags.<key>`.
+// The values of a block are ORed and the blocks are ANDed, e.g. a range of vCPUs can be specified
+// by two blocks with `match_by` ge and le.
+func FilterSchema(names []string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateFilterName(names),
+				},
+				"values": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"match_by": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      utils.FilterMatchByExact,
+					ValidateFunc: validation.StringInSlice(utils.FilterMatchRules, false),
+				},
+				"negate": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+func validateFilterName(names []string) schema.SchemaValidateFunc {
+	var supportTags bool
+	for _, name := range names {
+		if name == "tags" {
+			supportTags = true
+		}
+	}
+
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if supportTags && strings.HasPrefix(value, filterTagsPrefix) && len(value) > len(filterTagsPrefix) {
+			return
+		}
+		for _, name := range names {
+			if value == name && name != "tags" {
+				return
+			}
+		}
+
+		errors = append(errors, fmt.Errorf("expected %s to be one of %v, got %s", k, names, value))
+		return
+	}
+}
+
+// ExpandFilterRules builds the rules of utils.FilterSliceWithRules from the `filter` blocks.
+// The fields map the filter names to the struct fields of the objects to filter, e.g. "vcpus": "Vcpus";
+// if fields is nil, the objects are maps and the filter names are used as the keys.
+func ExpandFilterRules(d *schema.ResourceData, fields map[string]string) []utils.FilterRule {
+	rawFilters := d.Get("filter").([]interface{})
+	rules := make([]utils.FilterRule, 0, len(rawFilters))
+	for _, raw := range rawFilters {
+		filter := raw.(map[string]interface{})
+		name := filter["name"].(string)
+
+		field := name
+		if fields != nil {
+			if strings.HasPrefix(name, filterTagsPrefix) {
+				field = fields["tags"] + "." + strings.TrimPrefix(name, filterTagsPrefix)
+			} else {
+				field = fields[name]
+			}
+		}
+
+		rules = append(rules, utils.FilterRule{
+			Field:   field,
+			Values:  utils.ExpandToStringList(filter["values"].([]interface{})),
+			MatchBy: filter["match_by"].(string),
+			Negate:  filter["negate"].(bool),
+		})
+	}
+	return rules
+}
@@ -0,0 +1,123 @@
+package plancheck
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dcsflavors "github.com/chnsz/golangsdk/openstack/dcs/v2/flavors"
+	rdsflavors "github.com/chnsz/golangsdk/openstack/rds/v3/flavors"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// CheckRdsFlavor checks whether the RDS flavor supports the DB engine version and it's on sale in all of the
+// availability zones.
+func CheckRdsFlavor(conf *config.Config, region, dbType, dbVersion, flavorCode string, azs []string) error {
+	if flavorCode == "" || dbType == "" {
+		return nil
+	}
+
+	key := fmt.Sprintf("rds_flavors/%s/%s/%s", region, dbType, dbVersion)
+	value, ok := load(conf, key, func() (interface{}, error) {
+		client, err := conf.RdsV3Client(region)
+		if err != nil {
+			return nil, err
+		}
+		listOpts := rdsflavors.DbFlavorsOpts{Versionname: dbVersion}
+		pages, err := rdsflavors.List(client, listOpts, dbType).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := rdsflavors.ExtractDbFlavors(pages)
+		return resp.Flavorslist, err
+	})
+	if !ok {
+		return nil
+	}
+
+	allFlavors := value.([]rdsflavors.Flavors)
+	codes := make([]string, len(allFlavors))
+	for i, flavor := range allFlavors {
+		codes[i] = flavor.Speccode
+		if flavor.Speccode != flavorCode {
+			continue
+		}
+
+		validZones := make([]string, 0, len(flavor.Azstatus))
+		for az, status := range flavor.Azstatus {
+			if status == "normal" {
+				validZones = append(validZones, az)
+			}
+		}
+		sort.Strings(validZones)
+
+		for _, az := range azs {
+			if !utils.StrSliceContains(validZones, az) {
+				return unavailableZoneError("RDS flavor", flavorCode, az, validZones)
+			}
+		}
+		return nil
+	}
+
+	return notFoundError(fmt.Sprintf("RDS flavor of %s %s", dbType, dbVersion), flavorCode, region, codes)
+}
+
+// CheckDcsFlavor checks whether the DCS flavor supports the engine and capacity, and it's on sale in all of the
+// availability zones.
+func CheckDcsFlavor(conf *config.Config, region, engine string, capacity float64, flavorCode string,
+	azs []string) error {
+	if flavorCode == "" || engine == "" {
+		return nil
+	}
+
+	value, ok := load(conf, fmt.Sprintf("dcs_flavors/%s/%s", region, engine), func() (interface{}, error) {
+		client, err := conf.DcsV2Client(region)
+		if err != nil {
+			return nil, err
+		}
+		return dcsflavors.List(client, dcsflavors.ListOpts{Engine: engine}).Extract()
+	})
+	if !ok {
+		return nil
+	}
+
+	capacityStr := strconv.FormatFloat(capacity, 'f', -1, 64)
+	allFlavors := value.([]dcsflavors.Flavor)
+	codes := make([]string, 0, len(allFlavors))
+	for _, flavor := range allFlavors {
+		if !utils.StrSliceContains(flavor.Capacity, capacityStr) {
+			continue
+		}
+		codes = append(codes, flavor.SpecCode)
+		if flavor.SpecCode != flavorCode {
+			continue
+		}
+
+		var validZones []string
+		for _, item := range flavor.AvailableZones {
+			if item.Capacity == capacityStr || len(flavor.AvailableZones) == 1 {
+				validZones = item.AzCodes
+			}
+		}
+		for _, az := range azs {
+			if !utils.StrSliceContains(validZones, az) {
+				return unavailableZoneError("DCS flavor", flavorCode, az, validZones)
+			}
+		}
+		return nil
+	}
+
+	return notFoundError(fmt.Sprintf("DCS flavor of %s with capacity %s GB", engine, capacityStr),
+		flavorCode, region, codes)
+}
+
+func unavailableZoneError(kind, flavorCode, az string, validZones []string) error {
+	if len(validZones) == 0 {
+		return fmt.Errorf("the %s %q is not available in any availability zone", kind, flavorCode)
+	}
+	return fmt.Errorf("the %s %q is not available in availability zone %s, it's available in: %s",
+		kind, flavorCode, az, strings.Join(validZones, ", "))
+}
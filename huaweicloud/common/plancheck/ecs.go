@@ -0,0 +1,243 @@
+package plancheck
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chnsz/golangsdk/openstack/compute/v2/extensions/availabilityzones"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/flavors"
+	"github.com/chnsz/golangsdk/openstack/ims/v2/cloudimages"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+// the operation status of the ECS flavors which can not be used to create instances
+var unavailableFlavorStatus = []string{"abandon", "sellout", "obt_sellout"}
+
+// ecsLimits is the absolute quotas of the ECS instances, -1 means unlimited
+type ecsLimits struct {
+	MaxTotalInstances  int `json:"maxTotalInstances"`
+	TotalInstancesUsed int `json:"totalInstancesUsed"`
+	MaxTotalCores      int `json:"maxTotalCores"`
+	TotalCoresUsed     int `json:"totalCoresUsed"`
+	MaxTotalRAMSize    int `json:"maxTotalRAMSize"`
+	TotalRAMUsed       int `json:"totalRAMUsed"`
+}
+
+func computeFlavors(conf *config.Config, region string) ([]flavors.Flavor, bool) {
+	value, ok := load(conf, "ecs_flavors/"+region, func() (interface{}, error) {
+		client, err := conf.ComputeV1Client(region)
+		if err != nil {
+			return nil, err
+		}
+		pages, err := flavors.List(client, flavors.ListOpts{}).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		return flavors.ExtractFlavors(pages)
+	})
+	if !ok {
+		return nil, false
+	}
+	return value.([]flavors.Flavor), true
+}
+
+func computeAvailabilityZones(conf *config.Config, region string) ([]string, bool) {
+	value, ok := load(conf, "ecs_availability_zones/"+region, func() (interface{}, error) {
+		client, err := conf.ComputeV2Client(region)
+		if err != nil {
+			return nil, err
+		}
+		pages, err := availabilityzones.List(client).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		zones, err := availabilityzones.ExtractAvailabilityZones(pages)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(zones))
+		for _, zone := range zones {
+			if zone.ZoneState.Available {
+				names = append(names, zone.ZoneName)
+			}
+		}
+		return names, nil
+	})
+	if !ok {
+		return nil, false
+	}
+	return value.([]string), true
+}
+
+// flavorAzStatus returns the operation status of the flavor in the availability zone, the format of the operation
+// AZs is "cn-north-4a(normal),cn-north-4b(sellout)" and the flavor status is used if the AZ is not in the list.
+func flavorAzStatus(flavor flavors.Flavor, az string) string {
+	for _, item := range strings.Split(flavor.OsExtraSpecs.OperationAz, ",") {
+		item = strings.TrimSpace(item)
+		if strings.HasPrefix(item, az+"(") && strings.HasSuffix(item, ")") {
+			return strings.TrimSuffix(strings.TrimPrefix(item, az+"("), ")")
+		}
+	}
+	return flavor.OsExtraSpecs.OperationStatus
+}
+
+func isFlavorAvailable(flavor flavors.Flavor, az string) bool {
+	if az == "" {
+		return !utils.StrSliceContains(unavailableFlavorStatus, flavor.OsExtraSpecs.OperationStatus)
+	}
+	return !utils.StrSliceContains(unavailableFlavorStatus, flavorAzStatus(flavor, az))
+}
+
+// CheckComputeAvailabilityZone checks whether the ECS availability zone is available.
+func CheckComputeAvailabilityZone(conf *config.Config, region, az string) error {
+	if az == "" {
+		return nil
+	}
+
+	zones, ok := computeAvailabilityZones(conf, region)
+	if !ok || utils.StrSliceContains(zones, az) {
+		return nil
+	}
+	return notFoundError("availability zone", az, region, zones)
+}
+
+// CheckComputeFlavor checks whether the ECS flavor exists and it's on sale in the availability zone,
+// the flavor is matched by its ID or name. The AZ is ignored if it's empty.
+func CheckComputeFlavor(conf *config.Config, region, flavorID, az string) error {
+	if flavorID == "" {
+		return nil
+	}
+
+	allFlavors, ok := computeFlavors(conf, region)
+	if !ok {
+		return nil
+	}
+
+	var flavor *flavors.Flavor
+	available := make([]string, 0, len(allFlavors))
+	for i, f := range allFlavors {
+		if f.ID == flavorID || f.Name == flavorID {
+			flavor = &allFlavors[i]
+		}
+		if isFlavorAvailable(f, az) {
+			available = append(available, f.Name)
+		}
+	}
+
+	if flavor == nil {
+		return notFoundError("flavor", flavorID, region, available)
+	}
+	if isFlavorAvailable(*flavor, az) {
+		return nil
+	}
+
+	if az == "" {
+		return fmt.Errorf("the flavor %q is %s in region %s, the similar valid values are: %s",
+			flavorID, flavor.OsExtraSpecs.OperationStatus, region, suggest(flavorID, available))
+	}
+
+	zones, _ := computeAvailabilityZones(conf, region)
+	validZones := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if isFlavorAvailable(*flavor, zone) {
+			validZones = append(validZones, zone)
+		}
+	}
+	msg := fmt.Sprintf("the flavor %q is %s in availability zone %s", flavorID, flavorAzStatus(*flavor, az), az)
+	if len(validZones) > 0 {
+		msg += fmt.Sprintf(", it's available in: %s", strings.Join(validZones, ", "))
+	}
+	if len(available) > 0 {
+		msg += fmt.Sprintf(", the similar flavors available in %s are: %s", az, suggest(flavorID, available))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// CheckImage checks whether the IMS image exists, the image is queried by the ID or the name.
+func CheckImage(conf *config.Config, region, imageID, imageName string) error {
+	if imageID == "" && imageName == "" {
+		return nil
+	}
+
+	key := fmt.Sprintf("ims_images/%s/%s/%s", region, imageID, imageName)
+	value, ok := load(conf, key, func() (interface{}, error) {
+		client, err := conf.ImageV2Client(region)
+		if err != nil {
+			return nil, err
+		}
+		listOpts := &cloudimages.ListOpts{
+			ID:                  imageID,
+			Name:                imageName,
+			Limit:               1,
+			EnterpriseProjectID: "all_granted_eps",
+		}
+		pages, err := cloudimages.List(client, listOpts).AllPages()
+		if err != nil {
+			return nil, err
+		}
+		return cloudimages.ExtractImages(pages)
+	})
+	if !ok || len(value.([]cloudimages.Image)) > 0 {
+		return nil
+	}
+
+	if imageID != "" {
+		return fmt.Errorf("the image %q is not found in region %s", imageID, region)
+	}
+	return fmt.Errorf("the image named %q is not found in region %s", imageName, region)
+}
+
+// CheckComputeQuota checks whether the remaining ECS quotas are enough for the instances of the flavor.
+// The quotas are checked for each resource separately, the instances created in the same plan are not accumulated.
+func CheckComputeQuota(conf *config.Config, region, flavorID string, count int) error {
+	value, ok := load(conf, "ecs_limits/"+region, func() (interface{}, error) {
+		client, err := conf.ComputeV1Client(region)
+		if err != nil {
+			return nil, err
+		}
+		var r struct {
+			Absolute ecsLimits `json:"absolute"`
+		}
+		_, err = client.Get(client.ServiceURL("cloudservers", "limits"), &r, nil)
+		return &r.Absolute, err
+	})
+	if !ok {
+		return nil
+	}
+	limits := value.(*ecsLimits)
+
+	if exceedsQuota(limits.MaxTotalInstances, limits.TotalInstancesUsed, count) {
+		return fmt.Errorf("the quota of ECS instances in region %s is insufficient: %d used of %d, %d requested",
+			region, limits.TotalInstancesUsed, limits.MaxTotalInstances, count)
+	}
+
+	allFlavors, ok := computeFlavors(conf, region)
+	if !ok {
+		return nil
+	}
+	for _, flavor := range allFlavors {
+		if flavor.ID != flavorID && flavor.Name != flavorID {
+			continue
+		}
+
+		vcpus, _ := strconv.Atoi(flavor.Vcpus)
+		if exceedsQuota(limits.MaxTotalCores, limits.TotalCoresUsed, vcpus*count) {
+			return fmt.Errorf("the quota of ECS vCPUs in region %s is insufficient: %d used of %d, %d requested",
+				region, limits.TotalCoresUsed, limits.MaxTotalCores, vcpus*count)
+		}
+		ram := int(flavor.Ram) * count
+		if exceedsQuota(limits.MaxTotalRAMSize, limits.TotalRAMUsed, ram) {
+			return fmt.Errorf("the quota of ECS memory in region %s is insufficient: %d MB used of %d MB, "+
+				"%d MB requested", region, limits.TotalRAMUsed, limits.MaxTotalRAMSize, ram)
+		}
+	}
+	return nil
+}
+
+func exceedsQuota(max, used, requested int) bool {
+	return max >= 0 && used+requested > max
+}
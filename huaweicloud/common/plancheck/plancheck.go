@@ -0,0 +1,157 @@
+// Package plancheck provides the opt-in plan-time validations of the flavors, availability zones, images and quotas.
+// The validations are enabled by the provider argument enable_plan_validation, and the data queried from the cloud
+// services is cached in the provider config, so it's queried at most once per plan.
+package plancheck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+// the maximum number of the valid alternatives in the error messages
+const maxSuggestions = 5
+
+// CheckFunc validates the planned changes of a resource.
+type CheckFunc func(ctx context.Context, d *schema.ResourceDiff, conf *config.Config) error
+
+// CustomizeDiff returns a CustomizeDiffFunc which runs the checks in order if the plan validation is enabled.
+func CustomizeDiff(checks ...CheckFunc) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		conf, ok := meta.(*config.Config)
+		if !ok || !conf.EnablePlanValidation {
+			return nil
+		}
+
+		for _, check := range checks {
+			if err := check(ctx, d, conf); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// GetRegion returns the region of the planned resource, the provider region is used if it's not specified.
+func GetRegion(d *schema.ResourceDiff, conf *config.Config) string {
+	if v, ok := d.GetOk("region"); ok && d.NewValueKnown("region") {
+		return v.(string)
+	}
+	return conf.Region
+}
+
+// ShouldValidate returns true if the resource is being created or any of the keys is changed.
+func ShouldValidate(d *schema.ResourceDiff, keys ...string) bool {
+	if d.Id() == "" {
+		return true
+	}
+	for _, key := range keys {
+		if d.HasChange(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetKnownString returns the planned value of the key, it's empty if the value is unknown during the plan,
+// e.g. it's a computed attribute or it refers to a resource which is not created yet.
+func GetKnownString(d *schema.ResourceDiff, key string) string {
+	if !d.NewValueKnown(key) {
+		return ""
+	}
+	return d.Get(key).(string)
+}
+
+// GetKnownStringList returns the planned string list of the key, it's nil if any of the values is unknown.
+func GetKnownStringList(d *schema.ResourceDiff, key string) []string {
+	if !d.NewValueKnown(key) {
+		return nil
+	}
+
+	raw := d.Get(key).([]interface{})
+	result := make([]string, 0, len(raw))
+	for i, v := range raw {
+		if !d.NewValueKnown(fmt.Sprintf("%s.%d", key, i)) {
+			return nil
+		}
+		if s, ok := v.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// load queries the data by the loader and caches it, ok is false if the query fails. The plan validation is skipped
+// in that case, so that an unavailable API will not block the plan.
+func load(conf *config.Config, key string, loader func() (interface{}, error)) (value interface{}, ok bool) {
+	value, err := conf.PlanDataCache.Load(key, loader)
+	if err != nil {
+		logp.Printf("[WARN] skip the plan validation because failed to query %s: %s", key, err)
+		return nil, false
+	}
+	return value, true
+}
+
+// suggest returns at most maxSuggestions candidates which are the most similar to the value
+func suggest(value string, candidates []string) string {
+	sorted := make([]string, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		di, dj := levenshtein(value, sorted[i]), levenshtein(value, sorted[j])
+		if di != dj {
+			return di < dj
+		}
+		return sorted[i] < sorted[j]
+	})
+
+	if len(sorted) > maxSuggestions {
+		return fmt.Sprintf("%s and %d more", strings.Join(sorted[:maxSuggestions], ", "), len(sorted)-maxSuggestions)
+	}
+	return strings.Join(sorted, ", ")
+}
+
+// notFoundError builds the error of an invalid value with the valid alternatives
+func notFoundError(kind, value, region string, candidates []string) error {
+	if len(candidates) == 0 {
+		return fmt.Errorf("the %s %q is not available in region %s", kind, value, region)
+	}
+	return fmt.Errorf("the %s %q is not available in region %s, the similar valid values are: %s",
+		kind, value, region, suggest(value, candidates))
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
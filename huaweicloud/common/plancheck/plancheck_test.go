@@ -0,0 +1,191 @@
+package plancheck
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	dcsflavors "github.com/chnsz/golangsdk/openstack/dcs/v2/flavors"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/flavors"
+	rdsflavors "github.com/chnsz/golangsdk/openstack/rds/v3/flavors"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const testRegion = "cn-north-4"
+
+func testFlavor(name, vcpus string, ram int64, status, operationAz string) flavors.Flavor {
+	flavor := flavors.Flavor{ID: name, Name: name, Vcpus: vcpus, Ram: ram}
+	flavor.OsExtraSpecs.OperationStatus = status
+	flavor.OsExtraSpecs.OperationAz = operationAz
+	return flavor
+}
+
+// testConfig returns a config whose cache is filled with the data, so no requests will be sent
+func testConfig(t *testing.T, data map[string]interface{}) *config.Config {
+	conf := &config.Config{
+		Region:               testRegion,
+		EnablePlanValidation: true,
+		PlanDataCache:        config.NewPlanDataCache(),
+	}
+	for key, value := range data {
+		v := value
+		if _, err := conf.PlanDataCache.Load(key, func() (interface{}, error) { return v, nil }); err != nil {
+			t.Fatalf("failed to fill the cache: %s", err)
+		}
+	}
+	return conf
+}
+
+func defaultECSConfig(t *testing.T) *config.Config {
+	return testConfig(t, map[string]interface{}{
+		"ecs_flavors/cn-north-4": []flavors.Flavor{
+			testFlavor("s6.large.2", "2", 4096, "normal", "cn-north-4a(normal),cn-north-4b(sellout)"),
+			testFlavor("s6.large.4", "2", 8192, "normal", ""),
+			testFlavor("c6.large.2", "2", 4096, "normal", ""),
+			testFlavor("s3.large.2", "2", 4096, "abandon", ""),
+		},
+		"ecs_availability_zones/cn-north-4": []string{"cn-north-4a", "cn-north-4b", "cn-north-4c"},
+		"ecs_limits/cn-north-4": &ecsLimits{
+			MaxTotalInstances: 10, TotalInstancesUsed: 9,
+			MaxTotalCores: 20, TotalCoresUsed: 16,
+			MaxTotalRAMSize: -1, TotalRAMUsed: 65536,
+		},
+	})
+}
+
+func assertErrorContains(t *testing.T, err error, expected ...string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expect an error contains %q, but got nil", expected)
+	}
+	for _, s := range expected {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("expect %q in the error: %s", s, err)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"c6.large.2", "s6.large.4", "s6.xlarge.2", "s6.large.2", "m6.large.8", "s6.2xlarge.2",
+		"s6.small.1"}
+	result := suggest("s6.lage.2", candidates)
+	if !strings.HasPrefix(result, "s6.large.2, c6.large.2, s6.large.4") || !strings.HasSuffix(result, "and 2 more") {
+		t.Fatalf("unexpected suggestions: %s", result)
+	}
+}
+
+func TestCheckComputeFlavor(t *testing.T) {
+	conf := defaultECSConfig(t)
+
+	if err := CheckComputeFlavor(conf, testRegion, "s6.large.2", "cn-north-4a"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// the status of the flavor is used if the AZ is not in the operation AZs
+	if err := CheckComputeFlavor(conf, testRegion, "s6.large.4", "cn-north-4c"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := CheckComputeFlavor(conf, testRegion, "s6.lage.2", "")
+	assertErrorContains(t, err, `the flavor "s6.lage.2" is not available in region cn-north-4`,
+		"s6.large.2, c6.large.2, s6.large.4")
+
+	err = CheckComputeFlavor(conf, testRegion, "s6.large.2", "cn-north-4b")
+	assertErrorContains(t, err, "is sellout in availability zone cn-north-4b", "it's available in: cn-north-4a, cn-north-4c")
+
+	err = CheckComputeFlavor(conf, testRegion, "s3.large.2", "")
+	assertErrorContains(t, err, `the flavor "s3.large.2" is abandon`)
+}
+
+func TestCheckComputeAvailabilityZone(t *testing.T) {
+	conf := defaultECSConfig(t)
+
+	if err := CheckComputeAvailabilityZone(conf, testRegion, "cn-north-4a"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err := CheckComputeAvailabilityZone(conf, testRegion, "cn-north-4d")
+	assertErrorContains(t, err, "cn-north-4a, cn-north-4b, cn-north-4c")
+}
+
+func TestCheckComputeQuota(t *testing.T) {
+	conf := defaultECSConfig(t)
+
+	if err := CheckComputeQuota(conf, testRegion, "s6.large.2", 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err := CheckComputeQuota(conf, testRegion, "s6.large.2", 2)
+	assertErrorContains(t, err, "the quota of ECS instances", "9 used of 10, 2 requested")
+
+	conf = defaultECSConfig(t)
+	limits, _ := conf.PlanDataCache.Load("ecs_limits/cn-north-4", nil)
+	limits.(*ecsLimits).TotalCoresUsed = 19
+	err = CheckComputeQuota(conf, testRegion, "s6.large.2", 1)
+	assertErrorContains(t, err, "the quota of ECS vCPUs", "19 used of 20, 2 requested")
+}
+
+func TestCheck_queryFailed(t *testing.T) {
+	conf := testConfig(t, nil)
+	for _, key := range []string{"ecs_flavors/cn-north-4", "ecs_availability_zones/cn-north-4"} {
+		_, _ = conf.PlanDataCache.Load(key, func() (interface{}, error) {
+			return nil, fmt.Errorf("the request is throttled")
+		})
+	}
+
+	// the validation is skipped if the data can not be queried
+	if err := CheckComputeFlavor(conf, testRegion, "s6.lage.2", "cn-north-4d"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := CheckComputeAvailabilityZone(conf, testRegion, "cn-north-4d"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestCheckRdsFlavor(t *testing.T) {
+	conf := testConfig(t, map[string]interface{}{
+		"rds_flavors/cn-north-4/MySQL/8.0": []rdsflavors.Flavors{
+			{Speccode: "rds.mysql.n1.large.2", Azstatus: map[string]string{"cn-north-4a": "normal",
+				"cn-north-4b": "sellout", "cn-north-4c": "normal"}},
+			{Speccode: "rds.mysql.n1.large.4", Azstatus: map[string]string{"cn-north-4a": "normal"}},
+		},
+	})
+
+	azs := []string{"cn-north-4a", "cn-north-4c"}
+	if err := CheckRdsFlavor(conf, testRegion, "MySQL", "8.0", "rds.mysql.n1.large.2", azs); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := CheckRdsFlavor(conf, testRegion, "MySQL", "8.0", "rds.mysql.n1.large.2", []string{"cn-north-4b"})
+	assertErrorContains(t, err, "not available in availability zone cn-north-4b", "cn-north-4a, cn-north-4c")
+
+	err = CheckRdsFlavor(conf, testRegion, "MySQL", "8.0", "rds.mysql.large.2", azs)
+	assertErrorContains(t, err, "RDS flavor of MySQL 8.0", "rds.mysql.n1.large.2")
+}
+
+func TestCheckDcsFlavor(t *testing.T) {
+	conf := testConfig(t, map[string]interface{}{
+		"dcs_flavors/cn-north-4/Redis": []dcsflavors.Flavor{
+			{
+				SpecCode: "redis.ha.xu1.large.r2.2",
+				Capacity: []string{"2"},
+				AvailableZones: []dcsflavors.FlavorAzObject{
+					{Capacity: "2", AzCodes: []string{"cn-north-4a", "cn-north-4c"}},
+				},
+			},
+			{
+				SpecCode: "redis.ha.xu1.large.r2.4",
+				Capacity: []string{"4"},
+			},
+		},
+	})
+
+	if err := CheckDcsFlavor(conf, testRegion, "Redis", 2, "redis.ha.xu1.large.r2.2", []string{"cn-north-4a"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err := CheckDcsFlavor(conf, testRegion, "Redis", 2, "redis.ha.xu1.large.r2.2", []string{"cn-north-4b"})
+	assertErrorContains(t, err, "not available in availability zone cn-north-4b", "cn-north-4a, cn-north-4c")
+
+	// the flavor does not support the capacity
+	err = CheckDcsFlavor(conf, testRegion, "Redis", 2, "redis.ha.xu1.large.r2.4", nil)
+	assertErrorContains(t, err, "DCS flavor of Redis with capacity 2 GB", "redis.ha.xu1.large.r2.2")
+}
@@ -0,0 +1,87 @@
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Job is the asynchronous job shared by the APIs of ECS, EVS, VPC and IMS.
+type Job struct {
+	ID     string `json:"job_id"`
+	Type   string `json:"job_type"`
+	Status string `json:"status"`
+	// ErrorCode and FailReason are returned when the job fails
+	ErrorCode  string      `json:"error_code"`
+	FailReason string      `json:"fail_reason"`
+	Entities   JobEntities `json:"entities"`
+}
+
+// JobEntities is the objects of a job, the sub-jobs are returned when the job operates on several objects.
+type JobEntities struct {
+	SubJobs []Job `json:"sub_jobs"`
+	// ImageID is the image created by the IMS job
+	ImageID string `json:"image_id"`
+	// SubJobsResult is the data disk images created together with the system disk image by the IMS job
+	SubJobsResult []Job `json:"sub_jobs_result"`
+}
+
+// the states of the ECS, EVS, VPC and IMS jobs
+var (
+	jobPendingStates = []string{"INIT", "RUNNING", "PENDING_PAYMENT"}
+	jobTargetStates  = []string{"SUCCESS"}
+	jobFailedStates  = []string{"FAIL"}
+)
+
+// WaitForJob waits for the ECS, EVS, VPC or IMS job to succeed and returns the job detail.
+// The job is queried by the path "jobs/{job_id}" of the client, so the v1 client of the service should be used.
+func WaitForJob(ctx context.Context, client *golangsdk.ServiceClient, jobID string,
+	timeout time.Duration) (*Job, error) {
+	conf := &Conf{
+		Description:  fmt.Sprintf("job (%s)", jobID),
+		Pending:      jobPendingStates,
+		Target:       jobTargetStates,
+		Failed:       jobFailedStates,
+		Refresh:      refreshJob(client, jobID),
+		FailReason:   jobFailReason,
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+
+	result, err := conf.WaitForState(ctx)
+	if job, ok := result.(*Job); ok {
+		return job, err
+	}
+	return nil, err
+}
+
+func refreshJob(client *golangsdk.ServiceClient, jobID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		var job Job
+		_, err := client.Get(client.ServiceURL("jobs", jobID), &job, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		return &job, job.Status, nil
+	}
+}
+
+// jobFailReason returns the fail reason of the job, or the first failed sub-job if the job has none
+func jobFailReason(result interface{}) string {
+	job, ok := result.(*Job)
+	if !ok {
+		return ""
+	}
+
+	candidates := append([]Job{*job}, job.Entities.SubJobs...)
+	for _, j := range candidates {
+		if j.FailReason != "" || j.ErrorCode != "" {
+			return fmt.Sprintf("[%s] %s", j.ErrorCode, j.FailReason)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/bss/v2/orders"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// the status of the BSS orders: 3 is processing, 4 is canceled and 5 is completed
+var (
+	orderPendingStates = []string{"3"}
+	orderTargetStates  = []string{"5"}
+	orderFailedStates  = []string{"4"}
+)
+
+// WaitForOrder waits for the BSS order to complete, the client is the BSS v2 client.
+func WaitForOrder(ctx context.Context, client *golangsdk.ServiceClient, orderID string, timeout time.Duration) error {
+	conf := &Conf{
+		Description: fmt.Sprintf("order (%s) to complete payment", orderID),
+		Pending:     orderPendingStates,
+		Target:      orderTargetStates,
+		Failed:      orderFailedStates,
+		Refresh:     refreshOrder(client, orderID),
+		FailReason: func(interface{}) string {
+			return "the order has been canceled"
+		},
+		Timeout:      timeout,
+		Delay:        5 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+
+	_, err := conf.WaitForState(ctx)
+	return err
+}
+
+func refreshOrder(client *golangsdk.ServiceClient, orderID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		r, err := orders.Get(client, orderID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+		return r, strconv.Itoa(r.OrderInfo.Status), nil
+	}
+}
@@ -0,0 +1,228 @@
+// Package waiter waits for the asynchronous operations of the cloud services, e.g. the resource status,
+// the jobs of ECS, EVS and VPC, and the orders of BSS.
+//
+// Compared with resource.StateChangeConf, the terminal error states are reported as *FailedError with the
+// fail reason of the service, the polling interval grows with jitter while the state is unchanged, and a
+// progress line is logged at INFO level so that the long operations show activity.
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+const (
+	defaultPollInterval     = 5 * time.Second
+	defaultMaxPollInterval  = 30 * time.Second
+	defaultProgressInterval = time.Minute
+
+	// the polling interval is multiplied by the factor while the state is unchanged
+	pollBackoffFactor = 1.5
+	// the polling interval is randomized by ±20%
+	pollJitterRatio = 0.2
+)
+
+// Conf is the configuration of a waiter.
+type Conf struct {
+	// Description is used in the progress logs and errors, e.g. "ECS instance (xxx) to become ACTIVE".
+	Description string
+	// Pending is the list of the states to keep waiting, an empty list allows any state except Failed.
+	Pending []string
+	// Target is the list of the states to stop waiting.
+	Target []string
+	// Failed is the list of the terminal error states, e.g. "FAIL" and "ERROR".
+	Failed []string
+	// Refresh returns the object and its state, the resource.StateRefreshFunc of the resources can be reused.
+	// If the error is returned together with a Failed state, it is regarded as the fail reason.
+	Refresh resource.StateRefreshFunc
+	// FailReason extracts the fail reason from the object in a Failed state, e.g. the fail_reason of a job.
+	FailReason func(result interface{}) string
+
+	// Timeout is the maximum time to wait.
+	Timeout time.Duration
+	// Delay is the time to wait before the first refresh.
+	Delay time.Duration
+	// PollInterval is the initial interval between two refreshes, defaults to 5 seconds.
+	PollInterval time.Duration
+	// MaxPollInterval is the upper limit of the adaptive interval, defaults to 30 seconds.
+	MaxPollInterval time.Duration
+	// ProgressInterval is the interval of the progress logs, defaults to 1 minute.
+	ProgressInterval time.Duration
+	// ContinuousTargetOccurence is the number of times the Target state has to occur continuously, defaults to 1.
+	ContinuousTargetOccurence int
+}
+
+// FailedError is returned when the operation reaches a terminal error state.
+type FailedError struct {
+	Description string
+	State       string
+	FailReason  string
+}
+
+func (e *FailedError) Error() string {
+	if e.FailReason == "" {
+		return fmt.Sprintf("failed waiting for %s: the state is %s", e.Description, e.State)
+	}
+	return fmt.Sprintf("failed waiting for %s: the state is %s, fail reason: %s", e.Description, e.State, e.FailReason)
+}
+
+// UnexpectedStateError is returned when the operation reaches a state which is neither pending nor target.
+type UnexpectedStateError struct {
+	Description string
+	State       string
+	Expected    []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("unexpected state %q while waiting for %s, expected: %s", e.State, e.Description,
+		strings.Join(e.Expected, ", "))
+}
+
+// TimeoutError is returned when the operation does not reach the target states in time.
+type TimeoutError struct {
+	Description string
+	LastState   string
+	Timeout     time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout while waiting for %s (last state: %q, timeout: %s)",
+		e.Description, e.LastState, e.Timeout)
+}
+
+// WaitForState refreshes the object until it reaches one of the target states, and returns the last object.
+// It stops with an error when the state is failed or unexpected, the timeout expires or the context is canceled.
+func (conf *Conf) WaitForState(ctx context.Context) (interface{}, error) {
+	pollInterval := conf.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+	maxPollInterval := conf.MaxPollInterval
+	if maxPollInterval < pollInterval {
+		maxPollInterval = defaultMaxPollInterval
+		if maxPollInterval < pollInterval {
+			maxPollInterval = pollInterval
+		}
+	}
+	progressInterval := conf.ProgressInterval
+	if progressInterval <= 0 {
+		progressInterval = defaultProgressInterval
+	}
+	occurence := conf.ContinuousTargetOccurence
+	if occurence <= 0 {
+		occurence = 1
+	}
+
+	start := time.Now()
+	deadline := start.Add(conf.Timeout)
+	lastProgress := start
+
+	var result interface{}
+	var lastState string
+	var targetCount int
+	interval := pollInterval
+	wait := conf.Delay
+
+	for {
+		if conf.Timeout > 0 && time.Now().Add(wait).After(deadline) {
+			wait = time.Until(deadline)
+			if wait < 0 {
+				wait = 0
+			}
+		}
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return result, fmt.Errorf("stopped waiting for %s: %w", conf.Description, err)
+		}
+
+		res, state, err := conf.Refresh()
+		if err != nil {
+			if containsState(conf.Failed, state) {
+				return res, &FailedError{Description: conf.Description, State: state, FailReason: err.Error()}
+			}
+			return res, err
+		}
+		result = res
+
+		switch {
+		case containsState(conf.Target, state):
+			targetCount++
+			if targetCount >= occurence {
+				logp.Printf("[DEBUG] %s took %s", conf.Description, time.Since(start).Round(time.Second))
+				return result, nil
+			}
+		case containsState(conf.Failed, state):
+			failed := &FailedError{Description: conf.Description, State: state}
+			if conf.FailReason != nil {
+				failed.FailReason = conf.FailReason(result)
+			}
+			return result, failed
+		case len(conf.Pending) > 0 && !containsState(conf.Pending, state):
+			return result, &UnexpectedStateError{
+				Description: conf.Description,
+				State:       state,
+				Expected:    append(append([]string{}, conf.Pending...), conf.Target...),
+			}
+		default:
+			targetCount = 0
+		}
+
+		// poll faster once the state changes, and slow down while it is unchanged
+		if state != lastState {
+			interval = pollInterval
+		} else {
+			interval = time.Duration(float64(interval) * pollBackoffFactor)
+			if interval > maxPollInterval {
+				interval = maxPollInterval
+			}
+		}
+		lastState = state
+
+		now := time.Now()
+		if now.Sub(lastProgress) >= progressInterval {
+			logp.Printf("[INFO] Still waiting for %s, current state: %s, elapsed: %s", conf.Description, state,
+				now.Sub(start).Round(time.Second))
+			lastProgress = now
+		}
+
+		if conf.Timeout > 0 && !now.Before(deadline) {
+			return result, &TimeoutError{Description: conf.Description, LastState: state, Timeout: conf.Timeout}
+		}
+		wait = withJitter(interval)
+	}
+}
+
+func withJitter(interval time.Duration) time.Duration {
+	delta := (rand.Float64()*2 - 1) * pollJitterRatio * float64(interval)
+	return interval + time.Duration(delta)
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func containsState(states []string, state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+)
+
+// sequenceRefresh returns the states in order, and keeps returning the last one
+func sequenceRefresh(states ...string) func() (interface{}, string, error) {
+	index := 0
+	return func() (interface{}, string, error) {
+		state := states[index]
+		if index < len(states)-1 {
+			index++
+		}
+		return state, state, nil
+	}
+}
+
+func testConf(refresh func() (interface{}, string, error)) *Conf {
+	return &Conf{
+		Description:     "test object",
+		Pending:         []string{"PENDING"},
+		Target:          []string{"DONE"},
+		Failed:          []string{"FAILED"},
+		Refresh:         refresh,
+		Timeout:         time.Second,
+		PollInterval:    time.Millisecond,
+		MaxPollInterval: 5 * time.Millisecond,
+	}
+}
+
+func TestWaitForState_target(t *testing.T) {
+	result, err := testConf(sequenceRefresh("PENDING", "PENDING", "DONE")).WaitForState(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result != "DONE" {
+		t.Fatalf("expect the result DONE, but got %v", result)
+	}
+}
+
+func TestWaitForState_continuousTarget(t *testing.T) {
+	conf := testConf(sequenceRefresh("DONE", "PENDING", "DONE", "DONE", "DONE"))
+	conf.ContinuousTargetOccurence = 3
+
+	count := 0
+	refresh := conf.Refresh
+	conf.Refresh = func() (interface{}, string, error) {
+		count++
+		return refresh()
+	}
+
+	if _, err := conf.WaitForState(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if count != 5 {
+		t.Fatalf("expect 5 refreshes, but got %d", count)
+	}
+}
+
+func TestWaitForState_failed(t *testing.T) {
+	conf := testConf(sequenceRefresh("PENDING", "FAILED"))
+	conf.FailReason = func(interface{}) string {
+		return "disk quota exceeded"
+	}
+
+	_, err := conf.WaitForState(context.Background())
+	var failed *FailedError
+	if !errors.As(err, &failed) {
+		t.Fatalf("expect a FailedError, but got %v", err)
+	}
+	if failed.State != "FAILED" || failed.FailReason != "disk quota exceeded" {
+		t.Fatalf("unexpected FailedError: %#v", failed)
+	}
+	if !strings.Contains(err.Error(), "disk quota exceeded") {
+		t.Fatalf("expect the fail reason in the error message: %s", err)
+	}
+}
+
+func TestWaitForState_failedWithRefreshError(t *testing.T) {
+	conf := testConf(func() (interface{}, string, error) {
+		return nil, "FAILED", fmt.Errorf("[error code: 500, message: no valid host]")
+	})
+
+	_, err := conf.WaitForState(context.Background())
+	var failed *FailedError
+	if !errors.As(err, &failed) || !strings.Contains(failed.FailReason, "no valid host") {
+		t.Fatalf("expect a FailedError with the refresh error, but got %v", err)
+	}
+}
+
+func TestWaitForState_unexpectedState(t *testing.T) {
+	_, err := testConf(sequenceRefresh("PENDING", "UNKNOWN")).WaitForState(context.Background())
+	var unexpected *UnexpectedStateError
+	if !errors.As(err, &unexpected) || unexpected.State != "UNKNOWN" {
+		t.Fatalf("expect an UnexpectedStateError, but got %v", err)
+	}
+}
+
+func TestWaitForState_timeout(t *testing.T) {
+	conf := testConf(sequenceRefresh("PENDING"))
+	conf.Timeout = 20 * time.Millisecond
+
+	_, err := conf.WaitForState(context.Background())
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) || timeout.LastState != "PENDING" {
+		t.Fatalf("expect a TimeoutError, but got %v", err)
+	}
+}
+
+func TestWaitForState_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	conf := testConf(func() (interface{}, string, error) {
+		cancel()
+		return "PENDING", "PENDING", nil
+	})
+	conf.Timeout = time.Minute
+
+	_, err := conf.WaitForState(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expect the context to be canceled, but got %v", err)
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	interval := 10 * time.Second
+	for i := 0; i < 100; i++ {
+		d := withJitter(interval)
+		if d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("the jittered interval %s is out of range", d)
+		}
+	}
+}
+
+func TestWaitForJob_failReason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/project/jobs/job-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"job_id": "job-1", "status": "FAIL", "entities": {"sub_jobs": [
+			{"job_id": "job-2", "status": "FAIL", "error_code": "Ecs.0013", "fail_reason": "insufficient EIP quota"}
+		]}}`)
+	}))
+	defer server.Close()
+
+	client := &golangsdk.ServiceClient{
+		ProviderClient: &golangsdk.ProviderClient{HTTPClient: *http.DefaultClient},
+		Endpoint:       server.URL + "/",
+		ResourceBase:   server.URL + "/v1/project/",
+	}
+
+	job, err := WaitForJob(context.Background(), client, "job-1", time.Minute)
+	var failed *FailedError
+	if !errors.As(err, &failed) {
+		t.Fatalf("expect a FailedError, but got %v", err)
+	}
+	if failed.FailReason != "[Ecs.0013] insufficient EIP quota" {
+		t.Fatalf("unexpected fail reason: %s", failed.FailReason)
+	}
+	if job == nil || job.ID != "job-1" || len(job.Entities.SubJobs) != 1 {
+		t.Fatalf("unexpected job: %#v", job)
+	}
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+)
+
+// The sources of the resolved endpoints
+const (
+	// EndpointSourceCustom means the endpoint is specified by the endpoints of the provider
+	EndpointSourceCustom = "custom"
+	// EndpointSourceCatalog means the endpoint is discovered from the IAM service catalog
+	EndpointSourceCatalog = "catalog"
+	// EndpointSourceDefault means the endpoint is the built-in one which likes https://{Name}.{Region}.{Cloud}/
+	EndpointSourceDefault = "default"
+)
+
+// globalRegion is the region of the global services in the IAM service catalog
+const globalRegion = "*"
+
+// EndpointCatalog stores the endpoints discovered from the IAM service catalog, the key is the service name
+// (see ServiceCatalog.Name) and the region, and the value likes https://{host}/.
+type EndpointCatalog struct {
+	endpoints map[string]string
+}
+
+type catalogEndpoint struct {
+	Interface string `json:"interface"`
+	Region    string `json:"region"`
+	RegionID  string `json:"region_id"`
+	URL       string `json:"url"`
+}
+
+type catalogService struct {
+	Type      string            `json:"type"`
+	Name      string            `json:"name"`
+	Endpoints []catalogEndpoint `json:"endpoints"`
+}
+
+// newEndpointCatalog builds an EndpointCatalog from the services of the IAM service catalog, only the public
+// endpoints are used and the paths of the URLs are dropped, as the versions and the project IDs are appended
+// by the service clients.
+func newEndpointCatalog(services []catalogService) *EndpointCatalog {
+	catalog := EndpointCatalog{
+		endpoints: make(map[string]string),
+	}
+
+	for _, srv := range services {
+		for _, ep := range srv.Endpoints {
+			if ep.Interface != "" && ep.Interface != "public" {
+				continue
+			}
+			parsed, err := url.Parse(ep.URL)
+			if err != nil || parsed.Host == "" {
+				log.Printf("[WARN] ignore the invalid endpoint %q of service %s in the catalog", ep.URL, srv.Name)
+				continue
+			}
+
+			region := ep.RegionID
+			if region == "" {
+				region = ep.Region
+			}
+			if region == "" {
+				region = globalRegion
+			}
+
+			endpoint := fmt.Sprintf("%s://%s/", parsed.Scheme, parsed.Host)
+			for _, name := range []string{srv.Name, srv.Type} {
+				key := catalogKey(strings.ToLower(name), region)
+				if _, ok := catalog.endpoints[key]; name != "" && !ok {
+					catalog.endpoints[key] = endpoint
+				}
+			}
+		}
+	}
+	return &catalog
+}
+
+func catalogKey(name, region string) string {
+	return name + "/" + region
+}
+
+// Lookup returns the endpoint of the service in the region, the endpoint of the global service is returned
+// if the service has no endpoint in the region.
+func (e *EndpointCatalog) Lookup(name, region string) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	if endpoint, ok := e.endpoints[catalogKey(name, region)]; ok {
+		return endpoint, true
+	}
+	endpoint, ok := e.endpoints[catalogKey(name, globalRegion)]
+	return endpoint, ok
+}
+
+// Len returns the count of the discovered endpoints
+func (e *EndpointCatalog) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.endpoints)
+}
+
+// discoverEndpoints queries the service catalog of the token from IAM, the endpoints of the catalog are used
+// instead of the built-in ones.
+func (c *Config) discoverEndpoints() error {
+	catalogURL := strings.TrimSuffix(c.IdentityEndpoint, "/") + "/auth/catalog"
+	var body struct {
+		Catalog []catalogService `json:"catalog"`
+	}
+	_, err := c.HwClient.Request("GET", catalogURL, &golangsdk.RequestOpts{
+		JSONResponse: &body,
+		OkCodes:      []int{200},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.DiscoveredEndpoints = newEndpointCatalog(body.Catalog)
+	for key, endpoint := range c.DiscoveredEndpoints.endpoints {
+		c.RateLimiter.addHost(endpoint, strings.SplitN(key, "/", 2)[0])
+	}
+	log.Printf("[DEBUG] %d endpoints are discovered from the service catalog", c.DiscoveredEndpoints.Len())
+	return nil
+}
+
+// defaultEndpoint returns the endpoint of the service discovered from the IAM service catalog,
+// or the built-in one which likes https://{Name}.{Region}.{Cloud}/ if it's not discovered.
+func (c *Config) defaultEndpoint(catalog ServiceCatalog, region string) (string, string) {
+	if endpoint, ok := c.DiscoveredEndpoints.Lookup(catalog.Name, region); ok {
+		return endpoint, EndpointSourceCatalog
+	}
+
+	if catalog.Scope == "global" && !c.RegionClient {
+		return fmt.Sprintf("https://%s.%s/", catalog.Name, c.Cloud), EndpointSourceDefault
+	}
+	return fmt.Sprintf("https://%s.%s.%s/", catalog.Name, region, c.Cloud), EndpointSourceDefault
+}
+
+// ResolveEndpoint returns the endpoint of the service in the region and the source of it. The custom endpoints
+// take precedence over the discovered ones, and the built-in endpoints are used as the fallback.
+func (c *Config) ResolveEndpoint(srv, region string) (string, string, error) {
+	catalog, ok := allServiceCatalog[srv]
+	if !ok {
+		return "", "", fmt.Errorf("service type %s is invalid or not supportted", srv)
+	}
+
+	if endpoint, ok := c.Endpoints[srv]; ok {
+		return endpoint, EndpointSourceCustom, nil
+	}
+	endpoint, source := c.defaultEndpoint(catalog, region)
+	return endpoint, source, nil
+}
+
+// GetServiceCatalogKeys returns the sorted keys of all service catalogs.
+func GetServiceCatalogKeys() []string {
+	keys := make([]string, 0, len(allServiceCatalog))
+	for k := range allServiceCatalog {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetEndpointKeys returns the sorted keys which can be used in the custom endpoints of the provider,
+// they're the keys of all service catalogs and obs.
+func GetEndpointKeys() []string {
+	keys := append(GetServiceCatalogKeys(), "obs")
+	sort.Strings(keys)
+	return keys
+}
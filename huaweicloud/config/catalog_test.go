@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+)
+
+func TestNewEndpointCatalog(t *testing.T) {
+	catalog := newEndpointCatalog([]catalogService{
+		{
+			Type: "iam",
+			Name: "iam",
+			Endpoints: []catalogEndpoint{
+				{Interface: "public", Region: "*", URL: "https://iam.hcs.example.com/v3"},
+			},
+		},
+		{
+			Type: "compute",
+			Name: "ecs",
+			Endpoints: []catalogEndpoint{
+				{Interface: "internal", RegionID: "region-1", URL: "https://ecs.internal.example.com/v2"},
+				{Interface: "public", RegionID: "region-1", URL: "https://ecs.region-1.hcs.example.com/v2.1/$(tenant_id)s"},
+				{Interface: "public", RegionID: "region-2", URL: "https://ecs.region-2.hcs.example.com:8443/v2.1"},
+			},
+		},
+		{
+			Type: "vpc",
+			Name: "vpc",
+			Endpoints: []catalogEndpoint{
+				{Interface: "public", RegionID: "region-1", URL: "invalid"},
+			},
+		},
+	})
+
+	th.AssertEquals(t, 5, catalog.Len())
+
+	endpoint, ok := catalog.Lookup("ecs", "region-1")
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "https://ecs.region-1.hcs.example.com/", endpoint)
+
+	endpoint, ok = catalog.Lookup("compute", "region-2")
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "https://ecs.region-2.hcs.example.com:8443/", endpoint)
+
+	// the global endpoint is used in all regions
+	endpoint, ok = catalog.Lookup("iam", "region-1")
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, "https://iam.hcs.example.com/", endpoint)
+
+	_, ok = catalog.Lookup("vpc", "region-1")
+	th.AssertEquals(t, false, ok)
+	_, ok = catalog.Lookup("ecs", "region-3")
+	th.AssertEquals(t, false, ok)
+
+	var empty *EndpointCatalog
+	_, ok = empty.Lookup("ecs", "region-1")
+	th.AssertEquals(t, false, ok)
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	c := &Config{
+		Cloud:     "myhuaweicloud.com",
+		Endpoints: map[string]string{"vpc": "https://vpc.custom.example.com/"},
+		DiscoveredEndpoints: newEndpointCatalog([]catalogService{
+			{
+				Name: "vpc",
+				Endpoints: []catalogEndpoint{
+					{Interface: "public", RegionID: "cn-north-4", URL: "https://vpc.catalog.example.com/v1"},
+				},
+			},
+			{
+				Name: "ecs",
+				Endpoints: []catalogEndpoint{
+					{Interface: "public", RegionID: "cn-north-4", URL: "https://ecs.catalog.example.com/v1"},
+				},
+			},
+		}),
+	}
+
+	cases := []struct {
+		srv, endpoint, source string
+	}{
+		{"vpc", "https://vpc.custom.example.com/", EndpointSourceCustom},
+		{"ecs", "https://ecs.catalog.example.com/", EndpointSourceCatalog},
+		{"ecsv11", "https://ecs.catalog.example.com/", EndpointSourceCatalog},
+		{"rds", "https://rds.cn-north-4.myhuaweicloud.com/", EndpointSourceDefault},
+		{"iam", "https://iam.myhuaweicloud.com/", EndpointSourceDefault},
+	}
+	for _, tc := range cases {
+		endpoint, source, err := c.ResolveEndpoint(tc.srv, "cn-north-4")
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, tc.endpoint, endpoint)
+		th.AssertEquals(t, tc.source, source)
+		// the clients of huaweicloud-sdk-go-v3 use the same endpoints
+		th.AssertEquals(t, tc.endpoint, getServiceEndpoint(c, tc.srv, "cn-north-4"))
+	}
+
+	_, _, err := c.ResolveEndpoint("unknown", "cn-north-4")
+	th.AssertEquals(t, true, err != nil)
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"log"
+	"sync"
+
+	"github.com/chnsz/golangsdk"
+)
+
+// ServiceClientCache is a concurrency-safe cache of the service clients which are keyed by
+// the service, region and project. The cached clients will be invalidated when the credentials are rotated.
+type ServiceClientCache struct {
+	lock sync.RWMutex
+	// clients stores the golangsdk service clients
+	clients map[string]*golangsdk.ServiceClient
+	// sdkClients stores the huaweicloud-sdk-go-v3 service clients
+	sdkClients map[string]interface{}
+}
+
+// NewServiceClientCache returns an empty ServiceClientCache.
+func NewServiceClientCache() *ServiceClientCache {
+	return &ServiceClientCache{
+		clients:    make(map[string]*golangsdk.ServiceClient),
+		sdkClients: make(map[string]interface{}),
+	}
+}
+
+func buildClientCacheKey(srv, region, projectID string) string {
+	return srv + "/" + region + "/" + projectID
+}
+
+// Get returns a copy of the cached service client, the copy can be modified by the caller
+// without affecting the others. A nil cache is always empty.
+func (cache *ServiceClientCache) Get(srv, region, projectID string) *golangsdk.ServiceClient {
+	if cache == nil {
+		return nil
+	}
+
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	sc, ok := cache.clients[buildClientCacheKey(srv, region, projectID)]
+	if !ok {
+		return nil
+	}
+	clone := *sc
+	return &clone
+}
+
+// Put stores a copy of the service client into the cache.
+func (cache *ServiceClientCache) Put(srv, region, projectID string, sc *golangsdk.ServiceClient) {
+	if cache == nil || sc == nil {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	clone := *sc
+	cache.clients[buildClientCacheKey(srv, region, projectID)] = &clone
+}
+
+// GetSdkClient returns the cached huaweicloud-sdk-go-v3 service client.
+func (cache *ServiceClientCache) GetSdkClient(srv, region, projectID string) (interface{}, bool) {
+	if cache == nil {
+		return nil, false
+	}
+
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	client, ok := cache.sdkClients[buildClientCacheKey(srv, region, projectID)]
+	return client, ok
+}
+
+// PutSdkClient stores the huaweicloud-sdk-go-v3 service client into the cache.
+func (cache *ServiceClientCache) PutSdkClient(srv, region, projectID string, client interface{}) {
+	if cache == nil || client == nil {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.sdkClients[buildClientCacheKey(srv, region, projectID)] = client
+}
+
+// Invalidate removes all the cached service clients.
+func (cache *ServiceClientCache) Invalidate() {
+	if cache == nil {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	log.Printf("[DEBUG] invalidate %d cached service clients", len(cache.clients)+len(cache.sdkClients))
+	cache.clients = make(map[string]*golangsdk.ServiceClient)
+	cache.sdkClients = make(map[string]interface{})
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/chnsz/golangsdk"
+	th "github.com/chnsz/golangsdk/testhelper"
+)
+
+func newTestConfig(cache *ServiceClientCache) *Config {
+	client := &golangsdk.ProviderClient{
+		ProjectID: "project-id",
+	}
+	client.AKSKAuthOptions.AccessKey = "access-key"
+	client.AKSKAuthOptions.SecretKey = "secret-key"
+
+	return &Config{
+		AccessKey:          "access-key",
+		SecretKey:          "secret-key",
+		Region:             "cn-north-4",
+		Cloud:              "myhuaweicloud.com",
+		HwClient:           client,
+		DomainClient:       client,
+		RegionProjectIDMap: map[string]string{"cn-north-4": "project-id"},
+		RPLock:             new(sync.Mutex),
+		SecurityKeyLock:    new(sync.Mutex),
+		ClientCache:        cache,
+	}
+}
+
+func TestNewServiceClient_cache(t *testing.T) {
+	cfg := newTestConfig(NewServiceClientCache())
+
+	client1, err := cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://ecs.cn-north-4.myhuaweicloud.com/v1/project-id/", client1.ResourceBase)
+
+	client2, err := cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, client1.ResourceBase, client2.ResourceBase)
+	// the ProviderClient is shared but the ServiceClient is a copy
+	th.AssertEquals(t, client1.ProviderClient, client2.ProviderClient)
+	th.AssertEquals(t, true, client1 != client2)
+
+	// modifying the returned client should not affect the cached one
+	client1.Endpoint = "https://modified.com/"
+	client3, err := cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://ecs.cn-north-4.myhuaweicloud.com/", client3.Endpoint)
+
+	// different services are cached separately
+	vpcClient, err := cfg.NewServiceClient("vpc", "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://vpc.cn-north-4.myhuaweicloud.com/v1/", vpcClient.ResourceBase)
+
+	cfg.ClientCache.Invalidate()
+	th.AssertEquals(t, true, cfg.ClientCache.Get("ecs", "cn-north-4", "project-id") == nil)
+}
+
+func TestServiceClientCache_nil(t *testing.T) {
+	var cache *ServiceClientCache
+
+	cache.Put("ecs", "cn-north-4", "project-id", &golangsdk.ServiceClient{})
+	th.AssertEquals(t, true, cache.Get("ecs", "cn-north-4", "project-id") == nil)
+	cache.Invalidate()
+
+	cfg := newTestConfig(nil)
+	client, err := cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://ecs.cn-north-4.myhuaweicloud.com/v1/project-id/", client.ResourceBase)
+}
+
+func BenchmarkNewServiceClient(b *testing.B) {
+	b.Run("WithoutCache", func(b *testing.B) {
+		cfg := newTestConfig(nil)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := cfg.NewServiceClient("ecs", "cn-north-4"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("WithCache", func(b *testing.B) {
+		cfg := newTestConfig(NewServiceClientCache())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := cfg.NewServiceClient("ecs", "cn-north-4"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("WithCacheParallel", func(b *testing.B) {
+		cfg := newTestConfig(NewServiceClientCache())
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, err := cfg.NewServiceClient("ecs", "cn-north-4"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+}
@@ -4,13 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 
@@ -22,10 +18,9 @@ import (
 	"github.com/chnsz/golangsdk/openstack/obs"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/jmespath/go-jmespath"
-	"github.com/mitchellh/go-homedir"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/pathorcontents"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 const (
@@ -35,26 +30,6 @@ const (
 	keyExpiresDuration int64  = 600
 )
 
-// CLI Shared Config
-type SharedConfig struct {
-	Current  string    `json:"current"`
-	Profiles []Profile `json:"profiles"`
-}
-
-type Profile struct {
-	Name             string `json:"name"`
-	Mode             string `json:"mode"`
-	AccessKeyId      string `json:"accessKeyId"`
-	SecretAccessKey  string `json:"secretAccessKey"`
-	SecurityToken    string `json:"securityToken"`
-	Region           string `json:"region"`
-	ProjectId        string `json:"projectId"`
-	DomainId         string `json:"domainId"`
-	AgencyDomainId   string `json:"agencyDomainId"`
-	AgencyDomainName string `json:"agencyDomainName"`
-	AgencyName       string `json:"agencyName"`
-}
-
 type Config struct {
 	AccessKey           string
 	SecretKey           string
@@ -86,12 +61,38 @@ type Config struct {
 
 	// metadata security key expires at
 	SecurityKeyExpiresAt time.Time
+	// CredentialManager is used to refresh the temporary AK/SK before expiry, it's nil when authenticating by
+	// token or password
+	CredentialManager *CredentialManager
+	// credentialGeneration is the generation of the credentials in CredentialManager which are applied to the config
+	credentialGeneration uint64
 
 	HwClient     *golangsdk.ProviderClient
 	DomainClient *golangsdk.ProviderClient
 
 	// the custom endpoints used to override the default endpoint URL
 	Endpoints map[string]string
+	// EndpointDiscovery resolves the endpoints from the IAM service catalog after authentication
+	EndpointDiscovery bool
+	// DiscoveredEndpoints stores the endpoints of the IAM service catalog, it's nil if not discovered
+	DiscoveredEndpoints *EndpointCatalog
+
+	// RateLimits is a map of the maximum requests per second keyed by the service catalog name
+	RateLimits map[string]float64
+	// RateLimiter is shared by all service clients to throttle the requests of each service
+	RateLimiter *ServiceRateLimiter
+
+	// LogRedactFields and LogRedactHeaders are the custom JSON paths and header names to be redacted
+	LogRedactFields  []string
+	LogRedactHeaders []string
+	// LogRedactor is used to mask the sensitive headers and JSON fields in the debug log
+	LogRedactor *LogRedactor
+	// LogFormat is the format of the debug log, the valid values are "text" and "json"
+	LogFormat string
+
+	// TagsConfig is the default tags which will be applied to all taggable resources, and the ignored tags
+	// which are managed outside terraform
+	TagsConfig *utils.TagsConfig
 
 	// RegionProjectIDMap is a map which stores the region-projectId pairs,
 	// and region name will be the key and projectID will be the value in this map.
@@ -101,9 +102,17 @@ type Config struct {
 	// prevent sending duplicate query requests
 	RPLock *sync.Mutex
 
-	// SecurityKeyLock is used to make the accessing of SecurityKeyExpiresAt serial,
-	// prevent sending duplicate query metadata api
+	// SecurityKeyLock is used to make the rebuilding of the clients with the refreshed credentials serial
 	SecurityKeyLock *sync.Mutex
+
+	// ClientCache stores the service clients to avoid rebuilding them for every request,
+	// the service clients will not be cached if it's nil
+	ClientCache *ServiceClientCache
+
+	// EnablePlanValidation enables the plan-time validations of the flavors, availability zones and quotas
+	EnablePlanValidation bool
+	// PlanDataCache stores the data queried by the plan-time validations
+	PlanDataCache *PlanDataCache
 }
 
 func (c *Config) LoadAndValidate() error {
@@ -111,13 +120,27 @@ func (c *Config) LoadAndValidate() error {
 		return fmt.Errorf("max_retries should be a positive value")
 	}
 
-	err := fmt.Errorf("Must config token or aksk or username password to be authorized")
+	rateLimiter, err := NewServiceRateLimiter(c.RateLimits, c.Endpoints)
+	if err != nil {
+		return err
+	}
+	c.RateLimiter = rateLimiter
+	c.LogRedactor = NewLogRedactor(c.LogRedactFields, c.LogRedactHeaders)
+
+	err = fmt.Errorf("Must config token or aksk or username password to be authorized")
 
 	if c.Token != "" {
 		err = buildClientByToken(c)
 
 	} else if c.AccessKey != "" && c.SecretKey != "" {
-		err = buildClientByAKSK(c)
+		err = c.setCredentialProvider(&StaticCredentialProvider{
+			AccessKey:     c.AccessKey,
+			SecretKey:     c.SecretKey,
+			SecurityToken: c.SecurityToken,
+		})
+		if err == nil {
+			err = buildClientByAKSK(c)
+		}
 
 	} else if c.Password != "" {
 		if c.Username == "" && c.UserID == "" {
@@ -129,11 +152,10 @@ func (c *Config) LoadAndValidate() error {
 		err = buildClientByConfig(c)
 
 	} else {
-		err = getAuthConfigByMeta(c)
+		err = c.setCredentialProvider(&MetadataCredentialProvider{})
 		if err != nil {
 			return fmt.Errorf("Error fetching Auth credentials from ECS Metadata API, AkSk or ECS agency must be provided: %s", err)
 		}
-		log.Printf("[DEBUG] Successfully got metadata security key, which will expire at: %s", c.SecurityKeyExpiresAt)
 		err = buildClientByAKSK(c)
 	}
 	if err != nil {
@@ -149,6 +171,13 @@ func (c *Config) LoadAndValidate() error {
 	}
 	log.Printf("[DEBUG] init region and project map: %#v", c.RegionProjectIDMap)
 
+	if c.EndpointDiscovery {
+		if err := c.discoverEndpoints(); err != nil {
+			log.Printf("[WARN] failed to discover the endpoints from the service catalog, "+
+				"the built-in endpoints will be used: %s", err)
+		}
+	}
+
 	// set DomainID for IAM resource
 	if c.DomainID == "" {
 		if domainID, err := c.getDomainID(); err == nil {
@@ -157,6 +186,8 @@ func (c *Config) LoadAndValidate() error {
 			// update DomainClient.AKSKAuthOptions
 			if c.DomainClient.AKSKAuthOptions.AccessKey != "" {
 				c.DomainClient.AKSKAuthOptions.DomainID = c.DomainID
+				// the cached clients are cloned from the DomainClient without DomainID
+				c.ClientCache.Invalidate()
 			}
 		} else {
 			log.Printf("[WARN] get domain id failed: %s", err)
@@ -174,13 +205,56 @@ func (c *Config) LoadAndValidate() error {
 	return nil
 }
 
-func (c *Config) reloadSecurityKey() error {
-	err := getAuthConfigByMeta(c)
+// setCredentialProvider retrieves the credentials from the provider and applies them to the config,
+// the credentials will be refreshed automatically before expiry.
+func (c *Config) setCredentialProvider(provider CredentialProvider) error {
+	manager, err := NewCredentialManager(provider)
 	if err != nil {
-		return fmt.Errorf("Error reloading Auth credentials from ECS Metadata API: %s", err)
+		return err
 	}
-	log.Printf("Successfully reload metadata security key, which will expire at: %s", c.SecurityKeyExpiresAt)
-	return buildClientByAKSK(c)
+
+	creds, generation := manager.getWithGeneration()
+	c.CredentialManager = manager
+	c.credentialGeneration = generation
+	c.applyCredentials(creds)
+	return nil
+}
+
+func (c *Config) applyCredentials(creds Credentials) {
+	c.AccessKey = creds.AccessKey
+	c.SecretKey = creds.SecretKey
+	c.SecurityToken = creds.SecurityToken
+	c.SecurityKeyExpiresAt = creds.ExpiresAt
+}
+
+// refreshCredentials refreshes the temporary credentials if they are about to expire,
+// and rebuilds the provider clients which are signed with the new credentials.
+// The credentials may also be refreshed by the requests (see CredentialManager.resignRequest), so the generation
+// of the credentials is compared instead of whether they are refreshed by this call.
+func (c *Config) refreshCredentials() error {
+	if c.CredentialManager == nil {
+		return nil
+	}
+	if _, err := c.CredentialManager.RefreshIfNeeded(); err != nil {
+		return err
+	}
+
+	c.SecurityKeyLock.Lock()
+	defer c.SecurityKeyLock.Unlock()
+
+	creds, generation := c.CredentialManager.getWithGeneration()
+	if generation == c.credentialGeneration {
+		return nil
+	}
+
+	c.applyCredentials(creds)
+	// the cached service clients are signed with the expired credentials
+	c.ClientCache.Invalidate()
+	if err := buildClientByAKSK(c); err != nil {
+		return err
+	}
+	c.credentialGeneration = generation
+	return nil
 }
 
 func generateTLSConfig(c *Config) (*tls.Config, error) {
@@ -222,14 +296,11 @@ func generateTLSConfig(c *Config) (*tls.Config, error) {
 	return config, nil
 }
 
+// retryBackoffFunc sleeps a jittered exponential backoff duration in seconds before retrying the throttled request,
+// the Retry-After hint of the response is honored by the rate limiter of LogRoundTripper before the request is sent.
 func retryBackoffFunc(ctx context.Context, respErr *golangsdk.ErrUnexpectedResponseCode, e error, retries uint) error {
-	minutes := int(math.Pow(2, float64(retries)))
-	if minutes > 30 { // won't wait more than 30 minutes
-		minutes = 30
-	}
-
-	log.Printf("[WARN] Received StatusTooManyRequests response code, try to sleep %d minutes", minutes)
-	sleep := time.Duration(minutes) * time.Minute
+	sleep := jitteredBackoff(time.Second, retries, maxBackoffDuration)
+	log.Printf("[WARN] Received StatusTooManyRequests response code, try to sleep %s", sleep)
 
 	if ctx != nil {
 		select {
@@ -263,11 +334,20 @@ func genClient(c *Config, ao golangsdk.AuthOptionsProvider) (*golangsdk.Provider
 		TLSClientConfig: config,
 	}
 
+	var rt http.RoundTripper = transport
+	if recorder := getHTTPRecorder(); recorder != nil {
+		rt = recorder.Wrap(transport)
+	}
+
 	client.HTTPClient = http.Client{
 		Transport: &LogRoundTripper{
-			Rt:         transport,
-			OsDebug:    logging.IsDebugOrHigher(),
-			MaxRetries: c.MaxRetries,
+			Rt:          rt,
+			OsDebug:     logging.IsDebugOrHigher(),
+			MaxRetries:  c.MaxRetries,
+			RateLimiter: c.RateLimiter,
+			Redactor:    c.LogRedactor,
+			LogFormat:   c.LogFormat,
+			Credentials: c.CredentialManager,
 		},
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if client.AKSKAuthOptions.AccessKey != "" {
@@ -378,64 +458,6 @@ func buildClientByAKSK(c *Config) error {
 	return genClients(c, pao, dao)
 }
 
-func buildClientByConfig(c *Config) error {
-	profilePath, err := homedir.Expand(c.SharedConfigFile)
-	if err != nil {
-		return err
-	}
-
-	current := c.Profile
-	var providerConfig Profile
-	_, err = os.Stat(profilePath)
-	if !os.IsNotExist(err) {
-		data, err := ioutil.ReadFile(profilePath)
-		if err != nil {
-			return fmt.Errorf("Err reading from shared config file: %s", err)
-		}
-		sharedConfig := SharedConfig{}
-		err = json.Unmarshal(data, &sharedConfig)
-		if err != nil {
-			return err
-		}
-
-		// fetch current from shared config if not specified with provider
-		if current == "" {
-			current = sharedConfig.Current
-		}
-
-		// fetch the current profile config
-		for _, v := range sharedConfig.Profiles {
-			if current == v.Name {
-				providerConfig = v
-				break
-			}
-		}
-		if (providerConfig == Profile{}) {
-			return fmt.Errorf("Error finding profile %s from shared config file", current)
-		}
-	} else {
-		return fmt.Errorf("The specified shared config file %s does not exist", profilePath)
-	}
-
-	if providerConfig.Mode == "AKSK" {
-		c.AccessKey = providerConfig.AccessKeyId
-		c.SecretKey = providerConfig.SecretAccessKey
-		if providerConfig.Region != "" {
-			c.Region = providerConfig.Region
-		}
-		// non required fields
-		if providerConfig.DomainId != "" {
-			c.DomainID = providerConfig.DomainId
-		}
-		if providerConfig.ProjectId != "" {
-			c.TenantID = providerConfig.ProjectId
-		}
-	} else {
-		return fmt.Errorf("Unsupported mode %s in shared config file", providerConfig.Mode)
-	}
-	return buildClientByAKSK(c)
-}
-
 func buildClientByPassword(c *Config) error {
 	var pao, dao golangsdk.AuthOptions
 
@@ -491,67 +513,13 @@ func genClients(c *Config, pao, dao golangsdk.AuthOptionsProvider) error {
 	return err
 }
 
-func getAuthConfigByMeta(c *Config) error {
-	req, err := http.NewRequest("GET", securityKeyURL, nil)
-	if err != nil {
-		return fmt.Errorf("Error building metadata API request: %s", err.Error())
-	}
-
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("Error requesting metadata API: %s", err.Error())
-	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Error requesting metadata API: status code = %d", resp.StatusCode)
-	}
-
-	var parsedBody interface{}
-
-	defer resp.Body.Close()
-	rawBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("Error parsing metadata API response: %s", err.Error())
-	}
-
-	err = json.Unmarshal(rawBody, &parsedBody)
-	if err != nil {
-		return fmt.Errorf("Error unmarshal metadata API, agency_name is empty: %s", err.Error())
-	}
-
-	expiresAt, err := jmespath.Search("credential.expires_at", parsedBody)
-	if err != nil {
-		return fmt.Errorf("Error fetching metadata expires_at: %s", err.Error())
-	}
-	accessKey, err := jmespath.Search("credential.access", parsedBody)
-	if err != nil {
-		return fmt.Errorf("Error fetching metadata access: %s", err.Error())
-	}
-	secretKey, err := jmespath.Search("credential.secret", parsedBody)
-	if err != nil {
-		return fmt.Errorf("Error fetching metadata secret: %s", err.Error())
-	}
-	securityToken, err := jmespath.Search("credential.securitytoken", parsedBody)
-	if err != nil {
-		return fmt.Errorf("Error fetching metadata securitytoken: %s", err.Error())
-	}
-
-	if accessKey == nil || secretKey == nil || securityToken == nil || expiresAt == nil {
-		return fmt.Errorf("Error fetching metadata authentication information.")
-	}
-	expairesTime, err := time.Parse(time.RFC3339, expiresAt.(string))
-	if err != nil {
-		return err
-	}
-	c.AccessKey, c.SecretKey, c.SecurityToken, c.SecurityKeyExpiresAt = accessKey.(string), secretKey.(string), securityToken.(string), expairesTime
-
-	return nil
-}
-
 func getObsEndpoint(c *Config, region string) string {
 	if endpoint, ok := c.Endpoints["obs"]; ok {
 		return endpoint
 	}
+	if endpoint, ok := c.DiscoveredEndpoints.Lookup("obs", region); ok {
+		return endpoint
+	}
 	return fmt.Sprintf("https://obs.%s.%s/", region, c.Cloud)
 }
 
@@ -587,14 +555,8 @@ func (c *Config) ObjectStorageClient(region string) (*obs.ObsClient, error) {
 		}
 	}
 
-	if !c.SecurityKeyExpiresAt.IsZero() {
-		c.SecurityKeyLock.Lock()
-		defer c.SecurityKeyLock.Unlock()
-		timeNow := time.Now().Unix()
-		expairesAtInt := c.SecurityKeyExpiresAt.Unix()
-		if timeNow+keyExpiresDuration > expairesAtInt {
-			c.reloadSecurityKey()
-		}
+	if err := c.refreshCredentials(); err != nil {
+		log.Printf("[WARN] %s", err)
 	}
 
 	obsEndpoint := getObsEndpoint(c, region)
@@ -613,14 +575,13 @@ func (c *Config) NewServiceClient(srv, region string) (*golangsdk.ServiceClient,
 		return nil, fmt.Errorf("service type %s is invalid or not supportted", srv)
 	}
 
-	if !c.SecurityKeyExpiresAt.IsZero() {
-		c.SecurityKeyLock.Lock()
-		defer c.SecurityKeyLock.Unlock()
-		timeNow := time.Now().Unix()
-		expairesAtInt := c.SecurityKeyExpiresAt.Unix()
-		if timeNow+keyExpiresDuration > expairesAtInt {
-			c.reloadSecurityKey()
-		}
+	if err := c.refreshCredentials(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+
+	projectID := c.getCachedProjectID(region)
+	if sc := c.ClientCache.Get(srv, region, projectID); sc != nil {
+		return sc, nil
 	}
 
 	client := c.HwClient
@@ -628,10 +589,43 @@ func (c *Config) NewServiceClient(srv, region string) (*golangsdk.ServiceClient,
 		client = c.DomainClient
 	}
 
+	var sc *golangsdk.ServiceClient
+	var err error
 	if endpoint, ok := c.Endpoints[srv]; ok {
-		return c.newServiceClientByEndpoint(client, srv, endpoint)
+		sc, err = c.newServiceClientByEndpoint(client, srv, endpoint)
+	} else {
+		sc, err = c.newServiceClientByName(client, serviceCatalog, region)
 	}
-	return c.newServiceClientByName(client, serviceCatalog, region)
+	if err != nil {
+		return nil, err
+	}
+
+	// the project ID may be loaded when creating the service client
+	c.ClientCache.Put(srv, region, c.getCachedProjectID(region), sc)
+	return sc, nil
+}
+
+// getCachedProjectID returns the project ID of the region which is stored in RegionProjectIDMap,
+// an empty string will be returned if it has not been loaded.
+func (c *Config) getCachedProjectID(region string) string {
+	c.RPLock.Lock()
+	defer c.RPLock.Unlock()
+
+	return c.RegionProjectIDMap[region]
+}
+
+// GetProjectID returns the project ID of the region, it will be loaded if it's not in RegionProjectIDMap.
+func (c *Config) GetProjectID(region string) (string, error) {
+	c.RPLock.Lock()
+	defer c.RPLock.Unlock()
+
+	if projectID, ok := c.RegionProjectIDMap[region]; ok {
+		return projectID, nil
+	}
+	if err := c.loadUserProjects(c.HwClient, region); err != nil {
+		return "", err
+	}
+	return c.RegionProjectIDMap[region], nil
 }
 
 func (c *Config) newServiceClientByName(client *golangsdk.ProviderClient, catalog ServiceCatalog, region string) (*golangsdk.ServiceClient, error) {
@@ -668,11 +662,7 @@ func (c *Config) newServiceClientByName(client *golangsdk.ProviderClient, catalo
 		ProviderClient: clone,
 	}
 
-	if catalog.Scope == "global" && !c.RegionClient {
-		sc.Endpoint = fmt.Sprintf("https://%s.%s/", catalog.Name, c.Cloud)
-	} else {
-		sc.Endpoint = fmt.Sprintf("https://%s.%s.%s/", catalog.Name, region, c.Cloud)
-	}
+	sc.Endpoint, _ = c.defaultEndpoint(catalog, region)
 
 	sc.ResourceBase = sc.Endpoint
 	if catalog.Version != "" {
@@ -877,6 +867,10 @@ func (c *Config) ComputeV2Client(region string) (*golangsdk.ServiceClient, error
 	return c.NewServiceClient("ecsv21", region)
 }
 
+func (c *Config) ComputeV20Client(region string) (*golangsdk.ServiceClient, error) {
+	return c.NewServiceClient("ecsv2", region)
+}
+
 func (c *Config) AutoscalingV1Client(region string) (*golangsdk.ServiceClient, error) {
 	return c.NewServiceClient("autoscaling", region)
 }
@@ -885,6 +879,10 @@ func (c *Config) ImageV2Client(region string) (*golangsdk.ServiceClient, error)
 	return c.NewServiceClient("ims", region)
 }
 
+func (c *Config) ImageV1Client(region string) (*golangsdk.ServiceClient, error) {
+	return c.NewServiceClient("imsv1", region)
+}
+
 func (c *Config) CceV1Client(region string) (*golangsdk.ServiceClient, error) {
 	return c.NewServiceClient("ccev1", region)
 }
@@ -930,6 +928,14 @@ func (c *Config) BlockStorageV2Client(region string) (*golangsdk.ServiceClient,
 	return c.NewServiceClient("evs", region)
 }
 
+func (c *Config) BlockStorageV1Client(region string) (*golangsdk.ServiceClient, error) {
+	return c.NewServiceClient("evsv1", region)
+}
+
+func (c *Config) BlockStorageV5Client(region string) (*golangsdk.ServiceClient, error) {
+	return c.NewServiceClient("evsv5", region)
+}
+
 func (c *Config) SfsV2Client(region string) (*golangsdk.ServiceClient, error) {
 	return c.NewServiceClient("sfs", region)
 }
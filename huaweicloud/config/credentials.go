@@ -0,0 +1,410 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/jmespath/go-jmespath"
+)
+
+const (
+	// credentialRefreshWindow is the duration before the expiry time to refresh the temporary credentials
+	credentialRefreshWindow = time.Duration(keyExpiresDuration) * time.Second
+	// defaultAgencyDuration is the validity period (in seconds) of the credentials assumed from an agency
+	defaultAgencyDuration = 3600
+	// defaultProfileRefreshInterval is the interval to re-read the temporary credentials from the shared config file
+	defaultProfileRefreshInterval = 5 * time.Minute
+)
+
+// Credentials is a set of AK/SK which is used to sign the requests,
+// the SecurityToken and ExpiresAt are only valid for the temporary credentials.
+type Credentials struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+	// ExpiresAt is the expiry time of the temporary credentials, the zero value means never expire
+	ExpiresAt time.Time
+	// Source is the name of the CredentialProvider which the credentials come from
+	Source string
+}
+
+// IsValid returns true if both of the access key and secret key are not empty.
+func (c *Credentials) IsValid() bool {
+	return c != nil && c.AccessKey != "" && c.SecretKey != ""
+}
+
+// expiresWithin returns true if the credentials will expire within the duration.
+func (c *Credentials) expiresWithin(duration time.Duration) bool {
+	if c.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().Add(duration).After(c.ExpiresAt)
+}
+
+// CredentialProvider is the interface to retrieve the AK/SK credentials.
+type CredentialProvider interface {
+	// Name returns the name of the provider which is used in logs
+	Name() string
+	// Retrieve returns the latest credentials
+	Retrieve() (*Credentials, error)
+}
+
+// StaticCredentialProvider returns the AK/SK specified in the provider configuration or environment variables.
+type StaticCredentialProvider struct {
+	AccessKey     string
+	SecretKey     string
+	SecurityToken string
+}
+
+func (p *StaticCredentialProvider) Name() string {
+	return "static"
+}
+
+func (p *StaticCredentialProvider) Retrieve() (*Credentials, error) {
+	creds := Credentials{
+		AccessKey:     p.AccessKey,
+		SecretKey:     p.SecretKey,
+		SecurityToken: p.SecurityToken,
+		Source:        p.Name(),
+	}
+	if !creds.IsValid() {
+		return nil, fmt.Errorf("access_key or secret_key is missing")
+	}
+	return &creds, nil
+}
+
+// SharedProfileCredentialProvider returns the AK/SK of a profile in the shared config file (e.g. ~/.hcloud/config.json).
+// The temporary credentials in the file are updated by KooCLI without an expiry time,
+// so the file will be re-read every RefreshInterval when the profile contains a security token.
+type SharedProfileCredentialProvider struct {
+	Path string
+	// Profile is the name of the profile, the current profile in the file will be used if it's empty
+	Profile string
+	// RefreshInterval defaults to 5 minutes
+	RefreshInterval time.Duration
+}
+
+func (p *SharedProfileCredentialProvider) Name() string {
+	return "shared profile"
+}
+
+func (p *SharedProfileCredentialProvider) Retrieve() (*Credentials, error) {
+	profile, err := loadSharedProfile(p.Path, p.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := Credentials{
+		AccessKey:     profile.AccessKeyId,
+		SecretKey:     profile.SecretAccessKey,
+		SecurityToken: profile.SecurityToken,
+		Source:        p.Name(),
+	}
+	if !creds.IsValid() {
+		return nil, fmt.Errorf("accessKeyId or secretAccessKey is missing in profile %s", profile.Name)
+	}
+
+	if creds.SecurityToken != "" {
+		interval := p.RefreshInterval
+		if interval == 0 {
+			interval = defaultProfileRefreshInterval
+		}
+		creds.ExpiresAt = time.Now().Add(credentialRefreshWindow + interval)
+	}
+	return &creds, nil
+}
+
+// MetadataCredentialProvider returns the temporary AK/SK of the agency which is bound to the ECS instance
+// from the ECS metadata API.
+type MetadataCredentialProvider struct {
+	// URL is the security key URL of the metadata API, defaults to securityKeyURL
+	URL string
+}
+
+func (p *MetadataCredentialProvider) Name() string {
+	return "ecs metadata"
+}
+
+func (p *MetadataCredentialProvider) Retrieve() (*Credentials, error) {
+	metadataURL := p.URL
+	if metadataURL == "" {
+		metadataURL = securityKeyURL
+	}
+
+	req, err := http.NewRequest("GET", metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error building metadata API request: %s", err.Error())
+	}
+
+	httpClient := &http.Client{}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error requesting metadata API: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error requesting metadata API: status code = %d", resp.StatusCode)
+	}
+
+	rawBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing metadata API response: %s", err.Error())
+	}
+
+	creds, err := parseTemporaryCredentials(rawBody)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching metadata authentication information: %s", err)
+	}
+	creds.Source = p.Name()
+	return creds, nil
+}
+
+// AgencyCredentialProvider assumes an agency with the base credentials and returns the temporary AK/SK.
+// For more information, please refer to the IAM API: POST /v3.0/OS-CREDENTIAL/securitytokens
+type AgencyCredentialProvider struct {
+	Base CredentialProvider
+	// IdentityEndpoint is the IAM endpoint, e.g. https://iam.myhuaweicloud.com:443/v3
+	IdentityEndpoint string
+	// DomainName is the name of the domain which created the agency
+	DomainName string
+	// DomainID is the ID of the domain which created the agency, it is optional if DomainName is specified
+	DomainID   string
+	AgencyName string
+	// DurationSeconds is the validity period of the temporary credentials, defaults to 3600
+	DurationSeconds int
+	HTTPClient      *http.Client
+}
+
+func (p *AgencyCredentialProvider) Name() string {
+	return "agency"
+}
+
+func (p *AgencyCredentialProvider) Retrieve() (*Credentials, error) {
+	if p.Base == nil {
+		return nil, fmt.Errorf("the base credentials are required to assume the agency %s", p.AgencyName)
+	}
+	baseCreds, err := p.Base.Retrieve()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the base credentials to assume the agency: %s", err)
+	}
+
+	duration := p.DurationSeconds
+	if duration == 0 {
+		duration = defaultAgencyDuration
+	}
+	assumeRole := map[string]interface{}{
+		"agency_name":      p.AgencyName,
+		"duration_seconds": duration,
+	}
+	if p.DomainID != "" {
+		assumeRole["domain_id"] = p.DomainID
+	} else {
+		assumeRole["domain_name"] = p.DomainName
+	}
+	reqBody := map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods":     []string{"assume_role"},
+				"assume_role": assumeRole,
+			},
+		},
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// the identity endpoint likes https://iam.{cloud}:443/v3
+	iamEndpoint := strings.TrimSuffix(strings.TrimSuffix(p.IdentityEndpoint, "/"), "/v3")
+	req, err := http.NewRequest("POST", iamEndpoint+"/v3.0/OS-CREDENTIAL/securitytokens", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("Error building the request to assume the agency: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	golangsdk.Sign(req, golangsdk.SignOptions{
+		AccessKey: baseCreds.AccessKey,
+		SecretKey: baseCreds.SecretKey,
+	})
+	if baseCreds.SecurityToken != "" {
+		req.Header.Set("X-Security-Token", baseCreds.SecurityToken)
+	}
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error assuming the agency %s: %s", p.AgencyName, err)
+	}
+	defer resp.Body.Close()
+
+	rawBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the response of assuming the agency: %s", err)
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error assuming the agency %s: status code = %d, %s", p.AgencyName, resp.StatusCode, rawBody)
+	}
+
+	creds, err := parseTemporaryCredentials(rawBody)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the credentials of agency %s: %s", p.AgencyName, err)
+	}
+	creds.Source = p.Name()
+	return creds, nil
+}
+
+// parseTemporaryCredentials parses the temporary credentials from the response body of the ECS metadata API
+// or the IAM API, the format likes:
+// {"credential": {"access": "xxx", "secret": "xxx", "securitytoken": "xxx", "expires_at": "2021-01-01T00:00:00Z"}}
+func parseTemporaryCredentials(rawBody []byte) (*Credentials, error) {
+	var parsedBody interface{}
+	if err := json.Unmarshal(rawBody, &parsedBody); err != nil {
+		return nil, fmt.Errorf("Error unmarshal the response body: %s", err)
+	}
+
+	values := make(map[string]string)
+	for _, key := range []string{"access", "secret", "securitytoken", "expires_at"} {
+		val, err := jmespath.Search("credential."+key, parsedBody)
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching %s: %s", key, err)
+		}
+		strVal, ok := val.(string)
+		if !ok || strVal == "" {
+			return nil, fmt.Errorf("the %s is missing in the response body", key)
+		}
+		values[key] = strVal
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, values["expires_at"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credentials{
+		AccessKey:     values["access"],
+		SecretKey:     values["secret"],
+		SecurityToken: values["securitytoken"],
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// CredentialManager caches the credentials retrieved from a CredentialProvider and refreshes them before expiry.
+type CredentialManager struct {
+	provider CredentialProvider
+	lock     sync.RWMutex
+	current  *Credentials
+	// generation is increased each time the credentials are refreshed
+	generation uint64
+	// window is the duration before the expiry time to refresh the credentials
+	window time.Duration
+}
+
+// NewCredentialManager retrieves the credentials from the provider and returns a CredentialManager.
+func NewCredentialManager(provider CredentialProvider) (*CredentialManager, error) {
+	creds, err := provider.Retrieve()
+	if err != nil {
+		return nil, err
+	}
+
+	if !creds.ExpiresAt.IsZero() {
+		log.Printf("[DEBUG] Successfully got %s credentials, which will expire at: %s", provider.Name(), creds.ExpiresAt)
+	}
+	return &CredentialManager{
+		provider: provider,
+		current:  creds,
+		window:   credentialRefreshWindow,
+	}, nil
+}
+
+// Get returns a copy of the current credentials.
+func (m *CredentialManager) Get() Credentials {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return *m.current
+}
+
+// getWithGeneration returns a copy of the current credentials and their generation, the callers can compare the
+// generation with the one they used to find out whether the credentials have been refreshed by others.
+func (m *CredentialManager) getWithGeneration() (Credentials, uint64) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return *m.current, m.generation
+}
+
+// RefreshIfNeeded refreshes the credentials if they will expire within the refresh window,
+// the first return value is true if the credentials have been refreshed.
+func (m *CredentialManager) RefreshIfNeeded() (bool, error) {
+	if m == nil {
+		return false, nil
+	}
+
+	m.lock.RLock()
+	needed := m.current.expiresWithin(m.window)
+	m.lock.RUnlock()
+	if !needed {
+		return false, nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// the credentials may be refreshed by others while waiting for the lock
+	if !m.current.expiresWithin(m.window) {
+		return false, nil
+	}
+
+	creds, err := m.provider.Retrieve()
+	if err != nil {
+		return false, fmt.Errorf("Error refreshing the %s credentials: %s", m.provider.Name(), err)
+	}
+	m.current = creds
+	m.generation++
+	log.Printf("[DEBUG] Successfully refreshed %s credentials, which will expire at: %s", m.provider.Name(), creds.ExpiresAt)
+	return true, nil
+}
+
+// resignRequest re-signs the request which was signed by the expired AK/SK with the current credentials,
+// so the service clients which were created before refreshing can still work.
+func (m *CredentialManager) resignRequest(req *http.Request) {
+	if m == nil {
+		return
+	}
+
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, golangsdk.SignAlgorithmHMACSHA256) {
+		return
+	}
+
+	if _, err := m.RefreshIfNeeded(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+	creds := m.Get()
+	if strings.Contains(auth, "Credential="+creds.AccessKey+"/") {
+		return
+	}
+
+	log.Printf("[DEBUG] re-signing the request %s %s with the refreshed credentials", req.Method, req.URL)
+	if creds.SecurityToken != "" {
+		req.Header.Set("X-Security-Token", creds.SecurityToken)
+	} else {
+		req.Header.Del("X-Security-Token")
+	}
+	golangsdk.ReSign(req, golangsdk.SignOptions{
+		AccessKey:  creds.AccessKey,
+		SecretKey:  creds.SecretKey,
+		RegionName: req.Header.Get("region"),
+	})
+}
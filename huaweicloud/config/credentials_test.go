@@ -0,0 +1,243 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	th "github.com/chnsz/golangsdk/testhelper"
+	vpc "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/vpc/v3"
+)
+
+// countingProvider returns a new temporary AK each time it is called
+type countingProvider struct {
+	lock      sync.Mutex
+	count     int
+	expiresIn time.Duration
+}
+
+func (p *countingProvider) Name() string {
+	return "counting"
+}
+
+func (p *countingProvider) Retrieve() (*Credentials, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.count++
+	return &Credentials{
+		AccessKey:     fmt.Sprintf("access-key-%d", p.count),
+		SecretKey:     fmt.Sprintf("secret-key-%d", p.count),
+		SecurityToken: fmt.Sprintf("token-%d", p.count),
+		ExpiresAt:     time.Now().Add(p.expiresIn),
+	}, nil
+}
+
+func temporaryCredentialBody(expiresAt time.Time) string {
+	return fmt.Sprintf(`{"credential": {"access": "temp-ak", "secret": "temp-sk", "securitytoken": "temp-token",
+"expires_at": "%s"}}`, expiresAt.UTC().Format(time.RFC3339))
+}
+
+func TestStaticCredentialProvider(t *testing.T) {
+	provider := StaticCredentialProvider{AccessKey: "ak", SecretKey: "sk"}
+	creds, err := provider.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ak", creds.AccessKey)
+	th.AssertEquals(t, true, creds.ExpiresAt.IsZero())
+
+	_, err = (&StaticCredentialProvider{AccessKey: "ak"}).Retrieve()
+	th.AssertEquals(t, true, err != nil)
+}
+
+func TestSharedProfileCredentialProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hcloud")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	content := `{"current": "temp", "profiles": [
+{"name": "default", "mode": "AKSK", "accessKeyId": "ak", "secretAccessKey": "sk"},
+{"name": "temp", "mode": "AKSK", "accessKeyId": "temp-ak", "secretAccessKey": "temp-sk", "securityToken": "token"}]}`
+	th.AssertNoErr(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	creds, err := (&SharedProfileCredentialProvider{Path: path, Profile: "default"}).Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "ak", creds.AccessKey)
+	th.AssertEquals(t, true, creds.ExpiresAt.IsZero())
+
+	// the current profile is used and the temporary credentials will be re-read periodically
+	creds, err = (&SharedProfileCredentialProvider{Path: path}).Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "temp-ak", creds.AccessKey)
+	th.AssertEquals(t, "token", creds.SecurityToken)
+	th.AssertEquals(t, false, creds.ExpiresAt.IsZero())
+
+	_, err = (&SharedProfileCredentialProvider{Path: path, Profile: "unknown"}).Retrieve()
+	th.AssertEquals(t, true, err != nil)
+}
+
+func TestMetadataCredentialProvider(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, temporaryCredentialBody(expiresAt))
+	}))
+	defer server.Close()
+
+	creds, err := (&MetadataCredentialProvider{URL: server.URL}).Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "temp-ak", creds.AccessKey)
+	th.AssertEquals(t, "temp-sk", creds.SecretKey)
+	th.AssertEquals(t, "temp-token", creds.SecurityToken)
+	th.AssertEquals(t, true, creds.ExpiresAt.Equal(expiresAt))
+}
+
+func TestAgencyCredentialProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.AssertEquals(t, "/v3.0/OS-CREDENTIAL/securitytokens", r.URL.Path)
+		th.AssertEquals(t, true, strings.Contains(r.Header.Get("Authorization"), "Credential=base-ak/"))
+
+		var body map[string]interface{}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		assumeRole := body["auth"].(map[string]interface{})["identity"].(map[string]interface{})["assume_role"]
+		th.AssertEquals(t, "ops", assumeRole.(map[string]interface{})["agency_name"])
+		th.AssertEquals(t, "domain", assumeRole.(map[string]interface{})["domain_name"])
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, temporaryCredentialBody(time.Now().Add(time.Hour)))
+	}))
+	defer server.Close()
+
+	provider := AgencyCredentialProvider{
+		Base:             &StaticCredentialProvider{AccessKey: "base-ak", SecretKey: "base-sk"},
+		IdentityEndpoint: server.URL + "/v3",
+		DomainName:       "domain",
+		AgencyName:       "ops",
+	}
+	creds, err := provider.Retrieve()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "temp-ak", creds.AccessKey)
+	th.AssertEquals(t, "agency", creds.Source)
+}
+
+func TestCredentialManager_refresh(t *testing.T) {
+	// the credentials are not refreshed if they will not expire within the refresh window
+	provider := &countingProvider{expiresIn: time.Hour}
+	manager, err := NewCredentialManager(provider)
+	th.AssertNoErr(t, err)
+	refreshed, err := manager.RefreshIfNeeded()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, refreshed)
+	th.AssertEquals(t, "access-key-1", manager.Get().AccessKey)
+
+	// the credentials are refreshed only once by the concurrent callers
+	provider = &countingProvider{expiresIn: time.Minute}
+	manager, err = NewCredentialManager(provider)
+	th.AssertNoErr(t, err)
+	provider.expiresIn = time.Hour
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := manager.RefreshIfNeeded()
+			th.AssertNoErr(t, err)
+		}()
+	}
+	wg.Wait()
+	th.AssertEquals(t, 2, provider.count)
+	th.AssertEquals(t, "access-key-2", manager.Get().AccessKey)
+}
+
+func TestCredentialManager_resignRequest(t *testing.T) {
+	provider := &countingProvider{expiresIn: time.Minute}
+	manager, err := NewCredentialManager(provider)
+	th.AssertNoErr(t, err)
+
+	req, err := http.NewRequest("GET", "https://ecs.cn-north-4.myhuaweicloud.com/v1/servers", nil)
+	th.AssertNoErr(t, err)
+	golangsdk.Sign(req, golangsdk.SignOptions{AccessKey: "access-key-1", SecretKey: "secret-key-1"})
+	req.Header.Set("X-Security-Token", "token-1")
+
+	// the credentials are expiring, so the request will be re-signed with the refreshed credentials
+	manager.resignRequest(req)
+	th.AssertEquals(t, true, strings.Contains(req.Header.Get("Authorization"), "Credential=access-key-2/"))
+	th.AssertEquals(t, "token-2", req.Header.Get("X-Security-Token"))
+
+	// the request which is not signed by AK/SK is untouched
+	req, err = http.NewRequest("GET", "https://ecs.cn-north-4.myhuaweicloud.com/v1/servers", nil)
+	th.AssertNoErr(t, err)
+	req.Header.Set("X-Auth-Token", "token")
+	manager.resignRequest(req)
+	th.AssertEquals(t, "", req.Header.Get("Authorization"))
+}
+
+func TestConfig_refreshCredentials(t *testing.T) {
+	cache := NewServiceClientCache()
+	cfg := newTestConfig(cache)
+	th.AssertNoErr(t, cfg.setCredentialProvider(&countingProvider{expiresIn: time.Hour}))
+	th.AssertEquals(t, "access-key-1", cfg.AccessKey)
+	th.AssertEquals(t, "token-1", cfg.SecurityToken)
+
+	_, err := cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, cache.Get("ecs", "cn-north-4", "project-id") != nil)
+
+	// nothing happens if the credentials are valid
+	th.AssertNoErr(t, cfg.refreshCredentials())
+	th.AssertEquals(t, "access-key-1", cfg.AccessKey)
+	th.AssertEquals(t, true, cache.Get("ecs", "cn-north-4", "project-id") != nil)
+}
+
+func TestConfig_refreshCredentialsByRequest(t *testing.T) {
+	iamServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	}))
+	defer iamServer.Close()
+
+	cache := NewServiceClientCache()
+	cfg := newTestConfig(cache)
+	cfg.IdentityEndpoint = iamServer.URL + "/v3"
+	cfg.TenantID = "project-id"
+	cfg.DomainID = "domain-id"
+	provider := &countingProvider{expiresIn: time.Minute}
+	th.AssertNoErr(t, cfg.setCredentialProvider(provider))
+	provider.expiresIn = time.Hour
+	cache.Put("ecs", "cn-north-4", "project-id", &golangsdk.ServiceClient{Endpoint: "https://signed-by-key-1/"})
+	staleVpcClient := &vpc.VpcClient{}
+	cache.PutSdkClient("vpc", "cn-north-4", "project-id", staleVpcClient)
+
+	// the expiring credentials are refreshed by the request
+	req, err := http.NewRequest("GET", iamServer.URL+"/v1/servers", nil)
+	th.AssertNoErr(t, err)
+	golangsdk.Sign(req, golangsdk.SignOptions{AccessKey: "access-key-1", SecretKey: "secret-key-1"})
+	lrt := &LogRoundTripper{Rt: http.DefaultTransport, Credentials: cfg.CredentialManager}
+	resp, err := lrt.RoundTrip(req)
+	th.AssertNoErr(t, err)
+	resp.Body.Close()
+	th.AssertEquals(t, 2, provider.count)
+
+	// the config applies the credentials refreshed by the request and drops the clients signed by the old ones
+	vpcClient, err := NewVpcClient(cfg, "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, vpcClient != staleVpcClient)
+	th.AssertEquals(t, 2, provider.count)
+	th.AssertEquals(t, "access-key-2", cfg.AccessKey)
+	th.AssertEquals(t, "token-2", cfg.SecurityToken)
+	th.AssertEquals(t, "access-key-2", cfg.HwClient.AKSKAuthOptions.AccessKey)
+
+	client, err := cfg.NewServiceClient("ecs", "cn-north-4")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://ecs.cn-north-4.myhuaweicloud.com/", client.Endpoint)
+}
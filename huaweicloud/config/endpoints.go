@@ -17,8 +17,9 @@ type ServiceCatalog struct {
 var multiCatalogKeys = map[string][]string{
 	"iam":       {"identity", "iam_no_version"},
 	"bss":       {"bssv2"},
-	"ecs":       {"ecsv21", "ecsv11"},
-	"evs":       {"evsv21"},
+	"ecs":       {"ecsv21", "ecsv11", "ecsv2"},
+	"evs":       {"evsv21", "evsv1", "evsv5"},
+	"ims":       {"imsv1"},
 	"cce":       {"ccev1", "cce_addon"},
 	"cci":       {"cciv1_bata"},
 	"vpc":       {"networkv2", "vpcv3", "security_group", "fwv2"},
@@ -107,6 +108,10 @@ var allServiceCatalog = map[string]ServiceCatalog{
 		Name:    "ecs",
 		Version: "v2.1",
 	},
+	"ecsv2": {
+		Name:    "ecs",
+		Version: "v2",
+	},
 	"autoscaling": {
 		Name:    "as",
 		Version: "autoscaling-api/v1",
@@ -116,6 +121,11 @@ var allServiceCatalog = map[string]ServiceCatalog{
 		Version:          "v2",
 		WithOutProjectID: true,
 	},
+	"imsv1": {
+		Name:             "ims",
+		Version:          "v1",
+		WithOutProjectID: true,
+	},
 	"ccev1": {
 		Name:             "cce",
 		Version:          "api/v1",
@@ -167,6 +177,14 @@ var allServiceCatalog = map[string]ServiceCatalog{
 		Name:    "evs",
 		Version: "v2.1",
 	},
+	"evsv1": {
+		Name:    "evs",
+		Version: "v1",
+	},
+	"evsv5": {
+		Name:    "evs",
+		Version: "v5",
+	},
 	"sfs": {
 		Name:    "sfs",
 		Version: "v2",
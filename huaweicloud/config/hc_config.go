@@ -25,6 +25,11 @@ This file is used to impl the configuration of huaweicloud-sdk-go-v3 package and
 genetate service clients.
 */
 func buildAuthCredentials(c *Config, region string) (*basic.Credentials, error) {
+	// the temporary credentials must be refreshed before building the sdk-v3 clients
+	if err := c.refreshCredentials(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+
 	if c.AccessKey == "" || c.SecretKey == "" {
 		return nil, fmt.Errorf("access_key or secret_key is missing in the provider")
 	}
@@ -54,6 +59,10 @@ func buildAuthCredentials(c *Config, region string) (*basic.Credentials, error)
 }
 
 func buildGlobalAuthCredentials(c *Config, region string) (*global.Credentials, error) {
+	if err := c.refreshCredentials(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+
 	if c.AccessKey == "" || c.SecretKey == "" {
 		return nil, fmt.Errorf("access_key or secret_key is missing in the provider")
 	}
@@ -69,7 +78,8 @@ func buildGlobalAuthCredentials(c *Config, region string) (*global.Credentials,
 	return &credentials, nil
 }
 
-func buildHTTPConfig(c *Config) *hc_config.HttpConfig {
+// buildHTTPConfig builds the HttpConfig of the service client, the endpoint is used to throttle the requests.
+func buildHTTPConfig(c *Config, endpoint string) *hc_config.HttpConfig {
 	httpConfig := hc_config.DefaultHttpConfig()
 
 	if c.MaxRetries > 0 {
@@ -80,9 +90,30 @@ func buildHTTPConfig(c *Config) *hc_config.HttpConfig {
 		httpConfig = httpConfig.WithIgnoreSSLVerification(true)
 	}
 
+	// the response passed to the handler does not contain the request, so get the host from the endpoint
+	var host string
+	if parsed, err := url.Parse(endpoint); err == nil {
+		host = parsed.Host
+	}
+
+	rateLimiter := c.RateLimiter
+	logger := &sdkHTTPLogger{
+		redactor: c.LogRedactor,
+		format:   c.LogFormat,
+		service:  rateLimiter.serviceName(host),
+	}
 	httpHandler := httphandler.NewHttpHandler().
-		AddRequestHandler(logRequestHandler).
-		AddResponseHandler(logResponseHandler)
+		AddRequestHandler(func(request http.Request) {
+			if err := rateLimiter.Wait(request.Context(), host); err != nil {
+				log.Printf("[WARN] failed to wait for the rate limiter: %s", err)
+			}
+			logger.logRequestHandler(request)
+		}).
+		AddResponseHandler(func(response http.Response) {
+			rateLimiter.Observe(host, &response)
+			logger.logResponseHandler(response)
+		}).
+		AddMonitorHandler(logger.logMonitorHandler)
 	httpConfig = httpConfig.WithHttpHandler(httpHandler)
 
 	if proxyURL := getProxyFromEnv(); proxyURL != "" {
@@ -104,6 +135,12 @@ func buildHTTPConfig(c *Config) *hc_config.HttpConfig {
 		}
 	}
 
+	// the requests are served by the loopback server of the recorder, see HTTPRecorder.SetLoopback
+	if recorder := getHTTPRecorder(); recorder != nil && recorder.loopback != "" {
+		httpConfig.HttpProxy = nil
+		httpConfig = httpConfig.WithIgnoreSSLVerification(true).WithDialContext(recorder.dialLoopback)
+	}
+
 	return httpConfig
 }
 
@@ -113,23 +150,26 @@ func getServiceEndpoint(c *Config, srv, region string) string {
 		return endpoint
 	}
 
-	// get the endpoint from build-in catalog
+	// get the endpoint from the discovered catalog or build-in catalog
 	catalog, ok := allServiceCatalog[srv]
 	if !ok {
 		return ""
 	}
 
-	var ep string
-	if catalog.Scope == "global" && !c.RegionClient {
-		ep = fmt.Sprintf("https://%s.%s/", catalog.Name, c.Cloud)
-	} else {
-		ep = fmt.Sprintf("https://%s.%s.%s/", catalog.Name, region, c.Cloud)
-	}
+	ep, _ := c.defaultEndpoint(catalog, region)
 	return ep
 }
 
 // NewVpcClient is the VPC service client using huaweicloud-sdk-go-v3 package
 func NewVpcClient(c *Config, region string) (*vpc.VpcClient, error) {
+	// the cached clients are invalidated after the credentials are refreshed
+	if err := c.refreshCredentials(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+	if client, ok := c.ClientCache.GetSdkClient("vpc", region, c.getCachedProjectID(region)); ok {
+		return client.(*vpc.VpcClient), nil
+	}
+
 	credentials, err := buildAuthCredentials(c, region)
 	if err != nil {
 		return nil, err
@@ -140,16 +180,27 @@ func NewVpcClient(c *Config, region string) (*vpc.VpcClient, error) {
 		return nil, fmt.Errorf("failed to get the endpoint of VPC service")
 	}
 
-	return vpc.NewVpcClient(
+	client := vpc.NewVpcClient(
 		vpc.VpcClientBuilder().
 			WithEndpoint(vpcEndpoint).
 			WithCredential(*credentials).
-			WithHttpConfig(buildHTTPConfig(c)).
-			Build()), nil
+			WithHttpConfig(buildHTTPConfig(c, vpcEndpoint)).
+			Build())
+	c.ClientCache.PutSdkClient("vpc", region, credentials.ProjectId, client)
+	return client, nil
 }
 
 // NewTmsClient is the TMS service client using huaweicloud-sdk-go-v3 package
 func NewTmsClient(c *Config, region string) (*tms.TmsClient, error) {
+	if err := c.refreshCredentials(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+	// the endpoint of TMS depends on the region, so the client is cached by region and project like the others
+	projectID := c.getCachedProjectID(region)
+	if client, ok := c.ClientCache.GetSdkClient("tms", region, projectID); ok {
+		return client.(*tms.TmsClient), nil
+	}
+
 	credentials, err := buildGlobalAuthCredentials(c, region)
 	if err != nil {
 		return nil, err
@@ -160,12 +211,14 @@ func NewTmsClient(c *Config, region string) (*tms.TmsClient, error) {
 		return nil, fmt.Errorf("failed to get the endpoint of TMS service")
 	}
 
-	return tms.NewTmsClient(
+	client := tms.NewTmsClient(
 		tms.TmsClientBuilder().
 			WithEndpoint(tmsEndpoint).
 			WithCredential(*credentials).
-			WithHttpConfig(buildHTTPConfig(c)).
-			Build()), nil
+			WithHttpConfig(buildHTTPConfig(c, tmsEndpoint)).
+			Build())
+	c.ClientCache.PutSdkClient("tms", region, projectID, client)
+	return client, nil
 }
 
 func getProxyFromEnv() string {
@@ -182,34 +235,107 @@ func getProxyFromEnv() string {
 	return url
 }
 
-func logRequestHandler(request http.Request) {
+// sdkHTTPLogger logs the requests and responses of huaweicloud-sdk-go-v3 clients,
+// it uses the same LogRedactor as LogRoundTripper.
+type sdkHTTPLogger struct {
+	redactor *LogRedactor
+	format   string
+	service  string
+}
+
+func (l *sdkHTTPLogger) logRequestHandler(request http.Request) {
 	if !logging.IsDebugOrHigher() {
 		return
 	}
 
+	if l.format == LogFormatJSON {
+		entry := httpLogEntry{
+			Type:    "request",
+			Service: l.service,
+			Method:  request.Method,
+			URL:     request.URL.String(),
+			Headers: l.redactor.RedactHeaders(request.Header),
+		}
+		if request.Body != nil && strings.HasPrefix(request.Header.Get("Content-Type"), "application/json") {
+			entry.Body = l.readJSONBody(request.Body)
+		}
+		logJSONLine(&entry)
+		return
+	}
+
 	log.Printf("[DEBUG] API Request URL: %s %s", request.Method, request.URL)
-	log.Printf("[DEBUG] API Request Headers:\n%s", FormatHeaders(request.Header, "\n"))
+	log.Printf("[DEBUG] API Request Headers:\n%s", l.redactor.FormatHeaders(request.Header, "\n"))
 	if request.Body != nil {
-		if err := logRequest(request.Body, request.Header.Get("Content-Type")); err != nil {
+		if err := l.logRequest(request.Body, request.Header.Get("Content-Type")); err != nil {
 			log.Printf("[WARN] failed to get request body: %s", err)
 		}
 	}
 }
 
-func logResponseHandler(response http.Response) {
+func (l *sdkHTTPLogger) logResponseHandler(response http.Response) {
 	if !logging.IsDebugOrHigher() {
 		return
 	}
 
+	if l.format == LogFormatJSON {
+		entry := httpLogEntry{
+			Type:       "response",
+			Service:    l.service,
+			StatusCode: response.StatusCode,
+			RequestID:  response.Header.Get("X-Request-Id"),
+			Headers:    l.redactor.RedactHeaders(response.Header),
+		}
+		if strings.HasPrefix(response.Header.Get("Content-Type"), "application/json") {
+			entry.Body = l.readJSONBody(response.Body)
+		}
+		logJSONLine(&entry)
+		return
+	}
+
 	log.Printf("[DEBUG] API Response Code: %d", response.StatusCode)
-	log.Printf("[DEBUG] API Response Headers:\n%s", FormatHeaders(response.Header, "\n"))
+	log.Printf("[DEBUG] API Response Headers:\n%s", l.redactor.FormatHeaders(response.Header, "\n"))
 
-	if err := logResponse(response.Body, response.Header.Get("Content-Type")); err != nil {
+	if err := l.logResponse(response.Body, response.Header.Get("Content-Type")); err != nil {
 		log.Printf("[WARN] failed to get response body: %s", err)
 	}
 }
 
-func logRequest(original io.ReadCloser, contentType string) error {
+// logMonitorHandler logs the latency of the request, it is the only way to get the latency from the SDK.
+func (l *sdkHTTPLogger) logMonitorHandler(metric *httphandler.MonitorMetric) {
+	if !logging.IsDebugOrHigher() || l.format != LogFormatJSON {
+		return
+	}
+
+	logJSONLine(&httpLogEntry{
+		Type:       "metric",
+		Service:    l.service,
+		Method:     metric.Method,
+		URL:        fmt.Sprintf("%s%s", metric.Host, metric.Path),
+		StatusCode: metric.StatusCode,
+		RequestID:  metric.RequestId,
+		LatencyMs:  latencyInMs(metric.Latency),
+	})
+}
+
+// readJSONBody reads the dumped body and returns the redacted JSON data.
+func (l *sdkHTTPLogger) readJSONBody(original io.ReadCloser) interface{} {
+	defer original.Close()
+
+	var bs bytes.Buffer
+	if _, err := io.Copy(&bs, original); err != nil {
+		log.Printf("[WARN] failed to get the body: %s", err)
+		return nil
+	}
+
+	body := bs.Bytes()
+	index := findJSONIndex(body)
+	if index == -1 {
+		return nil
+	}
+	return l.redactor.parseJSONBody(body[index:])
+}
+
+func (l *sdkHTTPLogger) logRequest(original io.ReadCloser, contentType string) error {
 	defer original.Close()
 
 	var bs bytes.Buffer
@@ -226,7 +352,7 @@ func logRequest(original io.ReadCloser, contentType string) error {
 
 	// Handle request contentType
 	if strings.HasPrefix(contentType, "application/json") {
-		debugInfo := formatJSON(body[index:], true)
+		debugInfo := l.redactor.formatJSON(body[index:], true)
 		log.Printf("[DEBUG] API Request Body: %s", debugInfo)
 	} else {
 		log.Printf("[DEBUG] Not logging because the request body isn't JSON")
@@ -237,7 +363,7 @@ func logRequest(original io.ReadCloser, contentType string) error {
 
 // logResponse will log the HTTP Response details.
 // If the body is JSON, it will attempt to be pretty-formatted.
-func logResponse(original io.ReadCloser, contentType string) error {
+func (l *sdkHTTPLogger) logResponse(original io.ReadCloser, contentType string) error {
 	defer original.Close()
 
 	var bs bytes.Buffer
@@ -253,7 +379,7 @@ func logResponse(original io.ReadCloser, contentType string) error {
 	}
 
 	if strings.HasPrefix(contentType, "application/json") {
-		debugInfo := formatJSON(body[index:], true)
+		debugInfo := l.redactor.formatJSON(body[index:], true)
 		log.Printf("[DEBUG] API Response Body: %s", debugInfo)
 	} else {
 		log.Printf("[DEBUG] Not logging because the response body isn't JSON")
@@ -2,18 +2,13 @@ package config
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
-	"sort"
 	"strings"
 	"time"
-
-	"github.com/unknwon/com"
 )
 
 // MAXFieldLength is the maximum string length of single field when logging
@@ -24,18 +19,21 @@ var maxTimeout = 10 * time.Minute
 // LogRoundTripper satisfies the http.RoundTripper interface and is used to
 // customize the default http client RoundTripper to allow for logging.
 type LogRoundTripper struct {
-	Rt         http.RoundTripper
-	OsDebug    bool
-	MaxRetries int
+	Rt          http.RoundTripper
+	OsDebug     bool
+	MaxRetries  int
+	RateLimiter *ServiceRateLimiter
+	// Redactor is used to mask the sensitive headers and fields, the default rules will be used if it's nil
+	Redactor *LogRedactor
+	// LogFormat is the format of the debug log, defaults to LogFormatText
+	LogFormat string
+	// Credentials is used to re-sign the requests which were signed with the expired temporary credentials
+	Credentials *CredentialManager
 }
 
+// retryTimeout returns a jittered exponential backoff duration, won't wait more than maxTimeout
 func retryTimeout(count int) time.Duration {
-	seconds := math.Pow(2, float64(count))
-	timeout := time.Duration(seconds) * time.Second
-	if timeout > maxTimeout { // won't wait more than maxTimeout
-		timeout = maxTimeout
-	}
-	return timeout
+	return jitteredBackoff(time.Second, uint(count), maxTimeout)
 }
 
 // RoundTrip performs a round-trip HTTP request and logs relevant information about it.
@@ -51,18 +49,29 @@ func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, er
 
 	var err error
 
-	if lrt.OsDebug {
-		log.Printf("[DEBUG] API Request URL: %s %s", request.Method, request.URL)
-		log.Printf("[DEBUG] API Request Headers:\n%s", FormatHeaders(request.Header, "\n"))
+	lrt.Credentials.resignRequest(request)
 
-		if request.Body != nil {
-			request.Body, err = lrt.logRequest(request.Body, request.Header.Get("Content-Type"))
-			if err != nil {
-				return nil, err
+	if lrt.OsDebug {
+		if lrt.LogFormat == LogFormatJSON {
+			request.Body, err = lrt.logRequestJSON(request)
+		} else {
+			log.Printf("[DEBUG] API Request URL: %s %s", request.Method, request.URL)
+			log.Printf("[DEBUG] API Request Headers:\n%s", lrt.Redactor.FormatHeaders(request.Header, "\n"))
+
+			if request.Body != nil {
+				request.Body, err = lrt.logRequest(request.Body, request.Header.Get("Content-Type"))
 			}
 		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = lrt.RateLimiter.Wait(request.Context(), request.URL.Host); err != nil {
+		return nil, err
 	}
 
+	startTime := time.Now()
 	response, err := lrt.Rt.RoundTrip(request)
 	if response == nil {
 		errMessage := err.Error()
@@ -88,15 +97,24 @@ func (lrt *LogRoundTripper) RoundTrip(request *http.Request) (*http.Response, er
 		}
 		//lintignore:R018
 		time.Sleep(retryTimeout(retry))
+		if err = lrt.RateLimiter.Wait(request.Context(), request.URL.Host); err != nil {
+			return nil, err
+		}
 		response, err = lrt.Rt.RoundTrip(request)
 		retry++
 	}
+	latency := time.Since(startTime)
+	lrt.RateLimiter.Observe(request.URL.Host, response)
 
 	if lrt.OsDebug {
-		log.Printf("[DEBUG] API Response Code: %d", response.StatusCode)
-		log.Printf("[DEBUG] API Response Headers:\n%s", FormatHeaders(response.Header, "\n"))
+		if lrt.LogFormat == LogFormatJSON {
+			response.Body, err = lrt.logResponseJSON(request, response, latency, retry-1)
+		} else {
+			log.Printf("[DEBUG] API Response Code: %d", response.StatusCode)
+			log.Printf("[DEBUG] API Response Headers:\n%s", lrt.Redactor.FormatHeaders(response.Header, "\n"))
 
-		response.Body, err = lrt.logResponse(response.Body, response.Header.Get("Content-Type"))
+			response.Body, err = lrt.logResponse(response.Body, response.Header.Get("Content-Type"))
+		}
 	}
 
 	return response, err
@@ -115,7 +133,7 @@ func (lrt *LogRoundTripper) logRequest(original io.ReadCloser, contentType strin
 
 	// Handle request contentType
 	if strings.HasPrefix(contentType, "application/json") {
-		debugInfo := formatJSON(bs.Bytes(), true)
+		debugInfo := lrt.Redactor.formatJSON(bs.Bytes(), true)
 		log.Printf("[DEBUG] API Request Body: %s", debugInfo)
 	} else {
 		log.Printf("[DEBUG] Not logging because the request body isn't JSON")
@@ -134,7 +152,7 @@ func (lrt *LogRoundTripper) logResponse(original io.ReadCloser, contentType stri
 		if err != nil {
 			return nil, err
 		}
-		debugInfo := formatJSON(bs.Bytes(), true)
+		debugInfo := lrt.Redactor.formatJSON(bs.Bytes(), true)
 		if debugInfo != "" {
 			log.Printf("[DEBUG] API Response Body: %s", debugInfo)
 		}
@@ -145,104 +163,59 @@ func (lrt *LogRoundTripper) logResponse(original io.ReadCloser, contentType stri
 	return original, nil
 }
 
-// formatJSON will try to pretty-format a JSON body.
-// It will also mask known fields which contain sensitive information.
-func formatJSON(raw []byte, maskBody bool) string {
-	var data map[string]interface{}
-
-	err := json.Unmarshal(raw, &data)
-	if err != nil {
-		log.Printf("[DEBUG] Unable to parse JSON: %s", err)
-		return string(raw)
-	}
-
-	// Mask known password fields
-	if maskBody {
-		maskSecurityFields(data)
-	}
-
-	// Ignore the catalog
-	if _, ok := data["catalog"]; ok {
-		return "{ **skipped** }"
-	}
-	if v, ok := data["token"].(map[string]interface{}); ok {
-		if _, ok := v["catalog"]; ok {
-			return ""
-		}
-	}
-
-	pretty, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		log.Printf("[DEBUG] Unable to re-marshal JSON: %s", err)
-		return string(raw)
+// logRequestJSON logs the HTTP Request as a JSON line and returns the rebuilt request body.
+func (lrt *LogRoundTripper) logRequestJSON(request *http.Request) (io.ReadCloser, error) {
+	entry := httpLogEntry{
+		Type:    "request",
+		Service: lrt.RateLimiter.serviceName(request.URL.Host),
+		Method:  request.Method,
+		URL:     request.URL.String(),
+		Headers: lrt.Redactor.RedactHeaders(request.Header),
 	}
 
-	return string(pretty)
-}
+	body := request.Body
+	if body != nil && strings.HasPrefix(request.Header.Get("Content-Type"), "application/json") {
+		defer body.Close()
 
-// REDACT_HEADERS is a list of headers that need to be redacted
-var REDACT_HEADERS = []string{
-	"x-auth-token", "x-security-token", "x-service-token",
-	"x-subject-token", "x-storage-token", "authorization",
-}
-
-// RedactHeaders processes a headers object, returning a redacted list
-func RedactHeaders(headers http.Header) (processedHeaders []string) {
-	for name, header := range headers {
-		for _, v := range header {
-			if com.IsSliceContainsStr(REDACT_HEADERS, name) {
-				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, "***"))
-			} else {
-				processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, v))
-			}
+		var bs bytes.Buffer
+		if _, err := io.Copy(&bs, body); err != nil {
+			return nil, err
 		}
+		entry.Body = lrt.Redactor.parseJSONBody(bs.Bytes())
+		body = ioutil.NopCloser(strings.NewReader(bs.String()))
 	}
-	return
-}
 
-// FormatHeaders processes a headers object plus a deliminator, returning a string
-func FormatHeaders(headers http.Header, seperator string) string {
-	redactedHeaders := RedactHeaders(headers)
-	sort.Strings(redactedHeaders)
-
-	return strings.Join(redactedHeaders, seperator)
-}
-
-func maskSecurityFields(data map[string]interface{}) bool {
-	for k, val := range data {
-		switch val.(type) {
-		case string:
-			if isSecurityFields(k) {
-				data[k] = "***"
-			} else if len(val.(string)) > MAXFieldLength {
-				data[k] = "** large string **"
-			}
-		case map[string]interface{}:
-			subData := val.(map[string]interface{})
-			if masked := maskSecurityFields(subData); masked {
-				return true
-			}
-		}
-	}
-	return false
+	logJSONLine(&entry)
+	return body, nil
 }
 
-func isSecurityFields(field string) bool {
-	// "password" is apply to the most request JSON body
-	// "secret" is apply to the AK/SK response JSON body
-	if strings.Contains(field, "password") || strings.Contains(field, "secret") {
-		return true
-	}
+// logResponseJSON logs the HTTP Response as a JSON line and returns the rebuilt response body.
+func (lrt *LogRoundTripper) logResponseJSON(request *http.Request, response *http.Response, latency time.Duration,
+	retries int) (io.ReadCloser, error) {
+	entry := httpLogEntry{
+		Type:       "response",
+		Service:    lrt.RateLimiter.serviceName(request.URL.Host),
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		StatusCode: response.StatusCode,
+		RequestID:  response.Header.Get("X-Request-Id"),
+		LatencyMs:  latencyInMs(latency),
+		RetryCount: retries,
+		Headers:    lrt.Redactor.RedactHeaders(response.Header),
+	}
+
+	body := response.Body
+	if body != nil && strings.HasPrefix(response.Header.Get("Content-Type"), "application/json") {
+		defer body.Close()
 
-	// "adminPass" is apply to the ecs/bms instance request JSON body
-	// "adminPwd" is apply to the css cluster request JSON body
-	// 'encrypted_user_data' is apply to the function request JSON body of FunctionGraph
-	securityFields := []string{"adminPass", "adminPwd", "encrypted_user_data"}
-	for _, key := range securityFields {
-		if key == field {
-			return true
+		var bs bytes.Buffer
+		if _, err := io.Copy(&bs, body); err != nil {
+			return nil, err
 		}
+		entry.Body = lrt.Redactor.parseJSONBody(bs.Bytes())
+		body = ioutil.NopCloser(strings.NewReader(bs.String()))
 	}
 
-	return false
+	logJSONLine(&entry)
+	return body, nil
 }
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"sync"
+)
+
+// PlanDataCache is a concurrency-safe cache of the data queried by the plan-time validations, e.g. the flavors and
+// availability zones. The provider is configured for every plan, so the data is queried at most once per plan
+// no matter how many resources are validated.
+type PlanDataCache struct {
+	lock    sync.Mutex
+	entries map[string]*planDataEntry
+}
+
+type planDataEntry struct {
+	once  sync.Once
+	value interface{}
+	err   error
+}
+
+// NewPlanDataCache returns an empty PlanDataCache.
+func NewPlanDataCache() *PlanDataCache {
+	return &PlanDataCache{
+		entries: make(map[string]*planDataEntry),
+	}
+}
+
+// Load returns the cached value of the key, the loader is called to query the value if it's not cached yet.
+// The concurrent callers of the same key wait for the first loader, and the error of the loader is also cached.
+// A nil cache calls the loader every time.
+func (cache *PlanDataCache) Load(key string, loader func() (interface{}, error)) (interface{}, error) {
+	if cache == nil {
+		return loader()
+	}
+
+	cache.lock.Lock()
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &planDataEntry{}
+		cache.entries[key] = entry
+	}
+	cache.lock.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = loader()
+	})
+	return entry.value, entry.err
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+)
+
+func TestPlanDataCache_load(t *testing.T) {
+	cache := NewPlanDataCache()
+	var lock sync.Mutex
+	count := 0
+	loader := func() (interface{}, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		count++
+		return []string{"cn-north-4a", "cn-north-4b"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.Load("ecs_availability_zones/cn-north-4", loader)
+			th.AssertNoErr(t, err)
+			th.AssertDeepEquals(t, []string{"cn-north-4a", "cn-north-4b"}, value)
+		}()
+	}
+	wg.Wait()
+	th.AssertEquals(t, 1, count)
+
+	// the keys are cached separately
+	_, err := cache.Load("ecs_availability_zones/cn-south-1", loader)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, count)
+}
+
+func TestPlanDataCache_error(t *testing.T) {
+	cache := NewPlanDataCache()
+	count := 0
+	loader := func() (interface{}, error) {
+		count++
+		return nil, fmt.Errorf("the request is throttled")
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.Load("ecs_flavors/cn-north-4", loader)
+		th.AssertEquals(t, "the request is throttled", err.Error())
+	}
+	th.AssertEquals(t, 1, count)
+
+	// a nil cache calls the loader every time
+	var nilCache *PlanDataCache
+	for i := 0; i < 3; i++ {
+		_, _ = nilCache.Load("ecs_flavors/cn-north-4", loader)
+	}
+	th.AssertEquals(t, 4, count)
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxBackoffDuration is the maximum duration to wait between two retries when the response header
+	// does not contain a retry hint.
+	maxBackoffDuration = 60 * time.Second
+	// maxRetryAfterDuration is the maximum duration we will honor from the Retry-After header.
+	maxRetryAfterDuration = 10 * time.Minute
+)
+
+// rateLimiter is a simple token bucket with one token, the requests will be sent with an interval
+// of 1/rps seconds. A Retry-After hint from the server will block all requests until it expires.
+type rateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(rps float64) *rateLimiter {
+	var interval time.Duration
+	if rps > 0 {
+		interval = time.Duration(float64(time.Second) / rps)
+	}
+	return &rateLimiter{interval: interval}
+}
+
+// reserve returns the duration that the caller must wait before sending a request.
+func (l *rateLimiter) reserve() time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// delayUntil blocks the subsequent requests until the specified time.
+func (l *rateLimiter) delayUntil(until time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if until.After(l.next) {
+		l.next = until
+	}
+}
+
+// ServiceRateLimiter is a group of rate limiters keyed by the service catalog name,
+// the requests of the services which are not limited will be throttled only by the Retry-After hints.
+type ServiceRateLimiter struct {
+	lock sync.Mutex
+	// rates stores the requests per second of each service name, e.g. ecs, vpc
+	rates map[string]float64
+	// hosts stores the service name of the custom endpoint hosts
+	hosts    map[string]string
+	limiters map[string]*rateLimiter
+}
+
+// NewServiceRateLimiter creates a ServiceRateLimiter, the key of rates is the service catalog key
+// (see allServiceCatalog) and the value is the maximum requests per second.
+// All the catalogs which have the same service name will share the same limiter.
+func NewServiceRateLimiter(rates map[string]float64, endpoints map[string]string) (*ServiceRateLimiter, error) {
+	limiter := ServiceRateLimiter{
+		rates:    make(map[string]float64),
+		hosts:    make(map[string]string),
+		limiters: make(map[string]*rateLimiter),
+	}
+
+	for key, rps := range rates {
+		catalog, ok := allServiceCatalog[key]
+		if !ok {
+			return nil, fmt.Errorf("the service %s in rate_limits is invalid or not supported", key)
+		}
+		if rps <= 0 {
+			return nil, fmt.Errorf("the rate limit of service %s must be greater than 0, but got %v", key, rps)
+		}
+		limiter.rates[catalog.Name] = rps
+	}
+
+	for key, endpoint := range endpoints {
+		catalog, ok := allServiceCatalog[key]
+		if !ok {
+			continue
+		}
+		limiter.addHost(endpoint, catalog.Name)
+	}
+
+	return &limiter, nil
+}
+
+// addHost makes the requests to the host of the endpoint share the limiter of the service
+func (s *ServiceRateLimiter) addHost(endpoint, name string) {
+	if s == nil {
+		return
+	}
+	if parsed, err := url.Parse(endpoint); err == nil && parsed.Host != "" {
+		s.hosts[strings.ToLower(parsed.Host)] = name
+	}
+}
+
+// serviceName returns the service name of the host, the name is the first part of the build-in host
+// which likes {Name}.{Region}.{Cloud} if it is not a custom endpoint.
+func (s *ServiceRateLimiter) serviceName(host string) string {
+	host = strings.ToLower(host)
+	if s != nil {
+		if name, ok := s.hosts[host]; ok {
+			return name
+		}
+	}
+	if index := strings.Index(host, "."); index > 0 {
+		return host[:index]
+	}
+	return host
+}
+
+func (s *ServiceRateLimiter) getLimiter(host string) *rateLimiter {
+	name := s.serviceName(host)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	limiter, ok := s.limiters[name]
+	if !ok {
+		limiter = newRateLimiter(s.rates[name])
+		s.limiters[name] = limiter
+	}
+	return limiter
+}
+
+// Wait blocks until the request to the host is allowed to be sent or the context is done.
+func (s *ServiceRateLimiter) Wait(ctx context.Context, host string) error {
+	if s == nil {
+		return nil
+	}
+
+	wait := s.getLimiter(host).reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] rate limiting the request to %s, waiting %s", host, wait)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Observe parses the rate limit headers of the response and delays the subsequent requests to the host if necessary.
+func (s *ServiceRateLimiter) Observe(host string, response *http.Response) {
+	if s == nil || response == nil {
+		return
+	}
+
+	if wait, ok := parseRateLimitHeaders(response); ok && wait > 0 {
+		log.Printf("[WARN] the requests to %s are throttled, the subsequent requests will be delayed %s", host, wait)
+		s.getLimiter(host).delayUntil(time.Now().Add(wait))
+	}
+}
+
+// parseRateLimitHeaders returns the duration to wait parsed from the Retry-After or X-RateLimit-* headers.
+// The second return value is false if there is no hint in the headers.
+func parseRateLimitHeaders(response *http.Response) (time.Duration, bool) {
+	header := response.Header
+
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable {
+		if wait, ok := parseRetryAfter(header.Get("Retry-After")); ok {
+			return wait, true
+		}
+	}
+
+	remaining := header.Get("X-RateLimit-Remaining")
+	if response.StatusCode != http.StatusTooManyRequests && remaining != "0" {
+		return 0, false
+	}
+
+	if reset := header.Get("X-RateLimit-Reset"); reset != "" {
+		if wait, ok := parseRateLimitReset(reset); ok {
+			return wait, true
+		}
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses the Retry-After header which is either delay-seconds or HTTP-date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	return limitDuration(wait, maxRetryAfterDuration), true
+}
+
+// parseRateLimitReset parses the X-RateLimit-Reset header which is either the seconds to wait
+// or an unix timestamp (in seconds) when the quota will be reset.
+func parseRateLimitReset(value string) (time.Duration, bool) {
+	reset, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || reset < 0 {
+		return 0, false
+	}
+
+	var wait time.Duration
+	// a value which is larger than one year must be a timestamp
+	if reset > 365*24*3600 {
+		wait = time.Until(time.Unix(reset, 0))
+	} else {
+		wait = time.Duration(reset) * time.Second
+	}
+
+	return limitDuration(wait, maxRetryAfterDuration), true
+}
+
+func limitDuration(d, max time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// jitteredBackoff returns an exponential backoff duration (base*2^retries) with a random jitter,
+// the result is between half and the full backoff duration and won't be greater than max.
+func jitteredBackoff(base time.Duration, retries uint, max time.Duration) time.Duration {
+	backoff := time.Duration(float64(base) * math.Pow(2, float64(retries)))
+	if backoff <= 0 || backoff > max {
+		backoff = max
+	}
+
+	half := int64(backoff / 2)
+	if half <= 0 {
+		return backoff
+	}
+	return time.Duration(half + rand.Int63n(half+1))
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	wait, ok := parseRetryAfter("5")
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, 5*time.Second, wait)
+
+	date := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	wait, ok = parseRetryAfter(date)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, true, wait > 25*time.Second && wait <= 30*time.Second)
+
+	wait, ok = parseRetryAfter("86400")
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, maxRetryAfterDuration, wait)
+
+	_, ok = parseRetryAfter("invalid")
+	th.AssertEquals(t, false, ok)
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	}
+	resp.Header.Set("X-RateLimit-Remaining", "10")
+	resp.Header.Set("X-RateLimit-Reset", "3")
+	_, ok := parseRateLimitHeaders(resp)
+	th.AssertEquals(t, false, ok)
+
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	wait, ok := parseRateLimitHeaders(resp)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, 3*time.Second, wait)
+
+	resp.StatusCode = http.StatusTooManyRequests
+	resp.Header.Set("Retry-After", "7")
+	wait, ok = parseRateLimitHeaders(resp)
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, 7*time.Second, wait)
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	for i := uint(0); i < 10; i++ {
+		backoff := jitteredBackoff(time.Second, i, maxBackoffDuration)
+		th.AssertEquals(t, true, backoff <= maxBackoffDuration)
+
+		full := time.Second << i
+		if full > maxBackoffDuration {
+			full = maxBackoffDuration
+		}
+		th.AssertEquals(t, true, backoff >= full/2 && backoff <= full)
+	}
+}
+
+func TestServiceRateLimiter(t *testing.T) {
+	_, err := NewServiceRateLimiter(map[string]float64{"invalid": 1}, nil)
+	th.AssertEquals(t, true, err != nil)
+
+	limiter, err := NewServiceRateLimiter(map[string]float64{"ecs": 20},
+		map[string]string{"vpc": "https://vpc.example.com/"})
+	th.AssertNoErr(t, err)
+
+	// the catalogs of different versions share the same service name
+	th.AssertEquals(t, "ecs", limiter.serviceName("ecs.cn-north-4.myhuaweicloud.com"))
+	th.AssertEquals(t, "vpc", limiter.serviceName("vpc.example.com"))
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		th.AssertNoErr(t, limiter.Wait(context.Background(), "ecs.cn-north-4.myhuaweicloud.com"))
+	}
+	// 5 requests with 20 rps need at least 200ms
+	th.AssertEquals(t, true, time.Since(start) >= 190*time.Millisecond)
+
+	// the unlimited service should not be blocked
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		th.AssertNoErr(t, limiter.Wait(context.Background(), "vpc.example.com"))
+	}
+	th.AssertEquals(t, true, time.Since(start) < 50*time.Millisecond)
+
+	// the Retry-After hint blocks the subsequent requests
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"1"}},
+	}
+	limiter.Observe("vpc.example.com", resp)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	th.AssertEquals(t, context.DeadlineExceeded, limiter.Wait(ctx, "vpc.example.com"))
+}
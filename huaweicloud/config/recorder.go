@@ -0,0 +1,366 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// the modes of HTTPRecorder
+const (
+	// RecordModeRecord sends the requests to the cloud and saves the sanitized interactions into the cassette
+	RecordModeRecord = "record"
+	// RecordModeReplay serves the requests from the cassette without any network access
+	RecordModeReplay = "replay"
+)
+
+// the response headers which are kept in the cassette, others are dropped to keep the cassette small
+var recordedResponseHeaders = []string{
+	"Content-Type", "Location", "Retry-After", "X-Request-Id", "X-Subject-Token", "X-Openstack-Request-Id",
+}
+
+// recordedRequest is the request of an interaction, the body is kept for reading only,
+// and BodyHash is used to match the request.
+type recordedRequest struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	BodyHash string      `json:"body_hash,omitempty"`
+	Body     interface{} `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type cassette struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+// HTTPRecorder records the HTTP interactions into a cassette file and replays them in the offline acceptance tests.
+// The interactions with the same method, URL and body are served in the recorded order, and the last one will be
+// repeated when they are exhausted, so the polling sequences of resource.StateChangeConf are replayed as recorded.
+type HTTPRecorder struct {
+	mode     string
+	path     string
+	redactor *LogRedactor
+	// upstream is the transport used to forward the requests received by ServeHTTP in record mode
+	upstream http.RoundTripper
+
+	lock     sync.Mutex
+	cassette cassette
+	// served stores the count of served interactions of each request key in replay mode
+	served map[string]int
+	// loopback is the address of the TLS server which serves the huaweicloud-sdk-go-v3 requests
+	loopback string
+}
+
+var (
+	httpRecorder     *HTTPRecorder
+	httpRecorderLock sync.RWMutex
+)
+
+// SetHTTPRecorder sets the recorder used by the clients created afterwards, nil disables the recording.
+func SetHTTPRecorder(r *HTTPRecorder) {
+	httpRecorderLock.Lock()
+	defer httpRecorderLock.Unlock()
+	httpRecorder = r
+}
+
+func getHTTPRecorder() *HTTPRecorder {
+	httpRecorderLock.RLock()
+	defer httpRecorderLock.RUnlock()
+	return httpRecorder
+}
+
+// NewHTTPRecorder creates a recorder with the cassette file, the cassette must exist in replay mode.
+func NewHTTPRecorder(mode, path string) (*HTTPRecorder, error) {
+	r := HTTPRecorder{
+		mode:     mode,
+		path:     path,
+		redactor: defaultRedactor,
+		upstream: &http.Transport{Proxy: http.ProxyFromEnvironment},
+		served:   make(map[string]int),
+	}
+
+	switch mode {
+	case RecordModeRecord:
+		return &r, nil
+	case RecordModeReplay:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading the cassette %s: %s", path, err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("Error parsing the cassette %s: %s", path, err)
+		}
+		return &r, nil
+	}
+	return nil, fmt.Errorf("unsupported record mode %q, the valid modes are: %s, %s",
+		mode, RecordModeRecord, RecordModeReplay)
+}
+
+// Mode returns the mode of the recorder
+func (r *HTTPRecorder) Mode() string {
+	return r.mode
+}
+
+// Save writes the recorded interactions into the cassette file, it does nothing in replay mode.
+func (r *HTTPRecorder) Save() error {
+	if r.mode != RecordModeRecord {
+		return nil
+	}
+
+	r.lock.Lock()
+	data, err := json.MarshalIndent(&r.cassette, "", "  ")
+	r.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0600)
+}
+
+// Wrap returns a RoundTripper which records the requests sent by rt or replays them from the cassette,
+// it's used as the inner transport of LogRoundTripper.
+func (r *HTTPRecorder) Wrap(rt http.RoundTripper) http.RoundTripper {
+	return &recorderTransport{recorder: r, rt: rt}
+}
+
+// SetLoopback sets the address of the TLS server which serves the recorder as an http.Handler.
+// The huaweicloud-sdk-go-v3 clients always build their own transport and the HttpHandler can only observe
+// the requests, so their connections are dialed to the loopback server instead of the cloud.
+func (r *HTTPRecorder) SetLoopback(addr string) {
+	r.loopback = addr
+}
+
+func (r *HTTPRecorder) dialLoopback(ctx context.Context, network, _ string) (net.Conn, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, r.loopback)
+}
+
+// ServeHTTP forwards the requests of the loopback server to the cloud or replays them from the cassette.
+func (r *HTTPRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	target := "https://" + req.Host + req.URL.RequestURI()
+	outReq, err := http.NewRequestWithContext(req.Context(), req.Method, target, req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	outReq.Header = req.Header.Clone()
+	outReq.ContentLength = req.ContentLength
+
+	resp, err := r.Wrap(r.upstream).RoundTrip(outReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+type recorderTransport struct {
+	recorder *HTTPRecorder
+	rt       http.RoundTripper
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if t.recorder.mode == RecordModeReplay {
+		return t.recorder.replay(req, body)
+	}
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	t.recorder.record(req, body, resp, respBody)
+	return resp, nil
+}
+
+func (r *HTTPRecorder) record(req *http.Request, body []byte, resp *http.Response, respBody []byte) {
+	item := interaction{
+		Request: recordedRequest{
+			Method:   req.Method,
+			URL:      normalizeRecordedURL(req),
+			BodyHash: hashRequestBody(body),
+			Body:     r.sanitizeBody(body),
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    make(map[string]string),
+		},
+	}
+
+	for _, name := range recordedResponseHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			if r.redactor.IsRedactedHeader(name) {
+				v = redactedValue
+			}
+			item.Response.Headers[name] = v
+		}
+	}
+	item.Response.Body = string(respBody)
+	var data interface{}
+	if err := json.Unmarshal(respBody, &data); err == nil {
+		if encoded, err := json.Marshal(r.sanitizeValue(data, nil)); err == nil {
+			item.Response.Body = string(encoded)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &item)
+}
+
+func (r *HTTPRecorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	method := req.Method
+	reqURL := normalizeRecordedURL(req)
+	hash := hashRequestBody(body)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// match the body first, then fall back to the method and URL,
+	// as some request bodies contain the values which change in each run, e.g. the timestamps
+	var exact, loose []*interaction
+	for _, item := range r.cassette.Interactions {
+		if item.Request.Method != method || item.Request.URL != reqURL {
+			continue
+		}
+		loose = append(loose, item)
+		if item.Request.BodyHash == hash {
+			exact = append(exact, item)
+		}
+	}
+
+	key := fmt.Sprintf("%s %s %s", method, reqURL, hash)
+	candidates := exact
+	if len(candidates) == 0 {
+		key = fmt.Sprintf("%s %s", method, reqURL)
+		candidates = loose
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no interaction of %s %s is recorded in the cassette %s", method, reqURL, r.path)
+	}
+
+	index := r.served[key]
+	r.served[key]++
+	if index >= len(candidates) {
+		index = len(candidates) - 1
+	}
+	recorded := candidates[index].Response
+
+	resp := http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        make(http.Header),
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+	for k, v := range recorded.Headers {
+		resp.Header.Set(k, v)
+	}
+	return &resp, nil
+}
+
+// sanitizeBody masks the sensitive fields of the JSON body, the body which is not JSON is kept as a string.
+// Unlike the debug log, the large fields are kept as they may be used in the subsequent requests.
+func (r *HTTPRecorder) sanitizeBody(raw []byte) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return string(raw)
+	}
+	return r.sanitizeValue(data, nil)
+}
+
+func (r *HTTPRecorder) sanitizeValue(data interface{}, path []string) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			subPath := append(path[:len(path):len(path)], key)
+			if r.redactor.isRedactedField(subPath) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = r.sanitizeValue(val, subPath)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = r.sanitizeValue(val, path)
+		}
+	}
+	return data
+}
+
+// normalizeRecordedURL returns the URL with the sorted query parameters
+func normalizeRecordedURL(req *http.Request) string {
+	u := *req.URL
+	if u.Host == "" {
+		u.Host = req.Host
+	}
+
+	// Encode sorts the query by key
+	u.RawQuery = u.Query().Encode()
+	return u.String()
+}
+
+func hashRequestBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:8])
+}
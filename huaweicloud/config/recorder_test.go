@@ -0,0 +1,155 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+)
+
+// newPollingServer returns a server whose status changes from BUILD to ACTIVE and is deleted at last
+func newPollingServer() *httptest.Server {
+	var count int32
+	statuses := []string{"BUILD", "BUILD", "ACTIVE"}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Subject-Token", "secret-token")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"server": {"id": "server-id", "adminPass": "Passw0rd"}}`)
+		case "GET":
+			index := int(atomic.AddInt32(&count, 1)) - 1
+			if index >= len(statuses) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"server": {"id": "server-id", "status": "%s"}}`, statuses[index])
+		}
+	}))
+}
+
+func sendRecorderRequest(t *testing.T, rt http.RoundTripper, method, url, body string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	th.AssertNoErr(t, err)
+	resp, err := rt.RoundTrip(req)
+	th.AssertNoErr(t, err)
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	th.AssertNoErr(t, err)
+	return resp.StatusCode, string(data)
+}
+
+func TestHTTPRecorder_recordAndReplay(t *testing.T) {
+	server := newPollingServer()
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "cassette")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "cassette.json")
+
+	recorder, err := NewHTTPRecorder(RecordModeRecord, path)
+	th.AssertNoErr(t, err)
+	rt := recorder.Wrap(http.DefaultTransport)
+
+	code, _ := sendRecorderRequest(t, rt, "POST", server.URL+"/servers", `{"server": {"adminPass": "Passw0rd"}}`)
+	th.AssertEquals(t, http.StatusCreated, code)
+	for i := 0; i < 4; i++ {
+		sendRecorderRequest(t, rt, "GET", server.URL+"/servers/server-id?b=2&a=1", "")
+	}
+	th.AssertNoErr(t, recorder.Save())
+
+	// the sensitive headers and fields are not saved into the cassette
+	data, err := ioutil.ReadFile(path)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, strings.Contains(string(data), "Passw0rd"))
+	th.AssertEquals(t, false, strings.Contains(string(data), "secret-token"))
+
+	server.Close()
+	replayer, err := NewHTTPRecorder(RecordModeReplay, path)
+	th.AssertNoErr(t, err)
+	rt = replayer.Wrap(http.DefaultTransport)
+
+	code, body := sendRecorderRequest(t, rt, "POST", server.URL+"/servers", `{"server": {"adminPass": "Passw0rd"}}`)
+	th.AssertEquals(t, http.StatusCreated, code)
+	th.AssertEquals(t, true, strings.Contains(body, "server-id"))
+
+	// the polling sequence is served in the recorded order and the query order doesn't matter
+	for _, status := range []string{"BUILD", "BUILD", "ACTIVE"} {
+		_, body := sendRecorderRequest(t, rt, "GET", server.URL+"/servers/server-id?a=1&b=2", "")
+		th.AssertEquals(t, fmt.Sprintf(`{"server":{"id":"server-id","status":"%s"}}`, status), body)
+	}
+	code, _ = sendRecorderRequest(t, rt, "GET", server.URL+"/servers/server-id?a=1&b=2", "")
+	th.AssertEquals(t, http.StatusNotFound, code)
+	// the last interaction is repeated when the sequence is exhausted
+	code, _ = sendRecorderRequest(t, rt, "GET", server.URL+"/servers/server-id?a=1&b=2", "")
+	th.AssertEquals(t, http.StatusNotFound, code)
+
+	req, err := http.NewRequest("DELETE", server.URL+"/servers/server-id", nil)
+	th.AssertNoErr(t, err)
+	_, err = rt.RoundTrip(req)
+	if err == nil || !strings.Contains(err.Error(), "no interaction of DELETE") {
+		t.Fatalf("expected an error of the missing interaction but got %v", err)
+	}
+}
+
+func TestHTTPRecorder_bodyMatching(t *testing.T) {
+	recorder := &HTTPRecorder{mode: RecordModeReplay, served: make(map[string]int)}
+	recorder.cassette.Interactions = []*interaction{
+		{
+			Request:  recordedRequest{Method: "POST", URL: "https://vpc.example.com/vpcs", BodyHash: hashRequestBody([]byte("a"))},
+			Response: recordedResponse{StatusCode: 200, Body: "vpc-a"},
+		},
+		{
+			Request:  recordedRequest{Method: "POST", URL: "https://vpc.example.com/vpcs", BodyHash: hashRequestBody([]byte("b"))},
+			Response: recordedResponse{StatusCode: 200, Body: "vpc-b"},
+		},
+	}
+	rt := recorder.Wrap(nil)
+
+	_, body := sendRecorderRequest(t, rt, "POST", "https://vpc.example.com/vpcs", "b")
+	th.AssertEquals(t, "vpc-b", body)
+	_, body = sendRecorderRequest(t, rt, "POST", "https://vpc.example.com/vpcs", "a")
+	th.AssertEquals(t, "vpc-a", body)
+	// falls back to the method and URL if the body is not recorded
+	_, body = sendRecorderRequest(t, rt, "POST", "https://vpc.example.com/vpcs", "c")
+	th.AssertEquals(t, "vpc-a", body)
+}
+
+func TestHTTPRecorder_loopback(t *testing.T) {
+	recorder := &HTTPRecorder{mode: RecordModeReplay, served: make(map[string]int)}
+	recorder.cassette.Interactions = []*interaction{
+		{
+			Request: recordedRequest{Method: "GET", URL: "https://ecs.cn-north-4.myhuaweicloud.com/v1/servers"},
+			Response: recordedResponse{StatusCode: 200, Body: `{"servers": []}`,
+				Headers: map[string]string{"Content-Type": "application/json"}},
+		},
+	}
+	server := httptest.NewTLSServer(recorder)
+	defer server.Close()
+	recorder.SetLoopback(server.Listener.Addr().String())
+
+	SetHTTPRecorder(recorder)
+	defer SetHTTPRecorder(nil)
+
+	httpConfig := buildHTTPConfig(&Config{}, "https://ecs.cn-north-4.myhuaweicloud.com")
+	th.AssertEquals(t, true, httpConfig.IgnoreSSLVerification)
+
+	transport := &http.Transport{
+		DialContext:     httpConfig.DialContext,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: httpConfig.IgnoreSSLVerification},
+	}
+	code, body := sendRecorderRequest(t, transport, "GET", "https://ecs.cn-north-4.myhuaweicloud.com/v1/servers", "")
+	th.AssertEquals(t, http.StatusOK, code)
+	th.AssertEquals(t, `{"servers": []}`, body)
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// LogFormatText is the default log format which prints the request and response in multiple lines
+	LogFormatText = "text"
+	// LogFormatJSON prints the request and response as JSON lines which can be parsed by log tools
+	LogFormatJSON = "json"
+
+	redactedValue = "***"
+)
+
+// defaultRedactHeaders is a list of headers that need to be redacted
+var defaultRedactHeaders = []string{
+	"x-auth-token", "x-security-token", "x-service-token",
+	"x-subject-token", "x-storage-token", "authorization",
+}
+
+// defaultRedactFields is a list of JSON fields that need to be redacted in any depth:
+// "adminPass" and "admin_pass" are apply to the ecs/bms instance request JSON body
+// "adminPwd" is apply to the css cluster request JSON body
+// "encrypted_user_data" is apply to the function request JSON body of FunctionGraph
+// "securitytoken" and "security_token" are apply to the temporary credential JSON body
+var defaultRedactFields = []string{
+	"adminPass", "admin_pass", "adminPwd", "encrypted_user_data", "securitytoken", "security_token",
+}
+
+// LogRedactor masks the sensitive headers and JSON fields before logging,
+// it is shared by the golangsdk and huaweicloud-sdk-go-v3 clients.
+type LogRedactor struct {
+	// paths stores the JSON paths to be redacted, e.g. ["server", "adminPass"]
+	paths [][]string
+	// headers stores the header names in lower case
+	headers map[string]bool
+}
+
+// NewLogRedactor creates a LogRedactor with the default rules and the custom JSON paths and header names.
+// A JSON path is separated by dot and the "*" matches any key, e.g. "server.metadata.*",
+// a path without dot matches the field in any depth. The arrays in the JSON body are transparent for paths.
+func NewLogRedactor(fields, headers []string) *LogRedactor {
+	redactor := LogRedactor{
+		headers: make(map[string]bool),
+	}
+
+	for _, field := range append(defaultRedactFields, fields...) {
+		field = strings.TrimPrefix(strings.TrimSpace(field), "$.")
+		if field != "" {
+			redactor.paths = append(redactor.paths, strings.Split(field, "."))
+		}
+	}
+	for _, header := range append(defaultRedactHeaders, headers...) {
+		redactor.headers[strings.ToLower(strings.TrimSpace(header))] = true
+	}
+
+	return &redactor
+}
+
+// defaultRedactor is used when no redactor is specified
+var defaultRedactor = NewLogRedactor(nil, nil)
+
+func getRedactor(r *LogRedactor) *LogRedactor {
+	if r == nil {
+		return defaultRedactor
+	}
+	return r
+}
+
+// IsRedactedHeader returns true if the header should be redacted.
+func (r *LogRedactor) IsRedactedHeader(name string) bool {
+	return getRedactor(r).headers[strings.ToLower(name)]
+}
+
+// RedactHeaders returns the redacted headers as a map which the multiple values are joined by comma.
+func (r *LogRedactor) RedactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if r.IsRedactedHeader(name) {
+			result[name] = redactedValue
+		} else {
+			result[name] = strings.Join(values, ",")
+		}
+	}
+	return result
+}
+
+// FormatHeaders returns the sorted and redacted headers which are joined by the separator.
+func (r *LogRedactor) FormatHeaders(headers http.Header, separator string) string {
+	var processedHeaders []string
+	for name, values := range headers {
+		for _, v := range values {
+			if r.IsRedactedHeader(name) {
+				v = redactedValue
+			}
+			processedHeaders = append(processedHeaders, fmt.Sprintf("%v: %v", name, v))
+		}
+	}
+	sort.Strings(processedHeaders)
+
+	return strings.Join(processedHeaders, separator)
+}
+
+// RedactJSON masks the sensitive fields of the parsed JSON data in place,
+// and the large string fields will be replaced with a placeholder.
+func (r *LogRedactor) RedactJSON(data interface{}) interface{} {
+	return getRedactor(r).redactValue(data, nil)
+}
+
+func (r *LogRedactor) redactValue(data interface{}, path []string) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			subPath := append(path[:len(path):len(path)], key)
+			if r.isRedactedField(subPath) {
+				v[key] = redactedValue
+				continue
+			}
+			if str, ok := val.(string); ok && len(str) > MAXFieldLength {
+				v[key] = "** large string **"
+				continue
+			}
+			v[key] = r.redactValue(val, subPath)
+		}
+	case []interface{}:
+		// the array is transparent for the JSON path
+		for i, val := range v {
+			v[i] = r.redactValue(val, path)
+		}
+	}
+	return data
+}
+
+// isRedactedField checks whether the field matches any rules, the path is the keys from the root to the field.
+func (r *LogRedactor) isRedactedField(path []string) bool {
+	field := path[len(path)-1]
+	// "password" is apply to the most request JSON body
+	// "secret" is apply to the AK/SK response JSON body
+	lowerField := strings.ToLower(field)
+	if strings.Contains(lowerField, "password") || strings.Contains(lowerField, "secret") {
+		return true
+	}
+
+	for _, rule := range r.paths {
+		if matchJSONPath(rule, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchJSONPath(rule, path []string) bool {
+	if len(rule) == 1 {
+		return rule[0] == path[len(path)-1]
+	}
+	if len(rule) != len(path) {
+		return false
+	}
+
+	for i, key := range rule {
+		if key != "*" && key != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatJSON will try to pretty-format a JSON body.
+// It will also mask known fields which contain sensitive information.
+func (r *LogRedactor) formatJSON(raw []byte, maskBody bool) string {
+	var data map[string]interface{}
+
+	err := json.Unmarshal(raw, &data)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to parse JSON: %s", err)
+		return string(raw)
+	}
+
+	// Ignore the catalog
+	if _, ok := data["catalog"]; ok {
+		return "{ **skipped** }"
+	}
+	if v, ok := data["token"].(map[string]interface{}); ok {
+		if _, ok := v["catalog"]; ok {
+			return ""
+		}
+	}
+
+	// Mask known password fields
+	if maskBody {
+		r.RedactJSON(data)
+	}
+
+	pretty, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		log.Printf("[DEBUG] Unable to re-marshal JSON: %s", err)
+		return string(raw)
+	}
+
+	return string(pretty)
+}
+
+// parseJSONBody parses and redacts the JSON body for the JSON log format,
+// the body which is not JSON or contains the service catalog will be skipped.
+func (r *LogRedactor) parseJSONBody(raw []byte) interface{} {
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil
+	}
+
+	if m, ok := data.(map[string]interface{}); ok {
+		if _, ok := m["catalog"]; ok {
+			return "** skipped **"
+		}
+		if v, ok := m["token"].(map[string]interface{}); ok {
+			if _, ok := v["catalog"]; ok {
+				return "** skipped **"
+			}
+		}
+	}
+
+	return r.RedactJSON(data)
+}
+
+// httpLogEntry is a line of the JSON log format
+type httpLogEntry struct {
+	Type       string            `json:"type"`
+	Service    string            `json:"service,omitempty"`
+	Method     string            `json:"method,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	StatusCode int               `json:"status_code,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	LatencyMs  int64             `json:"latency_ms,omitempty"`
+	RetryCount int               `json:"retry_count,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       interface{}       `json:"body,omitempty"`
+}
+
+func logJSONLine(entry *httpLogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] Unable to marshal the log entry: %s", err)
+		return
+	}
+	log.Printf("[DEBUG] %s", line)
+}
+
+func latencyInMs(latency time.Duration) int64 {
+	return int64(latency / time.Millisecond)
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+)
+
+func TestLogRedactor_RedactJSON(t *testing.T) {
+	raw := `{
+  "server": {
+    "name": "ecs-demo",
+    "adminPass": "Test@123",
+    "metadata": {"admin_pass": "Test@123", "op_svc_userid": "abc"},
+    "extendparam": {"chargingMode": "postPaid"}
+  },
+  "instances": [{"manager_password": "Test@123", "secret_text": "text", "tenant": "t1"}],
+  "credential": {"access": "AK", "securitytoken": "token"},
+  "custom": {"sign": "xxx", "nested": {"sign": "yyy"}}
+}`
+	var data map[string]interface{}
+	th.AssertNoErr(t, json.Unmarshal([]byte(raw), &data))
+
+	redactor := NewLogRedactor([]string{"custom.sign", "server.extendparam.*"}, nil)
+	redactor.RedactJSON(data)
+
+	server := data["server"].(map[string]interface{})
+	th.AssertEquals(t, "ecs-demo", server["name"])
+	th.AssertEquals(t, redactedValue, server["adminPass"])
+	th.AssertEquals(t, redactedValue, server["metadata"].(map[string]interface{})["admin_pass"])
+	th.AssertEquals(t, "abc", server["metadata"].(map[string]interface{})["op_svc_userid"])
+	th.AssertEquals(t, redactedValue, server["extendparam"].(map[string]interface{})["chargingMode"])
+
+	instance := data["instances"].([]interface{})[0].(map[string]interface{})
+	th.AssertEquals(t, redactedValue, instance["manager_password"])
+	th.AssertEquals(t, redactedValue, instance["secret_text"])
+	th.AssertEquals(t, "t1", instance["tenant"])
+
+	credential := data["credential"].(map[string]interface{})
+	th.AssertEquals(t, "AK", credential["access"])
+	th.AssertEquals(t, redactedValue, credential["securitytoken"])
+
+	custom := data["custom"].(map[string]interface{})
+	th.AssertEquals(t, redactedValue, custom["sign"])
+	th.AssertEquals(t, "yyy", custom["nested"].(map[string]interface{})["sign"])
+}
+
+func TestLogRedactor_RedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-Auth-Token", "token")
+	headers.Set("Authorization", "SDK-HMAC-SHA256 Access=AK, Signature=xxx")
+	headers.Set("X-Custom-Key", "key")
+	headers.Set("Content-Type", "application/json")
+
+	redactor := NewLogRedactor(nil, []string{"x-custom-key"})
+	result := redactor.RedactHeaders(headers)
+	th.AssertEquals(t, redactedValue, result["X-Auth-Token"])
+	th.AssertEquals(t, redactedValue, result["Authorization"])
+	th.AssertEquals(t, redactedValue, result["X-Custom-Key"])
+	th.AssertEquals(t, "application/json", result["Content-Type"])
+
+	// the nil redactor uses the default rules
+	var nilRedactor *LogRedactor
+	formatted := nilRedactor.FormatHeaders(headers, "; ")
+	th.AssertEquals(t, "Authorization: ***; Content-Type: application/json; X-Auth-Token: ***; X-Custom-Key: key",
+		formatted)
+}
+
+func TestLogRedactor_ParseJSONBody(t *testing.T) {
+	redactor := NewLogRedactor(nil, nil)
+
+	th.AssertEquals(t, "** skipped **", redactor.parseJSONBody([]byte(`{"token": {"catalog": []}}`)))
+	th.AssertEquals(t, nil, redactor.parseJSONBody([]byte(`not a json`)))
+
+	body := redactor.parseJSONBody([]byte(`{"user": {"password": "Test@123"}}`))
+	user := body.(map[string]interface{})["user"].(map[string]interface{})
+	th.AssertEquals(t, redactedValue, user["password"])
+}
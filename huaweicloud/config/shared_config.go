@@ -0,0 +1,197 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// the modes of profile in the shared config file
+const (
+	// SharedModeAKSK authenticates with the AK/SK (and the security token) in the profile,
+	// the agency will be assumed if agencyName is specified.
+	SharedModeAKSK = "AKSK"
+	// SharedModeECSAgency authenticates with the temporary AK/SK from the ECS metadata API.
+	SharedModeECSAgency = "ecsAgency"
+	// SharedModeSSO authenticates with the IAM Identity Center, which is not supported yet.
+	SharedModeSSO = "SSO"
+)
+
+var supportedSharedModes = []string{SharedModeAKSK, SharedModeECSAgency}
+
+// CLI Shared Config
+type SharedConfig struct {
+	Current  string    `json:"current"`
+	Profiles []Profile `json:"profiles"`
+}
+
+type Profile struct {
+	Name             string `json:"name"`
+	Mode             string `json:"mode"`
+	AccessKeyId      string `json:"accessKeyId"`
+	SecretAccessKey  string `json:"secretAccessKey"`
+	SecurityToken    string `json:"securityToken"`
+	Region           string `json:"region"`
+	ProjectId        string `json:"projectId"`
+	DomainId         string `json:"domainId"`
+	AgencyDomainId   string `json:"agencyDomainId"`
+	AgencyDomainName string `json:"agencyDomainName"`
+	AgencyName       string `json:"agencyName"`
+}
+
+// isAgencyAssumed returns true if the profile assumes an agency with its AK/SK.
+func (p *Profile) isAgencyAssumed() bool {
+	return p.Mode == SharedModeAKSK && p.AgencyName != ""
+}
+
+// validate checks whether the required fields of the mode are specified.
+func (p *Profile) validate() error {
+	switch p.Mode {
+	case SharedModeAKSK:
+		if p.AccessKeyId == "" || p.SecretAccessKey == "" {
+			return fmt.Errorf("accessKeyId and secretAccessKey are required in profile %s with %s mode", p.Name, p.Mode)
+		}
+		if p.AgencyName != "" && p.AgencyDomainName == "" && p.AgencyDomainId == "" {
+			return fmt.Errorf("agencyDomainName or agencyDomainId is required to assume the agency %s in profile %s",
+				p.AgencyName, p.Name)
+		}
+		if p.AgencyName == "" && (p.AgencyDomainName != "" || p.AgencyDomainId != "") {
+			return fmt.Errorf("agencyName is required when agencyDomainName or agencyDomainId is specified in profile %s",
+				p.Name)
+		}
+	case SharedModeECSAgency:
+		// the credentials will be fetched from the ECS metadata API
+	case "":
+		return fmt.Errorf("the mode of profile %s is missing, the valid modes are: %s",
+			p.Name, strings.Join(supportedSharedModes, ", "))
+	case SharedModeSSO:
+		return fmt.Errorf("the %s mode of profile %s is not supported yet, please use a profile with %s mode "+
+			"and the temporary credentials instead, the valid modes are: %s",
+			p.Mode, p.Name, SharedModeAKSK, strings.Join(supportedSharedModes, ", "))
+	default:
+		return fmt.Errorf("unsupported mode %s in profile %s, the valid modes are: %s",
+			p.Mode, p.Name, strings.Join(supportedSharedModes, ", "))
+	}
+	return nil
+}
+
+// loadSharedProfile reads the shared config file and returns the specified profile,
+// the current profile of the shared config file will be used if the name is empty.
+func loadSharedProfile(path, name string) (*Profile, error) {
+	profilePath, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(profilePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("The specified shared config file %s does not exist", profilePath)
+	}
+
+	data, err := ioutil.ReadFile(profilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Err reading from shared config file: %s", err)
+	}
+	sharedConfig := SharedConfig{}
+	err = json.Unmarshal(data, &sharedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing shared config file %s: %s", profilePath, err)
+	}
+
+	// fetch current from shared config if not specified with provider
+	current := name
+	if current == "" {
+		current = sharedConfig.Current
+	}
+	if current == "" {
+		return nil, fmt.Errorf("the profile is not specified and there is no current profile in shared config file %s",
+			profilePath)
+	}
+
+	// fetch the current profile config
+	names := make([]string, 0, len(sharedConfig.Profiles))
+	for i, v := range sharedConfig.Profiles {
+		if current == v.Name {
+			return &sharedConfig.Profiles[i], nil
+		}
+		names = append(names, v.Name)
+	}
+	return nil, fmt.Errorf("Error finding profile %s from shared config file %s, the available profiles are: %s",
+		current, profilePath, strings.Join(names, ", "))
+}
+
+// buildSharedProfileProvider returns the CredentialProvider of the profile according to its mode.
+func buildSharedProfileProvider(c *Config, profile *Profile) (CredentialProvider, error) {
+	if err := profile.validate(); err != nil {
+		return nil, err
+	}
+
+	if profile.Mode == SharedModeECSAgency {
+		return &MetadataCredentialProvider{}, nil
+	}
+
+	provider := &SharedProfileCredentialProvider{
+		Path:    c.SharedConfigFile,
+		Profile: profile.Name,
+	}
+	if !profile.isAgencyAssumed() {
+		return provider, nil
+	}
+
+	return &AgencyCredentialProvider{
+		Base:             provider,
+		IdentityEndpoint: c.IdentityEndpoint,
+		DomainName:       profile.AgencyDomainName,
+		DomainID:         profile.AgencyDomainId,
+		AgencyName:       profile.AgencyName,
+	}, nil
+}
+
+// applySharedProfile overrides the region, project and domain of the provider with the profile.
+func applySharedProfile(c *Config, profile *Profile) {
+	if profile.Region != "" {
+		// the project name defaults to the region name
+		if c.TenantName == "" || c.TenantName == c.Region {
+			c.TenantName = profile.Region
+		}
+		c.Region = profile.Region
+	}
+
+	// non required fields
+	if profile.ProjectId != "" {
+		c.TenantID = profile.ProjectId
+	}
+	if !profile.isAgencyAssumed() {
+		if profile.DomainId != "" {
+			c.DomainID = profile.DomainId
+		}
+		return
+	}
+
+	// the assumed credentials belong to the domain which created the agency
+	c.DomainID = profile.AgencyDomainId
+	if profile.AgencyDomainName != "" {
+		c.DomainName = profile.AgencyDomainName
+	}
+}
+
+func buildClientByConfig(c *Config) error {
+	profile, err := loadSharedProfile(c.SharedConfigFile, c.Profile)
+	if err != nil {
+		return err
+	}
+
+	provider, err := buildSharedProfileProvider(c, profile)
+	if err != nil {
+		return err
+	}
+
+	applySharedProfile(c, profile)
+	if err := c.setCredentialProvider(provider); err != nil {
+		return fmt.Errorf("Error retrieving the credentials of profile %s (%s mode): %s", profile.Name, profile.Mode, err)
+	}
+	return buildClientByAKSK(c)
+}
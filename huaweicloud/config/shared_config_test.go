@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	th "github.com/chnsz/golangsdk/testhelper"
+)
+
+const testSharedConfig = `
+{
+  "current": "default",
+  "profiles": [
+    {
+      "name": "default",
+      "mode": "AKSK",
+      "accessKeyId": "ak",
+      "secretAccessKey": "sk",
+      "region": "cn-north-4",
+      "projectId": "project-id",
+      "domainId": "domain-id"
+    },
+    {
+      "name": "agency",
+      "mode": "AKSK",
+      "accessKeyId": "ak",
+      "secretAccessKey": "sk",
+      "region": "cn-south-1",
+      "agencyName": "ops",
+      "agencyDomainName": "target-domain",
+      "agencyDomainId": "target-domain-id"
+    },
+    {
+      "name": "ecs",
+      "mode": "ecsAgency",
+      "region": "ap-southeast-1"
+    }
+  ]
+}`
+
+// writeSharedConfig writes the content into a temporary shared config file and returns its path
+func writeSharedConfig(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "hcloud")
+	th.AssertNoErr(t, err)
+
+	path := filepath.Join(dir, "config.json")
+	th.AssertNoErr(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func assertErrorContains(t *testing.T, err error, substr string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("expected an error containing %q but got nil", substr)
+	}
+	if !strings.Contains(err.Error(), substr) {
+		t.Fatalf("expected an error containing %q but got %q", substr, err)
+	}
+}
+
+func TestLoadSharedProfile(t *testing.T) {
+	path, cleanup := writeSharedConfig(t, testSharedConfig)
+	defer cleanup()
+
+	// the current profile is used when the profile is not specified
+	profile, err := loadSharedProfile(path, "")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "default", profile.Name)
+	th.AssertEquals(t, "ak", profile.AccessKeyId)
+
+	profile, err = loadSharedProfile(path, "ecs")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, SharedModeECSAgency, profile.Mode)
+
+	_, err = loadSharedProfile(path, "unknown")
+	assertErrorContains(t, err, "the available profiles are: default, agency, ecs")
+
+	_, err = loadSharedProfile(filepath.Join(filepath.Dir(path), "not-exist.json"), "")
+	assertErrorContains(t, err, "does not exist")
+}
+
+func TestLoadSharedProfile_malformed(t *testing.T) {
+	path, cleanup := writeSharedConfig(t, `{"profiles": [{"name": "default"`)
+	defer cleanup()
+	_, err := loadSharedProfile(path, "default")
+	assertErrorContains(t, err, "Error parsing shared config file")
+
+	noCurrent, cleanupNoCurrent := writeSharedConfig(t, `{"profiles": [{"name": "default", "mode": "AKSK"}]}`)
+	defer cleanupNoCurrent()
+	_, err = loadSharedProfile(noCurrent, "")
+	assertErrorContains(t, err, "there is no current profile")
+}
+
+func TestProfile_validate(t *testing.T) {
+	testCases := []struct {
+		profile Profile
+		errMsg  string
+	}{
+		{
+			profile: Profile{Name: "aksk", Mode: SharedModeAKSK, AccessKeyId: "ak", SecretAccessKey: "sk"},
+		},
+		{
+			profile: Profile{Name: "ecs", Mode: SharedModeECSAgency},
+		},
+		{
+			profile: Profile{Name: "assume", Mode: SharedModeAKSK, AccessKeyId: "ak", SecretAccessKey: "sk",
+				AgencyName: "ops", AgencyDomainId: "domain-id"},
+		},
+		{
+			profile: Profile{Name: "no-sk", Mode: SharedModeAKSK, AccessKeyId: "ak"},
+			errMsg:  "accessKeyId and secretAccessKey are required in profile no-sk",
+		},
+		{
+			profile: Profile{Name: "no-domain", Mode: SharedModeAKSK, AccessKeyId: "ak", SecretAccessKey: "sk",
+				AgencyName: "ops"},
+			errMsg: "agencyDomainName or agencyDomainId is required",
+		},
+		{
+			profile: Profile{Name: "no-agency", Mode: SharedModeAKSK, AccessKeyId: "ak", SecretAccessKey: "sk",
+				AgencyDomainName: "domain"},
+			errMsg: "agencyName is required",
+		},
+		{
+			profile: Profile{Name: "empty"},
+			errMsg:  "the mode of profile empty is missing, the valid modes are: AKSK, ecsAgency",
+		},
+		{
+			profile: Profile{Name: "sso", Mode: SharedModeSSO},
+			errMsg:  "the SSO mode of profile sso is not supported yet",
+		},
+		{
+			profile: Profile{Name: "unknown", Mode: "password"},
+			errMsg:  "unsupported mode password in profile unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.profile.validate()
+		if tc.errMsg == "" {
+			th.AssertNoErr(t, err)
+		} else {
+			assertErrorContains(t, err, tc.errMsg)
+		}
+	}
+}
+
+func TestBuildSharedProfileProvider(t *testing.T) {
+	path, cleanup := writeSharedConfig(t, testSharedConfig)
+	defer cleanup()
+	cfg := &Config{SharedConfigFile: path, IdentityEndpoint: "https://iam.myhuaweicloud.com:443/v3"}
+
+	for name, expected := range map[string]string{
+		"default": "shared profile",
+		"agency":  "agency",
+		"ecs":     "ecs metadata",
+	} {
+		profile, err := loadSharedProfile(path, name)
+		th.AssertNoErr(t, err)
+		provider, err := buildSharedProfileProvider(cfg, profile)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, expected, provider.Name())
+	}
+
+	_, err := buildSharedProfileProvider(cfg, &Profile{Name: "sso", Mode: SharedModeSSO})
+	assertErrorContains(t, err, "not supported")
+}
+
+func TestApplySharedProfile(t *testing.T) {
+	path, cleanup := writeSharedConfig(t, testSharedConfig)
+	defer cleanup()
+
+	profile, err := loadSharedProfile(path, "default")
+	th.AssertNoErr(t, err)
+	cfg := &Config{Region: "cn-east-3", TenantName: "cn-east-3"}
+	applySharedProfile(cfg, profile)
+	th.AssertEquals(t, "cn-north-4", cfg.Region)
+	th.AssertEquals(t, "cn-north-4", cfg.TenantName)
+	th.AssertEquals(t, "project-id", cfg.TenantID)
+	th.AssertEquals(t, "domain-id", cfg.DomainID)
+
+	// the assumed credentials belong to the domain which created the agency
+	profile, err = loadSharedProfile(path, "agency")
+	th.AssertNoErr(t, err)
+	cfg = &Config{Region: "cn-east-3", TenantName: "project-name", DomainName: "my-domain"}
+	applySharedProfile(cfg, profile)
+	th.AssertEquals(t, "cn-south-1", cfg.Region)
+	th.AssertEquals(t, "project-name", cfg.TenantName)
+	th.AssertEquals(t, "target-domain-id", cfg.DomainID)
+	th.AssertEquals(t, "target-domain", cfg.DomainName)
+}
+
+func TestSharedProfile_agencyCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, temporaryCredentialBody(time.Now().Add(time.Hour)))
+	}))
+	defer server.Close()
+
+	path, cleanup := writeSharedConfig(t, testSharedConfig)
+	defer cleanup()
+	profile, err := loadSharedProfile(path, "agency")
+	th.AssertNoErr(t, err)
+
+	cfg := &Config{SharedConfigFile: path, IdentityEndpoint: server.URL + "/v3"}
+	provider, err := buildSharedProfileProvider(cfg, profile)
+	th.AssertNoErr(t, err)
+	th.AssertNoErr(t, cfg.setCredentialProvider(provider))
+	th.AssertEquals(t, "temp-ak", cfg.AccessKey)
+	th.AssertEquals(t, "temp-token", cfg.SecurityToken)
+	th.AssertEquals(t, false, cfg.SecurityKeyExpiresAt.IsZero())
+}
@@ -12,7 +12,7 @@ import (
 func dataSourceAntiDdosV1() *schema.Resource {
 	return &schema.Resource{
 		Read:               dataSourceAntiDdosV1Read,
-		DeprecationMessage: "this is deprecated",
+		DeprecationMessage: "this is deprecated and has no replacement",
 
 		Schema: map[string]*schema.Schema{
 			"region": {
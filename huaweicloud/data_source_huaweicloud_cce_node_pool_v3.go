@@ -246,7 +246,7 @@ func dataSourceCceNodePoolsV3Read(_ context.Context, d *schema.ResourceData, met
 	mErr = multierror.Append(mErr, d.Set("root_volume", rootVolume))
 
 	// set tags
-	tagmap := utils.TagsToMap(NodePool.Spec.NodeTemplate.UserTags)
+	tagmap := utils.TagsToMap(NodePool.Spec.NodeTemplate.UserTags, config.TagsConfig)
 	mErr = multierror.Append(mErr, d.Set("tags", tagmap))
 
 	if err = mErr.ErrorOrNil(); err != nil {
@@ -221,7 +221,7 @@ func dataSourceCceNodesV3Read(_ context.Context, d *schema.ResourceData, meta in
 	serverId := Node.Status.ServerID
 
 	if resourceTags, err := tags.Get(computeClient, "cloudservers", serverId).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 		mErr = multierror.Append(mErr, d.Set("tags", tagmap))
 	} else {
 		logp.Printf("[WARN] Error fetching tags of CCE Node (%s): %s", serverId, err)
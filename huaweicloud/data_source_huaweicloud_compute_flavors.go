@@ -7,8 +7,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/chnsz/golangsdk/openstack/ecs/v1/flavors"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
 )
 
@@ -42,6 +44,9 @@ func DataSourceEcsFlavors() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": common.FilterSchema([]string{
+				"id", "name", "performance_type", "generation", "cpu_core_count", "memory_size",
+			}),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -78,7 +83,7 @@ func dataSourceEcsFlavorsRead(d *schema.ResourceData, meta interface{}) error {
 	gen := d.Get("generation").(string)
 	az := d.Get("availability_zone").(string)
 
-	var ids []string
+	var candidates []interface{}
 	for _, flavor := range allFlavors {
 		vCpu, _ := strconv.Atoi(flavor.Vcpus)
 		if cpu > 0 && vCpu != cpu {
@@ -113,7 +118,27 @@ func dataSourceEcsFlavorsRead(d *schema.ResourceData, meta interface{}) error {
 			}
 		}
 
-		ids = append(ids, flavor.ID)
+		candidates = append(candidates, map[string]interface{}{
+			"id":               flavor.ID,
+			"name":             flavor.Name,
+			"performance_type": flavor.OsExtraSpecs.PerformanceType,
+			"generation":       flavor.OsExtraSpecs.Generation,
+			"cpu_core_count":   vCpu,
+			// the memory size in GB, some of the flavors have less than 1GB memory
+			"memory_size": float64(flavor.Ram) / 1024,
+		})
+	}
+
+	if rules := common.ExpandFilterRules(d, nil); len(rules) > 0 {
+		candidates, err = utils.FilterSliceWithRules(candidates, rules)
+		if err != nil {
+			return fmtp.Errorf("filter flavors failed: %s", err)
+		}
+	}
+
+	ids := make([]string, len(candidates))
+	for i, flavor := range candidates {
+		ids[i] = flavor.(map[string]interface{})["id"].(string)
 	}
 
 	if len(ids) < 1 {
@@ -12,7 +12,7 @@ import (
 func dataSourceCSBSBackupPolicyV1() *schema.Resource {
 	return &schema.Resource{
 		Read:               dataSourceCSBSBackupPolicyV1Read,
-		DeprecationMessage: "It has been deprecated.",
+		DeprecationMessage: "It has been deprecated, please use huaweicloud_cbr_vaults data source instead.",
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
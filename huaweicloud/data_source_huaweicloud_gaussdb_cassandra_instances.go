@@ -306,7 +306,7 @@ func dataSourceGeminiDBInstancesRead(d *schema.ResourceData, meta interface{}) e
 
 		//save geminidb tags
 		if resourceTags, err := tags.Get(client, "instances", instanceID).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 			instanceToSet["tags"] = tagmap
 		} else {
 			logp.Printf("[WARN] Error fetching tags of geminidb (%s): %s", instanceID, err)
@@ -281,7 +281,7 @@ func dataSourceGaussRedisInstanceRead(d *schema.ResourceData, meta interface{})
 
 	//save geminidb tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for geminidb (%s): %s", d.Id(), err)
 		}
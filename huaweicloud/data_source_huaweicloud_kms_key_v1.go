@@ -181,7 +181,7 @@ func dataSourceKmsKeyV1Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("enterprise_project_id", key.EnterpriseProjectID)
 
 	if resourceTags, err := tags.Get(kmsKeyV1Client, "kms", key.KeyID).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for kms key(%s): %s", key.KeyID, err)
 		}
@@ -0,0 +1,74 @@
+package huaweicloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+)
+
+// DataSourceServiceEndpoint exposes the endpoint resolved by the provider for a service, it's used to debug
+// the custom endpoints and the endpoints discovered from the service catalog.
+func DataSourceServiceEndpoint() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServiceEndpointRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"service": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(config.GetServiceCatalogKeys(), false),
+			},
+			"endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_base": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceServiceEndpointRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	region := GetRegion(d, conf)
+	service := d.Get("service").(string)
+
+	_, source, err := conf.ResolveEndpoint(service, region)
+	if err != nil {
+		return fmtp.DiagErrorf("Error resolving the endpoint of %s: %s", service, err)
+	}
+	client, err := conf.NewServiceClient(service, region)
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating the %s client: %s", service, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", service, region))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("endpoint", client.Endpoint),
+		d.Set("resource_base", client.ResourceBase),
+		d.Set("source", source),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmtp.DiagErrorf("Error setting the attributes of the service endpoint: %s", err)
+	}
+	return nil
+}
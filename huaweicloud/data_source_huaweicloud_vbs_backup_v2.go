@@ -11,7 +11,7 @@ import (
 func dataSourceVBSBackupV2() *schema.Resource {
 	return &schema.Resource{
 		Read:               dataSourceVBSBackupV2Read,
-		DeprecationMessage: "It has been deprecated.",
+		DeprecationMessage: "It has been deprecated, please use huaweicloud_cbr_vaults data source instead.",
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -0,0 +1,234 @@
+package generate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	"github.com/chnsz/golangsdk/openstack/networking/v3/security/groups"
+	"github.com/chnsz/golangsdk/openstack/rds/v3/instances"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+// ListFunc returns the import IDs of the existing resources in the region.
+type ListFunc func(conf *config.Config, region string) ([]string, error)
+
+// Discoverer enumerates the existing resources of a resource type, the resources are read by the Read function
+// of the provider after they're imported.
+type Discoverer struct {
+	// Service is the name used in the -services flag, e.g. vpc
+	Service string
+	// ResourceType is the type of the resource in the provider, e.g. huaweicloud_vpc
+	ResourceType string
+	// List returns the import IDs of the existing resources
+	List ListFunc
+	// IgnoreAttributes are not written into the configuration, e.g. the arguments which are only used on creation
+	// and are not returned by the API
+	IgnoreAttributes []string
+}
+
+var discoverers []Discoverer
+
+// Register adds the discoverer of a resource type, the resources are generated in the order of the registration.
+func Register(d Discoverer) {
+	for _, v := range discoverers {
+		if v.ResourceType == d.ResourceType {
+			panic(fmt.Sprintf("the discoverer of %s is already registered", d.ResourceType))
+		}
+	}
+	discoverers = append(discoverers, d)
+}
+
+// Services returns the sorted names of the services which have the registered discoverers.
+func Services() []string {
+	names := make(map[string]bool)
+	for _, d := range discoverers {
+		names[d.Service] = true
+	}
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// discoverersOf returns the discoverers of the service
+func discoverersOf(service string) []Discoverer {
+	var result []Discoverer
+	for _, d := range discoverers {
+		if d.Service == service {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func init() {
+	Register(Discoverer{
+		Service:      "vpc",
+		ResourceType: "huaweicloud_vpc",
+		List:         listVpcs,
+	})
+	Register(Discoverer{
+		Service:      "vpc",
+		ResourceType: "huaweicloud_vpc_subnet",
+		List:         listVpcSubnets,
+	})
+	Register(Discoverer{
+		Service:      "vpc",
+		ResourceType: "huaweicloud_networking_secgroup",
+		List:         listSecurityGroups,
+	})
+	Register(Discoverer{
+		Service:      "ecs",
+		ResourceType: "huaweicloud_compute_instance",
+		List:         listComputeInstances,
+		// the names are the same as the flavor and image specified by the IDs
+		IgnoreAttributes: []string{"flavor_name", "image_name"},
+	})
+	Register(Discoverer{
+		Service:      "evs",
+		ResourceType: "huaweicloud_evs_volume",
+		List:         listEvsVolumes,
+	})
+	Register(Discoverer{
+		Service:      "rds",
+		ResourceType: "huaweicloud_rds_instance",
+		List:         listRdsInstances,
+	})
+}
+
+func listVpcs(conf *config.Config, region string) ([]string, error) {
+	client, err := conf.NetworkingV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HuaweiCloud VPC client: %s", err)
+	}
+
+	allVpcs, err := vpcs.List(client, vpcs.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing the VPCs: %s", err)
+	}
+
+	ids := make([]string, len(allVpcs))
+	for i, v := range allVpcs {
+		ids[i] = v.ID
+	}
+	return ids, nil
+}
+
+func listVpcSubnets(conf *config.Config, region string) ([]string, error) {
+	client, err := conf.NetworkingV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HuaweiCloud VPC client: %s", err)
+	}
+
+	allSubnets, err := subnets.List(client, subnets.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing the subnets: %s", err)
+	}
+
+	ids := make([]string, len(allSubnets))
+	for i, v := range allSubnets {
+		ids[i] = v.ID
+	}
+	return ids, nil
+}
+
+func listSecurityGroups(conf *config.Config, region string) ([]string, error) {
+	client, err := conf.NetworkingV3Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HuaweiCloud networking v3 client: %s", err)
+	}
+
+	allGroups, err := groups.List(client, groups.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing the security groups: %s", err)
+	}
+
+	ids := make([]string, 0, len(allGroups))
+	for _, v := range allGroups {
+		// the default security group is created by the service
+		if v.Name != "default" {
+			ids = append(ids, v.ID)
+		}
+	}
+	return ids, nil
+}
+
+func listComputeInstances(conf *config.Config, region string) ([]string, error) {
+	client, err := conf.ComputeV1Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HuaweiCloud compute client: %s", err)
+	}
+
+	pages, err := cloudservers.List(client, cloudservers.ListOpts{Limit: 1000}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing the compute instances: %s", err)
+	}
+	allServers, err := cloudservers.ExtractServers(pages)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting the compute instances: %s", err)
+	}
+
+	ids := make([]string, len(allServers))
+	for i, v := range allServers {
+		ids[i] = v.ID
+	}
+	return ids, nil
+}
+
+func listEvsVolumes(conf *config.Config, region string) ([]string, error) {
+	client, err := conf.BlockStorageV2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HuaweiCloud EVS v2 client: %s", err)
+	}
+
+	pages, err := cloudvolumes.List(client, cloudvolumes.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing the EVS volumes: %s", err)
+	}
+	allVolumes, err := cloudvolumes.ExtractVolumes(pages)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting the EVS volumes: %s", err)
+	}
+
+	ids := make([]string, 0, len(allVolumes))
+	for _, v := range allVolumes {
+		// the system disks are managed by the compute instances
+		if v.Bootable != "true" {
+			ids = append(ids, v.ID)
+		}
+	}
+	return ids, nil
+}
+
+func listRdsInstances(conf *config.Config, region string) ([]string, error) {
+	client, err := conf.RdsV3Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HuaweiCloud RDS client: %s", err)
+	}
+
+	pages, err := instances.List(client, instances.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("error listing the RDS instances: %s", err)
+	}
+	resp, err := instances.ExtractRdsInstances(pages)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting the RDS instances: %s", err)
+	}
+
+	ids := make([]string, 0, len(resp.Instances))
+	for _, v := range resp.Instances {
+		// the read replicas are managed by huaweicloud_rds_read_replica_instance
+		if v.Type != "Replica" {
+			ids = append(ids, v.Id)
+		}
+	}
+	return ids, nil
+}
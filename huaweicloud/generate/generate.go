@@ -0,0 +1,283 @@
+// Package generate discovers the existing resources of an account and generates the Terraform configuration
+// with the import blocks, so the resources can be managed by Terraform without writing the HCL by hand.
+//
+// The resources are enumerated by the registered discoverers, and each resource is imported and read by the
+// importer and the Read function of the provider, the same as `terraform import`. The arguments in the state
+// are written into the resource blocks, and the configuration is checked by the Diff function of the provider,
+// so an empty plan is expected after importing. The resources which still have differences are reported as
+// warnings and commented in the files.
+package generate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const fileHeader = `# Generated by terraform-provider-huaweicloud generate.
+# The resources are imported by the import blocks which require Terraform v1.5 or later.
+`
+
+// Options is the options of the generation.
+type Options struct {
+	// Services are the names of the services to generate, e.g. vpc, ecs and rds
+	Services []string
+	// OutputDir is the directory of the generated files, a file named <service>.tf is written for each service
+	OutputDir string
+	// ProviderConfig is the arguments of the provider, the environment variables are used for the missing ones
+	ProviderConfig map[string]interface{}
+}
+
+// Resource is a generated resource.
+type Resource struct {
+	Type string
+	Name string
+	ID   string
+	// Diffs are the attributes which still have differences after importing, the plan is empty if it's empty
+	Diffs []string
+}
+
+// Address returns the address of the resource, e.g. huaweicloud_vpc.demo
+func (r *Resource) Address() string {
+	return r.Type + "." + r.Name
+}
+
+// Result is the result of the generation.
+type Result struct {
+	// Files are the paths of the generated files
+	Files []string
+	// Resources are the generated resources in the order of the files
+	Resources []*Resource
+	// Warnings are the errors which do not stop the generation, e.g. a resource type failed to be listed
+	Warnings []string
+}
+
+// Run discovers the resources of the services and writes the configuration into the output directory.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	if len(opts.Services) == 0 {
+		return nil, fmt.Errorf("at least one service is required, the supported services are: %s",
+			strings.Join(Services(), ", "))
+	}
+	for _, service := range opts.Services {
+		if len(discoverersOf(service)) == 0 {
+			return nil, fmt.Errorf("the service %q is not supported, the supported services are: %s",
+				service, strings.Join(Services(), ", "))
+		}
+	}
+
+	provider := huaweicloud.Provider()
+	raw := opts.ProviderConfig
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		return nil, fmt.Errorf("error configuring the provider: %s", diags[0].Summary)
+	}
+	conf := provider.Meta().(*config.Config)
+
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating the output directory: %s", err)
+	}
+
+	g := &generator{
+		provider: provider,
+		conf:     conf,
+		names:    make(map[string]bool),
+		result:   &Result{},
+	}
+	for _, service := range opts.Services {
+		var b strings.Builder
+		b.WriteString(fileHeader)
+		for _, d := range discoverersOf(service) {
+			g.generate(ctx, d, &b)
+		}
+
+		path := filepath.Join(outputDir, service+".tf")
+		if err := ioutil.WriteFile(path, []byte(b.String()), 0644); err != nil {
+			return nil, fmt.Errorf("error writing %s: %s", path, err)
+		}
+		g.result.Files = append(g.result.Files, path)
+	}
+	return g.result, nil
+}
+
+type generator struct {
+	provider *schema.Provider
+	conf     *config.Config
+	// names are the addresses which are already generated
+	names  map[string]bool
+	result *Result
+}
+
+// generate writes the import blocks and the resource blocks of the resources found by the discoverer
+func (g *generator) generate(ctx context.Context, d Discoverer, b *strings.Builder) {
+	res, ok := g.provider.ResourcesMap[d.ResourceType]
+	if !ok || res.Importer == nil {
+		g.warn("the resource %s does not support import", d.ResourceType)
+		return
+	}
+
+	ids, err := d.List(g.conf, g.conf.Region)
+	if err != nil {
+		g.warn("error listing %s: %s", d.ResourceType, err)
+		return
+	}
+
+	for _, id := range ids {
+		states, err := g.provider.ImportState(ctx, &terraform.InstanceInfo{Type: d.ResourceType}, id)
+		if err != nil {
+			g.warn("error importing %s %s: %s", d.ResourceType, id, err)
+			continue
+		}
+
+		for _, imported := range states {
+			state, diags := res.RefreshWithoutUpgrade(ctx, imported, g.conf)
+			if diags.HasError() {
+				g.warn("error reading %s %s: %s", d.ResourceType, id, diags[0].Summary)
+				continue
+			}
+			if state == nil {
+				// the resource is deleted after it's listed
+				continue
+			}
+
+			r := g.writeResource(ctx, d, res, id, state, b)
+			g.result.Resources = append(g.result.Resources, r)
+		}
+	}
+}
+
+func (g *generator) writeResource(ctx context.Context, d Discoverer, res *schema.Resource, importID string,
+	state *terraform.InstanceState, b *strings.Builder) *Resource {
+	data := res.Data(state)
+	values := make(map[string]interface{}, len(res.Schema))
+	for key := range res.Schema {
+		values[key] = data.Get(key)
+	}
+	// the region is the same as the provider
+	ignored := append([]string{"region"}, d.IgnoreAttributes...)
+	args := buildArguments(res.Schema, values, "", ignored, func(key string) bool {
+		return isStatePresent(state, key)
+	})
+
+	name, _ := values["name"].(string)
+	r := &Resource{
+		Type:  d.ResourceType,
+		Name:  g.uniqueName(d.ResourceType, resourceName(name, state.ID)),
+		ID:    importID,
+		Diffs: g.diff(ctx, res, state, args),
+	}
+
+	b.WriteString("\n")
+	writeImportBlock(b, r.Address(), importID)
+	b.WriteString("\n")
+	if len(r.Diffs) > 0 {
+		fmt.Fprintf(b, "# WARNING: the plan is not empty after importing, please check the arguments: %s\n",
+			strings.Join(r.Diffs, ", "))
+		g.warn("the plan of %s is not empty after importing: %s", r.Address(), strings.Join(r.Diffs, ", "))
+	}
+	writeResourceBlock(b, d.ResourceType, r.Name, res.Schema, args)
+	return r
+}
+
+// diff returns the attributes which will be changed by the configuration
+func (g *generator) diff(ctx context.Context, res *schema.Resource, state *terraform.InstanceState,
+	args map[string]interface{}) []string {
+	cfg := terraform.NewResourceConfigRaw(args)
+	if diags := res.Validate(cfg); diags.HasError() {
+		return []string{fmt.Sprintf("(invalid configuration: %s)", diags[0].Summary)}
+	}
+
+	instanceDiff, err := res.SimpleDiff(ctx, state, cfg, g.conf)
+	if err != nil {
+		return []string{fmt.Sprintf("(error: %s)", err)}
+	}
+
+	var result []string
+	for key, attr := range instanceDiff.Attributes {
+		if attr == nil || attr.NewComputed || (attr.Old == attr.New && !attr.NewRemoved) {
+			continue
+		}
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// uniqueName returns the name with a numeric suffix if the address is already generated, e.g. demo_2
+func (g *generator) uniqueName(resourceType, name string) string {
+	result := name
+	for i := 2; g.names[resourceType+"."+result]; i++ {
+		result = fmt.Sprintf("%s_%d", name, i)
+	}
+	g.names[resourceType+"."+result] = true
+	return result
+}
+
+func (g *generator) warn(format string, a ...interface{}) {
+	g.result.Warnings = append(g.result.Warnings, fmt.Sprintf(format, a...))
+}
+
+// Main runs the generate subcommand with the arguments, and returns the exit code.
+func Main(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	services := flags.String("services", "",
+		fmt.Sprintf("the comma-separated services to generate, supported: %s", strings.Join(Services(), ", ")))
+	region := flags.String("region", "", "the region of the resources, defaults to HW_REGION_NAME")
+	outputDir := flags.String("output", ".", "the directory of the generated files")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-huaweicloud generate -services=vpc,ecs,rds [options]\n\n")
+		fmt.Fprintf(stderr, "The credentials are read from the environment variables of the provider, "+
+			"e.g. HW_ACCESS_KEY and HW_SECRET_KEY.\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	opts := Options{
+		OutputDir:      *outputDir,
+		ProviderConfig: make(map[string]interface{}),
+	}
+	for _, s := range strings.Split(*services, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			opts.Services = append(opts.Services, s)
+		}
+	}
+	if *region != "" {
+		opts.ProviderConfig["region"] = *region
+	}
+
+	result, err := Run(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	for _, w := range result.Warnings {
+		fmt.Fprintf(stderr, "Warning: %s\n", w)
+	}
+	for _, path := range result.Files {
+		fmt.Fprintf(stdout, "Generated %s\n", path)
+	}
+	fmt.Fprintf(stdout, "%d resources are generated, run `terraform plan` to check the import.\n",
+		len(result.Resources))
+	return 0
+}
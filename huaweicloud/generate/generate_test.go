@@ -0,0 +1,206 @@
+package generate
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/jobs"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	"github.com/chnsz/golangsdk/openstack/networking/v3/security/groups"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
+)
+
+// createTestResources creates a VPC, a subnet, a security group, an ECS instance and an EVS volume in the fake
+func createTestResources(t *testing.T, c *config.Config) {
+	vpcClient, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vpc, err := vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-demo", CIDR: "192.168.0.0/16"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the VPC: %s", err)
+	}
+	subnet, err := subnets.Create(vpcClient, subnets.CreateOpts{
+		Name:      "subnet-demo",
+		CIDR:      "192.168.0.0/24",
+		GatewayIP: "192.168.0.1",
+		VPC_ID:    vpc.ID,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the subnet: %s", err)
+	}
+
+	sgClient, err := c.NetworkingV3Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secgroup, err := groups.Create(sgClient, groups.CreateOpts{Name: "secgroup-demo"})
+	if err != nil {
+		t.Fatalf("Error creating the security group: %s", err)
+	}
+
+	ecsClient, err := c.ComputeV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsV11Client, err := c.ComputeV11Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := cloudservers.Create(ecsV11Client, cloudservers.CreateOpts{
+		ImageRef:         "fake-image-id",
+		FlavorRef:        "s6.small.1",
+		Name:             "ecs-demo",
+		VpcId:            vpc.ID,
+		Nics:             []cloudservers.Nic{{SubnetId: subnet.ID}},
+		RootVolume:       cloudservers.RootVolume{VolumeType: "SSD"},
+		SecurityGroups:   []cloudservers.SecurityGroup{{ID: secgroup.ID}},
+		AvailabilityZone: "cn-north-4a",
+	}).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error creating the server: %s", err)
+	}
+	for i := 0; i < 5; i++ {
+		if j, err := jobs.Get(ecsClient, job.JobID); err == nil && j.Status == "SUCCESS" {
+			break
+		}
+	}
+
+	evsClient, err := c.BlockStorageV21Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cloudvolumes.Create(evsClient, cloudvolumes.CreateOpts{
+		Volume: cloudvolumes.VolumeOpts{
+			AvailabilityZone: "cn-north-4a",
+			VolumeType:       "SAS",
+			Name:             "volume-demo",
+			Size:             10,
+		},
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the volume: %s", err)
+	}
+}
+
+func TestRun_fakeCloud(t *testing.T) {
+	srv := fakecloud.NewServer()
+	defer srv.Close()
+	srv.PendingPolls = 0
+
+	c, err := srv.Config()
+	if err != nil {
+		t.Fatalf("Error loading the config of the fake cloud: %s", err)
+	}
+	createTestResources(t, c)
+
+	outputDir, err := ioutil.TempDir("", "generate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outputDir)
+
+	result, err := Run(context.Background(), Options{
+		Services:       []string{"vpc", "ecs", "evs"},
+		OutputDir:      outputDir,
+		ProviderConfig: srv.ProviderArguments(),
+	})
+	if err != nil {
+		t.Fatalf("Error generating the configuration: %s", err)
+	}
+	if len(result.Warnings) > 0 {
+		t.Errorf("unexpected warnings: %s", strings.Join(result.Warnings, "\n"))
+	}
+
+	expected := []string{
+		"huaweicloud_vpc.vpc-demo",
+		"huaweicloud_vpc_subnet.subnet-demo",
+		"huaweicloud_networking_secgroup.secgroup-demo",
+		"huaweicloud_compute_instance.ecs-demo",
+		"huaweicloud_evs_volume.volume-demo",
+	}
+	if len(result.Resources) != len(expected) {
+		t.Fatalf("expected %d resources, but got %d", len(expected), len(result.Resources))
+	}
+	for i, r := range result.Resources {
+		if r.Address() != expected[i] {
+			t.Errorf("expected %s, but got %s", expected[i], r.Address())
+		}
+		// the plan should be empty after importing
+		if len(r.Diffs) > 0 {
+			t.Errorf("the plan of %s is not empty: %s", r.Address(), strings.Join(r.Diffs, ", "))
+		}
+	}
+
+	if len(result.Files) != 3 {
+		t.Fatalf("expected 3 files, but got %v", result.Files)
+	}
+	for _, path := range result.Files {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, diags := hclsyntax.ParseConfig(content, filepath.Base(path), hcl.InitialPos); diags.HasErrors() {
+			t.Errorf("the generated %s is invalid: %s", path, diags.Error())
+		}
+		t.Logf("%s:\n%s", path, content)
+	}
+
+	content, _ := ioutil.ReadFile(filepath.Join(outputDir, "vpc.tf"))
+	for _, s := range []string{
+		"import {\n  to = huaweicloud_vpc.vpc-demo\n",
+		"resource \"huaweicloud_vpc\" \"vpc-demo\" {\n",
+		"  cidr                  = \"192.168.0.0/16\"\n",
+	} {
+		if !strings.Contains(string(content), s) {
+			t.Errorf("expected %q in vpc.tf", s)
+		}
+	}
+}
+
+func TestRun_unsupportedService(t *testing.T) {
+	_, err := Run(context.Background(), Options{Services: []string{"vpc", "foo"}})
+	if err == nil || !strings.Contains(err.Error(), `the service "foo" is not supported`) {
+		t.Fatalf("expected the unsupported service error, but got %v", err)
+	}
+}
+
+func TestQuoteString(t *testing.T) {
+	cases := map[string]string{
+		"demo":          `"demo"`,
+		"a \"b\"\n":     `"a \"b\"\n"`,
+		"${var.a}":      `"$${var.a}"`,
+		"%{if a}b":      `"%%{if a}b"`,
+		"C:\\temp $ok%": `"C:\\temp $ok%"`,
+	}
+	for input, expected := range cases {
+		if result := quoteString(input); result != expected {
+			t.Errorf("expected %s, but got %s", expected, result)
+		}
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	cases := [][]string{
+		{"vpc-demo", "id", "vpc-demo"},
+		{"My VPC (prod)", "id", "my_vpc_prod"},
+		{"", "0f1e-id", "r_0f1e-id"},
+		{"云主机", "abc", "abc"},
+	}
+	for _, c := range cases {
+		if result := resourceName(c[0], c[1]); result != c[2] {
+			t.Errorf("expected %s, but got %s", c[2], result)
+		}
+	}
+}
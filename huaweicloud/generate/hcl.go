@@ -0,0 +1,311 @@
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+const indentUnit = "  "
+
+var (
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+	identifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+)
+
+// buildArguments returns the arguments which reproduce the values, the keys in ignored are the paths of the
+// arguments without the indexes, e.g. db.password. The present function returns whether the key is set.
+func buildArguments(schemaMap map[string]*schema.Schema, values map[string]interface{}, path string,
+	ignored []string, present func(key string) bool) map[string]interface{} {
+	args := make(map[string]interface{})
+
+	// the arguments which are not computed are added first, so the optional computed arguments which
+	// conflict with them are skipped
+	for _, computed := range []bool{false, true} {
+		for _, key := range sortedKeys(schemaMap) {
+			s := schemaMap[key]
+			if (!s.Required && !s.Optional) || s.Computed != computed {
+				continue
+			}
+			// the sensitive values are not written into the files, e.g. passwords
+			if s.Sensitive || utils.StrSliceContains(ignored, path+key) || !present(key) {
+				continue
+			}
+			if s.Computed && (s.Deprecated != "" || isConflicting(s, args)) {
+				continue
+			}
+
+			if value, ok := buildValue(s, values[key], path+key+".", ignored); ok {
+				args[key] = value
+			}
+		}
+	}
+	return args
+}
+
+// buildValue returns the value of the argument, ok is false if the argument can be omitted
+func buildValue(s *schema.Schema, raw interface{}, path string, ignored []string) (interface{}, bool) {
+	if raw == nil {
+		return nil, false
+	}
+
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		var items []interface{}
+		if set, ok := raw.(*schema.Set); ok {
+			items = set.List()
+		} else {
+			items, _ = raw.([]interface{})
+		}
+		if len(items) == 0 {
+			return []interface{}{}, s.Required
+		}
+
+		result := make([]interface{}, 0, len(items))
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			for _, item := range items {
+				m, _ := item.(map[string]interface{})
+				result = append(result, buildArguments(elem.Schema, m, path, ignored, func(string) bool {
+					return true
+				}))
+			}
+			return result, true
+		}
+		for _, item := range items {
+			if item != nil {
+				result = append(result, item)
+			}
+		}
+		if s.Type == schema.TypeSet {
+			sort.Slice(result, func(i, j int) bool {
+				return fmt.Sprint(result[i]) < fmt.Sprint(result[j])
+			})
+		}
+		return result, true
+	case schema.TypeMap:
+		m, _ := raw.(map[string]interface{})
+		return m, len(m) > 0 || s.Required
+	default:
+		if s.Required {
+			return raw, true
+		}
+		if s.Default != nil {
+			return raw, fmt.Sprint(raw) != fmt.Sprint(s.Default)
+		}
+		return raw, fmt.Sprint(raw) != fmt.Sprint(zeroValue(s.Type))
+	}
+}
+
+func zeroValue(t schema.ValueType) interface{} {
+	switch t {
+	case schema.TypeBool:
+		return false
+	case schema.TypeInt:
+		return 0
+	case schema.TypeFloat:
+		return 0.0
+	default:
+		return ""
+	}
+}
+
+// isConflicting returns true if any of the arguments which conflict with the schema is already added
+func isConflicting(s *schema.Schema, args map[string]interface{}) bool {
+	keys := make([]string, 0, len(s.ConflictsWith)+len(s.ExactlyOneOf))
+	keys = append(keys, s.ConflictsWith...)
+	keys = append(keys, s.ExactlyOneOf...)
+	for _, key := range keys {
+		// the keys of the nested arguments are the full paths, e.g. network.0.fixed_ip_v4
+		name := key[strings.LastIndex(key, ".")+1:]
+		if _, ok := args[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isStatePresent returns whether the top-level key is set in the state
+func isStatePresent(state *terraform.InstanceState, key string) bool {
+	for _, k := range []string{key, key + ".#", key + ".%"} {
+		if _, ok := state.Attributes[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceName converts the name of the resource into a valid name of the resource block, e.g. vpc_demo
+func resourceName(name, id string) string {
+	result := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if result == "" {
+		result = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(id), "_"), "_-")
+	}
+	if result == "" || (result[0] >= '0' && result[0] <= '9') || result[0] == '-' {
+		result = "r_" + result
+	}
+	return result
+}
+
+// writeImportBlock writes the import block which imports the resource with the ID
+func writeImportBlock(b *strings.Builder, address, id string) {
+	b.WriteString("import {\n")
+	writeAttributes(b, []string{"to", "id"}, map[string]string{"to": address, "id": quoteString(id)}, indentUnit)
+	b.WriteString("}\n")
+}
+
+// writeResourceBlock writes the resource block with the arguments
+func writeResourceBlock(b *strings.Builder, resourceType, name string, schemaMap map[string]*schema.Schema,
+	args map[string]interface{}) {
+	fmt.Fprintf(b, "resource %q %q {\n", resourceType, name)
+	writeBody(b, schemaMap, args, indentUnit)
+	b.WriteString("}\n")
+}
+
+// writeBody writes the attributes in the order of the names, the nested blocks are written after the attributes
+func writeBody(b *strings.Builder, schemaMap map[string]*schema.Schema, args map[string]interface{}, indent string) {
+	var attrNames, mapNames, blockNames []string
+	attrValues := make(map[string]string)
+	for _, key := range sortedKeys(args) {
+		s := schemaMap[key]
+		switch {
+		case isBlock(s):
+			blockNames = append(blockNames, key)
+		case s.Type == schema.TypeMap:
+			mapNames = append(mapNames, key)
+		default:
+			attrNames = append(attrNames, key)
+			attrValues[key] = formatValue(args[key])
+		}
+	}
+
+	writeAttributes(b, attrNames, attrValues, indent)
+	for _, key := range mapNames {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "{\n") {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "%s%s = {\n", indent, key)
+		m := args[key].(map[string]interface{})
+		values := make(map[string]string, len(m))
+		names := make([]string, 0, len(m))
+		for _, k := range sortedKeys(m) {
+			name := formatMapKey(k)
+			names = append(names, name)
+			values[name] = formatValue(m[k])
+		}
+		writeAttributes(b, names, values, indent+indentUnit)
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+
+	for _, key := range blockNames {
+		elem := schemaMap[key].Elem.(*schema.Resource)
+		for _, item := range args[key].([]interface{}) {
+			if !strings.HasSuffix(b.String(), "{\n") {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(b, "%s%s {\n", indent, key)
+			writeBody(b, elem.Schema, item.(map[string]interface{}), indent+indentUnit)
+			fmt.Fprintf(b, "%s}\n", indent)
+		}
+	}
+}
+
+// writeAttributes writes the attributes whose equal signs are aligned like terraform fmt
+func writeAttributes(b *strings.Builder, names []string, values map[string]string, indent string) {
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	for _, name := range names {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, name, values[name])
+	}
+}
+
+func isBlock(s *schema.Schema) bool {
+	if s.Type != schema.TypeList && s.Type != schema.TypeSet {
+		return false
+	}
+	_, ok := s.Elem.(*schema.Resource)
+	return ok
+}
+
+func formatValue(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return quoteString(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case int:
+		return strconv.Itoa(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = formatValue(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return quoteString(fmt.Sprint(value))
+	}
+}
+
+func formatMapKey(key string) string {
+	if identifierRegexp.MatchString(key) {
+		return key
+	}
+	return quoteString(key)
+}
+
+// quoteString returns the quoted HCL string, the template sequences are escaped
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch v := m.(type) {
+	case map[string]*schema.Schema:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	case map[string]interface{}:
+		for k := range v {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
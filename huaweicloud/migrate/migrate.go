@@ -0,0 +1,262 @@
+// Package migrate moves the resources of the legacy aliases, such as huaweicloud_vpc_v1 and
+// huaweicloud_compute_instance_v2, to their current names in the Terraform state and configuration.
+//
+// An alias shares the implementation and the schema with the current name, so the instances are moved
+// without any change of the attributes. Terraform only reads the state of a resource with its own type,
+// hence the state is rewritten directly instead of the moved blocks.
+//
+// The deprecated resources, data sources and attributes used in a state are reported by Report, so the migration
+// of the workspaces can be tracked.
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+)
+
+// Rename is a resource moved from the legacy alias to the current name.
+type Rename struct {
+	From string
+	To   string
+}
+
+// MigrateState renames the resources of the aliases in the state, which must be in the format version 4 written
+// by Terraform v0.12 and later. The dependencies of the instances are updated as well, and the serial is increased
+// so the state can be pushed to the backend.
+func MigrateState(raw []byte, aliases map[string]string) ([]byte, []Rename, error) {
+	state, err := decodeState(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources, _ := state["resources"].([]interface{})
+	renames := make([]Rename, 0)
+	addresses := make(map[string]bool)
+	for _, r := range resources {
+		resource, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid resource in the state: %v", r)
+		}
+		from := resourceAddress(resource)
+		if resource["mode"] == "managed" {
+			resourceType, _ := resource["type"].(string)
+			if name, ok := aliases[resourceType]; ok {
+				resource["type"] = name
+				renames = append(renames, Rename{From: from, To: resourceAddress(resource)})
+			}
+		}
+
+		to := resourceAddress(resource)
+		if addresses[to] {
+			return nil, nil, fmt.Errorf("can not move %s to %s, the resource already exists in the state", from, to)
+		}
+		addresses[to] = true
+
+		instances, _ := resource["instances"].([]interface{})
+		for _, i := range instances {
+			if instance, ok := i.(map[string]interface{}); ok {
+				renameDependencies(instance, aliases)
+			}
+		}
+	}
+
+	if len(renames) == 0 {
+		return raw, renames, nil
+	}
+
+	if serial, ok := state["serial"].(json.Number); ok {
+		value, _ := serial.Int64()
+		state["serial"] = value + 1
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		return nil, nil, fmt.Errorf("error writing the state: %s", err)
+	}
+	return buf.Bytes(), renames, nil
+}
+
+// decodeState parses the state in the format version 4, the numbers are kept as they are
+func decodeState(raw []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		return nil, fmt.Errorf("error parsing the state: %s", err)
+	}
+
+	if version, ok := state["version"].(json.Number); !ok || version.String() != "4" {
+		return nil, fmt.Errorf("unsupported state version %v, only the version 4 is supported, "+
+			"please upgrade the state with Terraform v0.12 or later first", state["version"])
+	}
+	return state, nil
+}
+
+// resourceAddress returns the address of the resource in the state, e.g. module.network.huaweicloud_vpc.myvpc
+func resourceAddress(resource map[string]interface{}) string {
+	address := fmt.Sprintf("%s.%s", resource["type"], resource["name"])
+	if resource["mode"] == "data" {
+		address = "data." + address
+	}
+	if module, ok := resource["module"].(string); ok && module != "" {
+		address = module + "." + address
+	}
+	return address
+}
+
+func renameDependencies(instance map[string]interface{}, aliases map[string]string) {
+	dependencies, _ := instance["dependencies"].([]interface{})
+	for i, d := range dependencies {
+		if address, ok := d.(string); ok {
+			dependencies[i] = renameAddress(address, aliases)
+		}
+	}
+}
+
+// renameAddress renames the resource type of the address of a managed resource, the module path is skipped
+func renameAddress(address string, aliases map[string]string) string {
+	parts := strings.Split(address, ".")
+	for i := 0; i < len(parts); i++ {
+		switch parts[i] {
+		case "module":
+			i++
+		case "data":
+			return address
+		default:
+			if name, ok := aliases[parts[i]]; ok {
+				parts[i] = name
+			}
+			return strings.Join(parts, ".")
+		}
+	}
+	return address
+}
+
+// MigrateConfig renames the resource blocks and the references of the aliases in the .tf files of the directory,
+// and returns the paths of the changed files. The data sources and the subdirectories are not changed.
+func MigrateConfig(dir string, aliases map[string]string) ([]string, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	// match the longer names first, e.g. huaweicloud_iam_agency_v3 before huaweicloud_iam_agency
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	pattern := strings.Join(names, "|")
+	blockRegexp := regexp.MustCompile(`(resource\s+")(` + pattern + `)(")`)
+	referenceRegexp := regexp.MustCompile(`(^|[^\w.\-])(` + pattern + `)(\.[A-Za-z_])`)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make([]string, 0)
+	for _, path := range files {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		replace := func(re *regexp.Regexp, src []byte) []byte {
+			return re.ReplaceAllFunc(src, func(match []byte) []byte {
+				groups := re.FindSubmatch(match)
+				return []byte(string(groups[1]) + aliases[string(groups[2])] + string(groups[3]))
+			})
+		}
+		result := replace(referenceRegexp, replace(blockRegexp, content))
+		if bytes.Equal(result, content) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, result, info.Mode()); err != nil {
+			return nil, err
+		}
+		changed = append(changed, path)
+	}
+	return changed, nil
+}
+
+// Main is the entry of the migrate-state subcommand, and returns the exit code.
+func Main(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("migrate-state", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	statePath := flags.String("state", "terraform.tfstate", "the path of the state file")
+	outPath := flags.String("out", "",
+		"the path of the migrated state, defaults to overwrite the state with a .backup file")
+	configDir := flags.String("config", "", "the directory of the configuration to rename the resources in")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-huaweicloud migrate-state [options]\n\n")
+		fmt.Fprintf(stderr, "Moves the resources of the legacy aliases, e.g. huaweicloud_vpc_v1, "+
+			"to the current names.\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	raw, err := ioutil.ReadFile(*statePath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	aliases := huaweicloud.ResourceAliases(huaweicloud.Provider())
+	result, renames, err := MigrateState(raw, aliases)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if len(renames) > 0 {
+		out := *outPath
+		if out == "" {
+			out = *statePath
+			if err := ioutil.WriteFile(out+".backup", raw, 0600); err != nil {
+				fmt.Fprintf(stderr, "Error writing the backup: %s\n", err)
+				return 1
+			}
+		}
+		if err := ioutil.WriteFile(out, result, 0600); err != nil {
+			fmt.Fprintf(stderr, "Error writing the state: %s\n", err)
+			return 1
+		}
+	}
+	for _, r := range renames {
+		fmt.Fprintf(stdout, "Moved %s to %s\n", r.From, r.To)
+	}
+	fmt.Fprintf(stdout, "%d resources are moved in the state.\n", len(renames))
+
+	if *configDir != "" {
+		files, err := MigrateConfig(*configDir, aliases)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		for _, path := range files {
+			fmt.Fprintf(stdout, "Updated %s\n", path)
+		}
+	}
+	return 0
+}
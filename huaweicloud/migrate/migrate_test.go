@@ -0,0 +1,279 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+)
+
+var testAliases = map[string]string{
+	"huaweicloud_vpc_v1":              "huaweicloud_vpc",
+	"huaweicloud_vpc_subnet_v1":       "huaweicloud_vpc_subnet",
+	"huaweicloud_iam_agency":          "huaweicloud_identity_agency",
+	"huaweicloud_iam_agency_v3":       "huaweicloud_identity_agency",
+	"huaweicloud_compute_instance_v2": "huaweicloud_compute_instance",
+}
+
+const testState = `{
+  "version": 4,
+  "terraform_version": "0.14.5",
+  "serial": 7,
+  "lineage": "6f1f5b0e-4c9b-c5a1-7e3b-2f7a1d0c9e8b",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "huaweicloud_vpc_v1",
+      "name": "myvpc",
+      "provider": "provider[\"registry.terraform.io/huaweicloud/huaweicloud\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {"id": "vpc-id", "cidr": "192.168.0.0/16", "size": 12345678901234567890},
+          "private": "bnVsbA=="
+        }
+      ]
+    },
+    {
+      "module": "module.network",
+      "mode": "managed",
+      "type": "huaweicloud_vpc_subnet_v1",
+      "name": "subnet",
+      "provider": "provider[\"registry.terraform.io/huaweicloud/huaweicloud\"]",
+      "instances": [
+        {
+          "schema_version": 0,
+          "attributes": {"id": "subnet-id", "description": "a<b&c"},
+          "dependencies": ["huaweicloud_vpc_v1.myvpc", "data.huaweicloud_vpc_v1.other"]
+        }
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "huaweicloud_vpc_v1",
+      "name": "other",
+      "provider": "provider[\"registry.terraform.io/huaweicloud/huaweicloud\"]",
+      "instances": []
+    }
+  ]
+}`
+
+func TestMigrateState(t *testing.T) {
+	result, renames, err := MigrateState([]byte(testState), testAliases)
+	if err != nil {
+		t.Fatalf("Error migrating the state: %s", err)
+	}
+
+	expected := []Rename{
+		{From: "huaweicloud_vpc_v1.myvpc", To: "huaweicloud_vpc.myvpc"},
+		{From: "module.network.huaweicloud_vpc_subnet_v1.subnet", To: "module.network.huaweicloud_vpc_subnet.subnet"},
+	}
+	if !reflect.DeepEqual(renames, expected) {
+		t.Fatalf("expected renames %v, but got %v", expected, renames)
+	}
+
+	for _, s := range []string{
+		`"serial": 8`,
+		`"type": "huaweicloud_vpc"`,
+		`"type": "huaweicloud_vpc_subnet"`,
+		`"size": 12345678901234567890`,
+		`"description": "a<b&c"`,
+		`"huaweicloud_vpc.myvpc"`,
+		`"data.huaweicloud_vpc_v1.other"`,
+		`"private": "bnVsbA=="`,
+	} {
+		if !bytes.Contains(result, []byte(s)) {
+			t.Errorf("expected %s in the migrated state:\n%s", s, result)
+		}
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(result, &state); err != nil {
+		t.Fatalf("the migrated state is invalid: %s", err)
+	}
+	if dataSource := state["resources"].([]interface{})[2].(map[string]interface{}); dataSource["type"] != "huaweicloud_vpc_v1" {
+		t.Errorf("the data source should not be renamed, but got %s", dataSource["type"])
+	}
+}
+
+func TestMigrateState_noChange(t *testing.T) {
+	raw := []byte(`{"version": 4, "serial": 1, "resources": [{"mode": "managed", "type": "huaweicloud_vpc", "name": "a"}]}`)
+	result, renames, err := MigrateState(raw, testAliases)
+	if err != nil {
+		t.Fatalf("Error migrating the state: %s", err)
+	}
+	if len(renames) != 0 || !bytes.Equal(result, raw) {
+		t.Errorf("expected the state is unchanged, but got %v:\n%s", renames, result)
+	}
+}
+
+func TestMigrateState_conflict(t *testing.T) {
+	raw := []byte(`{"version": 4, "serial": 1, "resources": [
+		{"mode": "managed", "type": "huaweicloud_vpc", "name": "a"},
+		{"mode": "managed", "type": "huaweicloud_vpc_v1", "name": "a"}
+	]}`)
+	_, _, err := MigrateState(raw, testAliases)
+	if err == nil || !strings.Contains(err.Error(), "can not move huaweicloud_vpc_v1.a to huaweicloud_vpc.a") {
+		t.Fatalf("expected the conflict error, but got %v", err)
+	}
+}
+
+func TestMigrateState_unsupportedVersion(t *testing.T) {
+	_, _, err := MigrateState([]byte(`{"version": 3, "serial": 1, "modules": []}`), testAliases)
+	if err == nil || !strings.Contains(err.Error(), "unsupported state version 3") {
+		t.Fatalf("expected the unsupported version error, but got %v", err)
+	}
+}
+
+func TestRenameAddress(t *testing.T) {
+	cases := map[string]string{
+		"huaweicloud_vpc_v1.myvpc":                       "huaweicloud_vpc.myvpc",
+		"huaweicloud_iam_agency.agency":                  "huaweicloud_identity_agency.agency",
+		"module.huaweicloud_vpc_v1.huaweicloud_vpc_v1.a": "module.huaweicloud_vpc_v1.huaweicloud_vpc.a",
+		"module.a.module.b.huaweicloud_vpc_v1.a":         "module.a.module.b.huaweicloud_vpc.a",
+		"data.huaweicloud_vpc_v1.a":                      "data.huaweicloud_vpc_v1.a",
+		"huaweicloud_vpc.a":                              "huaweicloud_vpc.a",
+	}
+	for address, expected := range cases {
+		if result := renameAddress(address, testAliases); result != expected {
+			t.Errorf("expected %s, but got %s", expected, result)
+		}
+	}
+}
+
+func TestMigrateConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := `resource "huaweicloud_vpc_v1" "myvpc" {
+  name = "vpc-demo"
+  cidr = "192.168.0.0/16"
+}
+
+data "huaweicloud_vpc_v1" "other" {
+  name = "other"
+}
+
+resource "huaweicloud_iam_agency_v3" "agency" {
+  name = "agency"
+}
+
+resource "huaweicloud_vpc_subnet_v1" "subnet" {
+  vpc_id      = huaweicloud_vpc_v1.myvpc.id
+  description = "${data.huaweicloud_vpc_v1.other.id} ${huaweicloud_iam_agency_v3.agency.name}"
+  depends_on  = [module.huaweicloud_vpc_v1.id]
+}
+`
+	expected := `resource "huaweicloud_vpc" "myvpc" {
+  name = "vpc-demo"
+  cidr = "192.168.0.0/16"
+}
+
+data "huaweicloud_vpc_v1" "other" {
+  name = "other"
+}
+
+resource "huaweicloud_identity_agency" "agency" {
+  name = "agency"
+}
+
+resource "huaweicloud_vpc_subnet" "subnet" {
+  vpc_id      = huaweicloud_vpc.myvpc.id
+  description = "${data.huaweicloud_vpc_v1.other.id} ${huaweicloud_identity_agency.agency.name}"
+  depends_on  = [module.huaweicloud_vpc_v1.id]
+}
+`
+	mainFile := filepath.Join(dir, "main.tf")
+	if err := ioutil.WriteFile(mainFile, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	otherFile := filepath.Join(dir, "variables.tf")
+	if err := ioutil.WriteFile(otherFile, []byte("variable \"name\" {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := MigrateConfig(dir, testAliases)
+	if err != nil {
+		t.Fatalf("Error migrating the configuration: %s", err)
+	}
+	if !reflect.DeepEqual(changed, []string{mainFile}) {
+		t.Errorf("expected only %s is changed, but got %v", mainFile, changed)
+	}
+
+	content, _ := ioutil.ReadFile(mainFile)
+	if string(content) != expected {
+		t.Errorf("unexpected migrated configuration:\n%s", content)
+	}
+}
+
+func TestMain_inPlace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migrate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	statePath := filepath.Join(dir, "terraform.tfstate")
+	if err := ioutil.WriteFile(statePath, []byte(testState), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := Main([]string{"-state", statePath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected the exit code 0, but got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Moved huaweicloud_vpc_v1.myvpc to huaweicloud_vpc.myvpc") {
+		t.Errorf("unexpected output: %s", stdout.String())
+	}
+
+	backup, err := ioutil.ReadFile(statePath + ".backup")
+	if err != nil || string(backup) != testState {
+		t.Errorf("expected the original state is backed up, got %s", err)
+	}
+	content, _ := ioutil.ReadFile(statePath)
+	if !strings.Contains(string(content), `"type": "huaweicloud_vpc"`) {
+		t.Errorf("the state is not migrated:\n%s", content)
+	}
+}
+
+// TestResourceAliases_shareImplementation makes sure the aliases can be moved without changing the attributes
+func TestResourceAliases_shareImplementation(t *testing.T) {
+	p := huaweicloud.Provider()
+	aliases := huaweicloud.ResourceAliases(p)
+
+	for alias, name := range map[string]string{
+		"huaweicloud_vpc_v1":                   "huaweicloud_vpc",
+		"huaweicloud_compute_instance_v2":      "huaweicloud_compute_instance",
+		"huaweicloud_rds_instance_v3":          "huaweicloud_rds_instance",
+		"huaweicloud_iam_agency_v3":            "huaweicloud_identity_agency",
+		"huaweicloud_networking_eip_associate": "huaweicloud_vpc_eip_associate",
+	} {
+		if aliases[alias] != name {
+			t.Errorf("expected the alias %s of %s, but got %q", alias, name, aliases[alias])
+		}
+	}
+
+	for alias, name := range aliases {
+		if _, ok := p.ResourcesMap[name]; !ok {
+			t.Errorf("the resource %s of the alias %s is not registered", name, alias)
+			continue
+		}
+		aliasType := p.ResourcesMap[alias].CoreConfigSchema().ImpliedType()
+		if !aliasType.Equals(p.ResourcesMap[name].CoreConfigSchema().ImpliedType()) {
+			t.Errorf("the schema of %s is different from %s", alias, name)
+		}
+		if p.ResourcesMap[alias].SchemaVersion != p.ResourcesMap[name].SchemaVersion {
+			t.Errorf("the schema version of %s is different from %s", alias, name)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package migrate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+)
+
+// The kinds of the deprecated usage.
+const (
+	KindResource   = "resource"
+	KindDataSource = "data_source"
+	KindAttribute  = "attribute"
+)
+
+// DeprecationReport is the deprecated usage in a state.
+type DeprecationReport struct {
+	State            string             `json:"state"`
+	TerraformVersion string             `json:"terraform_version"`
+	Lineage          string             `json:"lineage"`
+	Serial           json.Number        `json:"serial"`
+	Summary          DeprecationSummary `json:"summary"`
+	Deprecations     []Deprecation      `json:"deprecations"`
+}
+
+// DeprecationSummary is the count of the deprecated usage of each kind.
+type DeprecationSummary struct {
+	Resources   int `json:"resources"`
+	DataSources int `json:"data_sources"`
+	Attributes  int `json:"attributes"`
+}
+
+// Deprecation is a deprecated resource, data source or attribute used in the state.
+type Deprecation struct {
+	// Kind is one of resource, data_source and attribute
+	Kind string `json:"kind"`
+	// Address is the address of the resource, or the address of the instance for the attributes
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	// Attribute is the path of the deprecated attribute, e.g. root_volume.0.extend_param
+	Attribute string `json:"attribute,omitempty"`
+	// Replacement is what to use instead, it's empty if the deprecated one has no replacement
+	Replacement string `json:"replacement,omitempty"`
+	Message     string `json:"message"`
+}
+
+var replacementRegexp = regexp.MustCompile(`(?i)\buse (.+?) instead`)
+
+// replacementOf returns the replacement named in the deprecation message, e.g. huaweicloud_vpc
+// of "use huaweicloud_vpc resource instead"
+func replacementOf(message string) string {
+	match := replacementRegexp.FindStringSubmatch(message)
+	if match == nil {
+		return ""
+	}
+
+	replacement := strings.Trim(match[1], "`\"")
+	for _, suffix := range []string{" resources", " resource", " data sources", " data source"} {
+		replacement = strings.TrimSuffix(replacement, suffix)
+	}
+	return strings.Trim(replacement, "`\"")
+}
+
+// Report returns the deprecated resources, data sources and attributes of the provider used in the state.
+// Only the arguments which are not computed are reported for the deprecated attributes, as the values of the
+// computed ones are set by the provider even if they are not configured.
+func Report(raw []byte, p *schema.Provider) (*DeprecationReport, error) {
+	state, err := decodeState(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	report := DeprecationReport{
+		Deprecations: make([]Deprecation, 0),
+	}
+	report.TerraformVersion, _ = state["terraform_version"].(string)
+	report.Lineage, _ = state["lineage"].(string)
+	report.Serial, _ = state["serial"].(json.Number)
+
+	resources, _ := state["resources"].([]interface{})
+	for _, r := range resources {
+		resource, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid resource in the state: %v", r)
+		}
+
+		resourceType, _ := resource["type"].(string)
+		kind, schemaResource := KindResource, p.ResourcesMap[resourceType]
+		if resource["mode"] == "data" {
+			kind, schemaResource = KindDataSource, p.DataSourcesMap[resourceType]
+		}
+		if schemaResource == nil {
+			continue
+		}
+
+		address := resourceAddress(resource)
+		if message := schemaResource.DeprecationMessage; message != "" {
+			report.Deprecations = append(report.Deprecations, Deprecation{
+				Kind:        kind,
+				Address:     address,
+				Type:        resourceType,
+				Replacement: replacementOf(message),
+				Message:     message,
+			})
+		}
+
+		instances, _ := resource["instances"].([]interface{})
+		for _, i := range instances {
+			instance, ok := i.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			attributes, _ := instance["attributes"].(map[string]interface{})
+			instanceAddress := address + indexOf(instance["index_key"])
+			walkDeprecatedArguments("", schemaResource.Schema, attributes, func(path, message string) {
+				report.Deprecations = append(report.Deprecations, Deprecation{
+					Kind:        KindAttribute,
+					Address:     instanceAddress,
+					Type:        resourceType,
+					Attribute:   path,
+					Replacement: replacementOf(message),
+					Message:     message,
+				})
+			})
+		}
+	}
+
+	for _, d := range report.Deprecations {
+		switch d.Kind {
+		case KindResource:
+			report.Summary.Resources++
+		case KindDataSource:
+			report.Summary.DataSources++
+		case KindAttribute:
+			report.Summary.Attributes++
+		}
+	}
+	return &report, nil
+}
+
+// indexOf returns the index of the instance in the address, e.g. [0] of count and ["a"] of for_each
+func indexOf(key interface{}) string {
+	switch v := key.(type) {
+	case json.Number:
+		return fmt.Sprintf("[%s]", v)
+	case string:
+		return fmt.Sprintf("[%q]", v)
+	}
+	return ""
+}
+
+func walkDeprecatedArguments(prefix string, s map[string]*schema.Schema, attributes map[string]interface{},
+	report func(path, message string)) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := s[k]
+		value := attributes[k]
+		if v.Deprecated != "" && (v.Optional || v.Required) && !isEmptyValue(value, v.Default) {
+			report(prefix+k, v.Deprecated)
+		}
+
+		elem, ok := v.Elem.(*schema.Resource)
+		if !ok {
+			continue
+		}
+		items, _ := value.([]interface{})
+		for i, item := range items {
+			if nested, ok := item.(map[string]interface{}); ok {
+				walkDeprecatedArguments(fmt.Sprintf("%s%s.%d.", prefix, k, i), elem.Schema, nested, report)
+			}
+		}
+	}
+}
+
+// isEmptyValue returns true if the value in the state is null, zero or the default value
+func isEmptyValue(value, defaultValue interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == "" || v == fmt.Sprint(defaultValue)
+	case bool:
+		return !v || (defaultValue != nil && v == defaultValue)
+	case json.Number:
+		return v.String() == "0" || v.String() == fmt.Sprint(defaultValue)
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// ReportMain is the entry of the deprecation-report subcommand, and returns the exit code.
+func ReportMain(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("deprecation-report", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	statePath := flags.String("state", "terraform.tfstate", "the path of the state file")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-huaweicloud deprecation-report [options]\n\n")
+		fmt.Fprintf(stderr, "Prints the deprecated resources, data sources and attributes used in the state "+
+			"as JSON.\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	raw, err := ioutil.ReadFile(*statePath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	report, err := Report(raw, huaweicloud.Provider())
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	report.State = *statePath
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
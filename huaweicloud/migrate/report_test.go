@@ -0,0 +1,182 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+)
+
+const testReportState = `{
+  "version": 4,
+  "terraform_version": "0.14.5",
+  "serial": 3,
+  "lineage": "6f1f5b0e-4c9b-c5a1-7e3b-2f7a1d0c9e8b",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "huaweicloud_vpc_v1",
+      "name": "myvpc",
+      "instances": [{"attributes": {"id": "vpc-id", "name": "vpc-demo", "cidr": "192.168.0.0/16"}}]
+    },
+    {
+      "mode": "data",
+      "type": "huaweicloud_vpc_subnet_v1",
+      "name": "subnet",
+      "instances": [{"attributes": {"id": "subnet-id"}}]
+    },
+    {
+      "mode": "managed",
+      "type": "huaweicloud_vpnaas_service",
+      "name": "vpn",
+      "instances": [{"attributes": {"id": "vpn-id", "tenant_id": "project-id"}}]
+    },
+    {
+      "module": "module.cce",
+      "mode": "managed",
+      "type": "huaweicloud_cce_node",
+      "name": "node",
+      "instances": [
+        {
+          "index_key": 0,
+          "attributes": {
+            "id": "node-id",
+            "billing_mode": 0,
+            "eip_ids": [],
+            "root_volume": [{"size": 40, "volumetype": "SSD", "extend_param": "", "extend_params": {}}],
+            "data_volumes": [
+              {"size": 100, "volumetype": "SSD", "extend_param": "", "extend_params": {}},
+              {"size": 100, "volumetype": "SSD", "extend_param": "{\"useType\":\"docker\"}", "extend_params": {}}
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "huaweicloud_nat_gateway",
+      "name": "nat",
+      "instances": [{"index_key": "a", "attributes": {"id": "nat-id", "router_id": "vpc-id", "internal_network_id": ""}}]
+    },
+    {
+      "mode": "managed",
+      "type": "random_id",
+      "name": "suffix",
+      "instances": [{"attributes": {"id": "abc"}}]
+    }
+  ]
+}`
+
+func TestReport(t *testing.T) {
+	report, err := Report([]byte(testReportState), huaweicloud.Provider())
+	if err != nil {
+		t.Fatalf("Error reporting the deprecations: %s", err)
+	}
+
+	expected := []Deprecation{
+		{
+			Kind:        KindResource,
+			Address:     "huaweicloud_vpc_v1.myvpc",
+			Type:        "huaweicloud_vpc_v1",
+			Replacement: "huaweicloud_vpc",
+			Message:     "use huaweicloud_vpc resource instead",
+		},
+		{
+			Kind:        KindDataSource,
+			Address:     "data.huaweicloud_vpc_subnet_v1.subnet",
+			Type:        "huaweicloud_vpc_subnet_v1",
+			Replacement: "huaweicloud_vpc_subnet",
+			Message:     "use huaweicloud_vpc_subnet data source instead",
+		},
+		{
+			Kind:    KindResource,
+			Address: "huaweicloud_vpnaas_service.vpn",
+			Type:    "huaweicloud_vpnaas_service",
+			Message: "VPN has been deprecated and has no replacement.",
+		},
+		{
+			Kind:      KindAttribute,
+			Address:   "huaweicloud_vpnaas_service.vpn",
+			Type:      "huaweicloud_vpnaas_service",
+			Attribute: "tenant_id",
+			Message:   "tenant_id is deprecated and has no replacement",
+		},
+		{
+			Kind:        KindAttribute,
+			Address:     "module.cce.huaweicloud_cce_node.node[0]",
+			Type:        "huaweicloud_cce_node",
+			Attribute:   "data_volumes.1.extend_param",
+			Replacement: "extend_params",
+			Message:     "use extend_params instead",
+		},
+		{
+			Kind:        KindAttribute,
+			Address:     `huaweicloud_nat_gateway.nat["a"]`,
+			Type:        "huaweicloud_nat_gateway",
+			Attribute:   "router_id",
+			Replacement: "vpc_id",
+			Message:     "use vpc_id instead",
+		},
+	}
+	if !reflect.DeepEqual(report.Deprecations, expected) {
+		t.Errorf("expected deprecations:\n%+v\nbut got:\n%+v", expected, report.Deprecations)
+	}
+
+	summary := DeprecationSummary{Resources: 2, DataSources: 1, Attributes: 3}
+	if report.Summary != summary {
+		t.Errorf("expected the summary %+v, but got %+v", summary, report.Summary)
+	}
+	if report.Serial.String() != "3" || report.TerraformVersion != "0.14.5" {
+		t.Errorf("unexpected state metadata: %+v", report)
+	}
+}
+
+func TestReplacementOf(t *testing.T) {
+	cases := map[string]string{
+		"use huaweicloud_vpc resource instead":                                      "huaweicloud_vpc",
+		"use huaweicloud_availability_zones data source instead":                    "huaweicloud_availability_zones",
+		"Deprecated, please use `availability_zones` instead":                       "availability_zones",
+		`Deprecated. Please use "huaweicloud_availability_zones" instead.`:          "huaweicloud_availability_zones",
+		"use huaweicloud_vpc and huaweicloud_vpc_subnet resources instead":          "huaweicloud_vpc and huaweicloud_vpc_subnet",
+		"use system_disk_type, system_disk_size, data_disks instead":                "system_disk_type, system_disk_size, data_disks",
+		"tenant_id is deprecated and has no replacement":                            "",
+		"If you need to change the bandwidth, please update the product_id instead": "",
+	}
+	for message, expected := range cases {
+		if result := replacementOf(message); result != expected {
+			t.Errorf("expected %q of %q, but got %q", expected, message, result)
+		}
+	}
+}
+
+func TestReportMain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	statePath := filepath.Join(dir, "terraform.tfstate")
+	if err := ioutil.WriteFile(statePath, []byte(testReportState), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := ReportMain([]string{"-state", statePath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected the exit code 0, but got %d: %s", code, stderr.String())
+	}
+
+	var report DeprecationReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("the report is not valid JSON: %s\n%s", err, stdout.String())
+	}
+	if report.State != statePath || len(report.Deprecations) != 6 {
+		t.Errorf("unexpected report:\n%s", stdout.String())
+	}
+}
@@ -3,10 +3,12 @@ package huaweicloud
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/mutexkv"
@@ -42,11 +44,13 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/mrs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/obs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rds"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/rest"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/scm"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/swr"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/tms"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/vpc"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/waf"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 )
 
 const defaultCloud string = "myhuaweicloud.com"
@@ -270,10 +274,18 @@ func Provider() *schema.Provider {
 			},
 
 			"endpoints": {
-				Type:        schema.TypeMap,
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Description:  descriptions["endpoints"],
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateProviderEndpoints,
+			},
+
+			"endpoint_discovery": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: descriptions["endpoints"],
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: descriptions["endpoint_discovery"],
+				DefaultFunc: schema.EnvDefaultFunc("HW_ENDPOINT_DISCOVERY", false),
 			},
 
 			"shared_config_file": {
@@ -303,11 +315,76 @@ func Provider() *schema.Provider {
 				Description: descriptions["max_retries"],
 				DefaultFunc: schema.EnvDefaultFunc("HW_MAX_RETRIES", 5),
 			},
+
+			"log_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  descriptions["log_format"],
+				DefaultFunc:  schema.EnvDefaultFunc("HW_LOG_FORMAT", config.LogFormatText),
+				ValidateFunc: validation.StringInSlice([]string{config.LogFormatText, config.LogFormatJSON}, false),
+			},
+
+			"log_redact_fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["log_redact_fields"],
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"log_redact_headers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: descriptions["log_redact_headers"],
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"rate_limits": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["rate_limits"],
+				Elem:        &schema.Schema{Type: schema.TypeFloat},
+			},
+
+			"default_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["default_tags"],
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"enable_plan_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: descriptions["enable_plan_validation"],
+				DefaultFunc: schema.EnvDefaultFunc("HW_ENABLE_PLAN_VALIDATION", false),
+			},
+
+			"ignore_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["ignore_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"key_prefixes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"huaweicloud_antiddos":                             dataSourceAntiDdosV1(),
 			"huaweicloud_availability_zones":                   DataSourceAvailabilityZones(),
+			"huaweicloud_service_endpoint":                     DataSourceServiceEndpoint(),
 			"huaweicloud_bms_flavors":                          bms.DataSourceBmsFlavors(),
 			"huaweicloud_cbr_vaults":                           cbr.DataSourceCbrVaultsV3(),
 			"huaweicloud_cce_addon_template":                   DataSourceCCEAddonTemplateV3(),
@@ -381,6 +458,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_rds_flavors":                          rds.DataSourceRdsFlavor(),
 			"huaweicloud_rds_engine_versions":                  rds.DataSourceRdsEngineVersionsV3(),
 			"huaweicloud_rds_instances":                        rds.DataSourceRdsInstances(),
+			"huaweicloud_rest_request":                         rest.DataSourceRestRequest(),
 			"huaweicloud_sfs_file_system":                      DataSourceSFSFileSystemV2(),
 			"huaweicloud_vbs_backup_policy":                    dataSourceVBSBackupPolicyV2(),
 			"huaweicloud_vbs_backup":                           dataSourceVBSBackupV2(),
@@ -562,6 +640,9 @@ func Provider() *schema.Provider {
 			"huaweicloud_iec_vpc":                          ResourceIecVpc(),
 			"huaweicloud_iec_vpc_subnet":                   resourceIecSubnet(),
 			"huaweicloud_images_image":                     ResourceImsImage(),
+			"huaweicloud_images_image_copy":                ims.ResourceImsImageCopy(),
+			"huaweicloud_images_image_share":               ims.ResourceImsImageShare(),
+			"huaweicloud_images_image_share_accepter":      ims.ResourceImsImageShareAccepter(),
 			"huaweicloud_kms_key":                          ResourceKmsKeyV1(),
 			"huaweicloud_lb_certificate":                   lb.ResourceCertificateV2(),
 			"huaweicloud_lb_l7policy":                      lb.ResourceL7PolicyV2(),
@@ -599,6 +680,7 @@ func Provider() *schema.Provider {
 			"huaweicloud_rds_instance":                     ResourceRdsInstanceV3(),
 			"huaweicloud_rds_parametergroup":               ResourceRdsConfigurationV3(),
 			"huaweicloud_rds_read_replica_instance":        ResourceRdsReadReplicaInstance(),
+			"huaweicloud_rest_resource":                    rest.ResourceRestResource(),
 			"huaweicloud_sfs_access_rule":                  ResourceSFSAccessRuleV2(),
 			"huaweicloud_sfs_file_system":                  ResourceSFSFileSystemV2(),
 			"huaweicloud_sfs_turbo":                        ResourceSFSTurbo(),
@@ -757,6 +839,8 @@ func Provider() *schema.Provider {
 		return configureProvider(d, terraformVersion)
 	}
 
+	deprecateAliases(provider)
+
 	return provider
 }
 
@@ -810,6 +894,9 @@ func init() {
 
 		"endpoints": "The custom endpoints used to override the default endpoint URL.",
 
+		"endpoint_discovery": "Whether to resolve the endpoints from the service catalog of IAM, " +
+			"the built-in endpoints are used if the services are not in the catalog.",
+
 		"shared_config_file": "The path to the shared config file. If not set, the default is ~/.hcloud/config.json.",
 
 		"profile": "The profile name as set in the shared config file.",
@@ -817,6 +904,21 @@ func init() {
 		"max_retries": "How many times HTTP connection should be retried until giving up.",
 
 		"enterprise_project_id": "enterprise project id",
+
+		"log_format": "The format of the HTTP debug log, the valid values are text and json.",
+
+		"log_redact_fields": "The JSON paths of the request and response body to be redacted in the debug log.",
+
+		"log_redact_headers": "The header names to be redacted in the debug log.",
+
+		"rate_limits": "The maximum requests per second of each service, the key is the service name of endpoints.",
+
+		"default_tags": "The default tags which will be applied to all taggable resources.",
+
+		"ignore_tags": "The tag keys and key prefixes which will be ignored by all taggable resources.",
+
+		"enable_plan_validation": "Whether to validate the flavors, availability zones, images and quotas of some " +
+			"resources during the plan.",
 	}
 }
 
@@ -887,10 +989,18 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 		EnterpriseProjectID: d.Get("enterprise_project_id").(string),
 		SharedConfigFile:    d.Get("shared_config_file").(string),
 		Profile:             d.Get("profile").(string),
+		LogFormat:           d.Get("log_format").(string),
+		LogRedactFields:     utils.ExpandToStringList(d.Get("log_redact_fields").([]interface{})),
+		LogRedactHeaders:    utils.ExpandToStringList(d.Get("log_redact_headers").([]interface{})),
 		TerraformVersion:    terraformVersion,
 		RegionProjectIDMap:  make(map[string]string),
 		RPLock:              new(sync.Mutex),
 		SecurityKeyLock:     new(sync.Mutex),
+		ClientCache:         config.NewServiceClientCache(),
+
+		EndpointDiscovery:    d.Get("endpoint_discovery").(bool),
+		EnablePlanValidation: d.Get("enable_plan_validation").(bool),
+		PlanDataCache:        config.NewPlanDataCache(),
 	}
 
 	// get custom endpoints
@@ -900,6 +1010,20 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 	}
 	config.Endpoints = endpoints
 
+	rateLimits := make(map[string]float64)
+	for key, val := range d.Get("rate_limits").(map[string]interface{}) {
+		rateLimits[key] = val.(float64)
+	}
+	config.RateLimits = rateLimits
+
+	// get default tags and ignore tags
+	ignoreKeys, ignoreKeyPrefixes := flattenProviderIgnoreTags(d)
+	config.TagsConfig = &utils.TagsConfig{
+		DefaultTags:       flattenProviderDefaultTags(d),
+		IgnoreKeys:        ignoreKeys,
+		IgnoreKeyPrefixes: ignoreKeyPrefixes,
+	}
+
 	if err := config.LoadAndValidate(); err != nil {
 		return nil, err
 	}
@@ -907,6 +1031,25 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 	return &config, nil
 }
 
+// validateProviderEndpoints checks the keys of the custom endpoints, an unknown key is probably a typo and
+// the endpoint would be ignored silently
+func validateProviderEndpoints(v interface{}, k string) (ws []string, errors []error) {
+	validKeys := config.GetEndpointKeys()
+	keys := make([]string, 0)
+	for key := range v.(map[string]interface{}) {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !utils.StrSliceContains(validKeys, key) {
+			errors = append(errors, fmt.Errorf("%q is not a valid key of %s, the valid keys are: %s",
+				key, k, strings.Join(validKeys, ", ")))
+		}
+	}
+	return
+}
+
 func flattenProviderEndpoints(d *schema.ResourceData) (map[string]string, error) {
 	endpoints := d.Get("endpoints").(map[string]interface{})
 	epMap := make(map[string]string)
@@ -944,3 +1087,27 @@ func flattenProviderEndpoints(d *schema.ResourceData) (map[string]string, error)
 	log.Printf("[DEBUG] customer endpoints: %+v", epMap)
 	return epMap, nil
 }
+
+func flattenProviderDefaultTags(d *schema.ResourceData) map[string]string {
+	defaultTags := make(map[string]string)
+	for key, val := range d.Get("default_tags").(map[string]interface{}) {
+		defaultTags[key] = val.(string)
+	}
+
+	log.Printf("[DEBUG] default tags: %+v", defaultTags)
+	return defaultTags
+}
+
+func flattenProviderIgnoreTags(d *schema.ResourceData) (keys, prefixes []string) {
+	rawList := d.Get("ignore_tags").([]interface{})
+	if len(rawList) == 0 || rawList[0] == nil {
+		return
+	}
+
+	raw := rawList[0].(map[string]interface{})
+	keys = utils.ExpandToStringListBySet(raw["keys"].(*schema.Set))
+	prefixes = utils.ExpandToStringListBySet(raw["key_prefixes"].(*schema.Set))
+
+	log.Printf("[DEBUG] ignore tag keys: %v, ignore tag key prefixes: %v", keys, prefixes)
+	return
+}
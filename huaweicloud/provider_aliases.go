@@ -0,0 +1,86 @@
+package huaweicloud
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// legacyResourceRenames are the legacy resource names which are not the current names without the version suffix
+var legacyResourceRenames = map[string]string{
+	"huaweicloud_networking_eip_associate": "huaweicloud_vpc_eip_associate",
+	"huaweicloud_iam_agency":               "huaweicloud_identity_agency",
+	"huaweicloud_maas_task":                "huaweicloud_oms_task",
+}
+
+var versionSuffixRegexp = regexp.MustCompile(`_v\d+$`)
+
+// ResourceAliases returns the legacy names of the resources and their current names, such as
+// huaweicloud_vpc_v1 and huaweicloud_vpc. A legacy name is an alias only if it shares the implementation
+// with the current name, so the state can be moved to the current name without any change.
+func ResourceAliases(p *schema.Provider) map[string]string {
+	return aliasesOf(p.ResourcesMap)
+}
+
+// DataSourceAliases returns the legacy names of the data sources and their current names.
+func DataSourceAliases(p *schema.Provider) map[string]string {
+	return aliasesOf(p.DataSourcesMap)
+}
+
+func aliasesOf(resources map[string]*schema.Resource) map[string]string {
+	aliases := make(map[string]string)
+	for name, r := range resources {
+		current := name
+		for {
+			next, ok := legacyResourceRenames[current]
+			if !ok && versionSuffixRegexp.MatchString(current) {
+				next, ok = versionSuffixRegexp.ReplaceAllString(current, ""), true
+			}
+			if !ok {
+				break
+			}
+			if target, exist := resources[next]; !exist || !isSameImplementation(r, target) {
+				break
+			}
+			current = next
+		}
+
+		if current != name {
+			aliases[name] = current
+		}
+	}
+	return aliases
+}
+
+// isSameImplementation returns true if the resources are read by the same function
+func isSameImplementation(a, b *schema.Resource) bool {
+	readFunc := func(r *schema.Resource) uintptr {
+		if r.ReadContext != nil {
+			return reflect.ValueOf(r.ReadContext).Pointer()
+		}
+		if r.Read != nil {
+			return reflect.ValueOf(r.Read).Pointer()
+		}
+		return 0
+	}
+
+	pa := readFunc(a)
+	return pa != 0 && pa == readFunc(b)
+}
+
+// deprecateAliases sets the deprecation message of the aliases without one, so a warning which names the current
+// name is shown when an alias is used.
+func deprecateAliases(p *schema.Provider) {
+	for alias, name := range ResourceAliases(p) {
+		if r := p.ResourcesMap[alias]; r.DeprecationMessage == "" {
+			r.DeprecationMessage = fmt.Sprintf("use %s resource instead", name)
+		}
+	}
+	for alias, name := range DataSourceAliases(p) {
+		if r := p.DataSourcesMap[alias]; r.DeprecationMessage == "" {
+			r.DeprecationMessage = fmt.Sprintf("use %s data source instead", name)
+		}
+	}
+}
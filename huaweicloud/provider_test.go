@@ -4,8 +4,10 @@ import (
 	"context"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
@@ -194,6 +196,69 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+// TestProvider_deprecationMessages makes sure the warnings of the deprecated resources, data sources and
+// attributes tell the users what to use instead
+func TestProvider_deprecationMessages(t *testing.T) {
+	p := Provider()
+	isDescriptive := func(message string) bool {
+		return strings.Contains(message, "instead") || strings.Contains(message, "no replacement")
+	}
+
+	var checkSchema func(address string, s map[string]*schema.Schema)
+	checkSchema = func(address string, s map[string]*schema.Schema) {
+		for k, v := range s {
+			if v.Deprecated != "" && !isDescriptive(v.Deprecated) {
+				t.Errorf("the deprecation message of %s.%s does not name the replacement: %s", address, k, v.Deprecated)
+			}
+			if r, ok := v.Elem.(*schema.Resource); ok {
+				checkSchema(address+"."+k, r.Schema)
+			}
+		}
+	}
+	check := func(kind string, resources map[string]*schema.Resource, aliases map[string]string) {
+		for name, r := range resources {
+			if _, ok := aliases[name]; ok && r.DeprecationMessage == "" {
+				t.Errorf("the alias %s %s is not deprecated", kind, name)
+			}
+			if r.DeprecationMessage != "" && !isDescriptive(r.DeprecationMessage) {
+				t.Errorf("the deprecation message of %s %s does not name the replacement: %s",
+					kind, name, r.DeprecationMessage)
+			}
+			checkSchema(name, r.Schema)
+		}
+	}
+	check("resource", p.ResourcesMap, ResourceAliases(p))
+	check("data source", p.DataSourcesMap, DataSourceAliases(p))
+
+	diags := p.ResourcesMap["huaweicloud_vpc_v1"].Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "vpc-demo",
+		"cidr": "192.168.0.0/16",
+	}))
+	if len(diags) != 1 || diags[0].Detail != "use huaweicloud_vpc resource instead" {
+		t.Errorf("expected the deprecation warning of huaweicloud_vpc_v1, but got %v", diags)
+	}
+}
+
+func TestValidateProviderEndpoints(t *testing.T) {
+	_, errs := validateProviderEndpoints(map[string]interface{}{
+		"ecs": "https://ecs.example.com",
+		"obs": "https://obs.example.com",
+	}, "endpoints")
+	if len(errs) != 0 {
+		t.Errorf("expected no error of the valid keys, but got %v", errs)
+	}
+
+	_, errs = validateProviderEndpoints(map[string]interface{}{
+		"ecs":  "https://ecs.example.com",
+		"vcp":  "https://vpc.example.com",
+		"ecs2": "https://ecs.example.com",
+	}, "endpoints")
+	if len(errs) != 2 || !strings.HasPrefix(errs[0].Error(), `"ecs2" is not a valid key of endpoints`) ||
+		!strings.HasPrefix(errs[1].Error(), `"vcp" is not a valid key of endpoints`) {
+		t.Errorf("expected the errors of the unknown keys, but got %v", errs)
+	}
+}
+
 // Steps for configuring HuaweiCloud with SSL validation are here:
 // https://github.com/hashicorp/terraform/pull/6279#issuecomment-219020144
 func TestAccProvider_caCertFile(t *testing.T) {
@@ -336,3 +401,24 @@ func envVarFile(varName string) (string, error) {
 	}
 	return tmpFile.Name(), nil
 }
+
+// testAccImportStateIdWithAttributes returns the composite import ID of the resource, which joins the values of the
+// attributes and the resource ID with slashes, e.g. "<pool_id>/<id>".
+func testAccImportStateIdWithAttributes(resourceName string, attributes ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmtp.Errorf("Can't find %s in state", resourceName)
+		}
+
+		parts := make([]string, 0, len(attributes)+1)
+		for _, attr := range attributes {
+			value := rs.Primary.Attributes[attr]
+			if value == "" {
+				return "", fmtp.Errorf("The attribute %s of %s is empty", attr, resourceName)
+			}
+			parts = append(parts, value)
+		}
+		return strings.Join(append(parts, rs.Primary.ID), "/"), nil
+	}
+}
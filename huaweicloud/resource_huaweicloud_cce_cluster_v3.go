@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/waiter"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
@@ -205,7 +206,6 @@ func ResourceCCEClusterV3() *schema.Resource {
 			"enterprise_project_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"extend_param": {
@@ -221,10 +221,11 @@ func ResourceCCEClusterV3() *schema.Resource {
 			"tags": tagsForceNewSchema(),
 
 			// charge info: charging_mode, period_unit, period, auto_renew
-			"charging_mode": schemeChargingMode(nil),
-			"period_unit":   schemaPeriodUnit(nil),
-			"period":        schemaPeriod(nil),
-			"auto_renew":    schemaAutoRenew(nil),
+			"charging_mode": common.SchemaChargingModeUpdatable(nil),
+			"period_unit":   common.SchemaPeriodUnitUpdatable(nil),
+			"period":        common.SchemaPeriodUpdatable(nil),
+			"auto_renew":    common.SchemaAutoRenewUpdatable(nil),
+			"expire_time":   common.SchemaExpireTime(),
 
 			"delete_efs": associateDeleteSchema,
 			"delete_eni": associateDeleteSchema,
@@ -312,8 +313,8 @@ func resourceClusterLabelsV3(d *schema.ResourceData) map[string]string {
 	return m
 }
 
-func resourceCCEClusterTags(d *schema.ResourceData) []tags.ResourceTag {
-	tagRaw := d.Get("tags").(map[string]interface{})
+func resourceCCEClusterTags(d *schema.ResourceData, conf *config.Config) []tags.ResourceTag {
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), conf.TagsConfig)
 	return utils.ExpandResourceTags(tagRaw)
 }
 
@@ -455,7 +456,7 @@ func resourceCCEClusterV3Create(ctx context.Context, d *schema.ResourceData, met
 			BillingMode:          billingMode,
 			ExtendParam:          resourceClusterExtendParamV3(d, config),
 			KubernetesSvcIPRange: d.Get("service_network_cidr").(string),
-			ClusterTags:          resourceCCEClusterTags(d),
+			ClusterTags:          resourceCCEClusterTags(d, config),
 		},
 	}
 
@@ -490,16 +491,19 @@ func resourceCCEClusterV3Create(ctx context.Context, d *schema.ResourceData, met
 	d.SetId(clusterID)
 
 	logp.Printf("[DEBUG] Waiting for HuaweiCloud CCE cluster (%s) to become available", clusterID)
-	stateConf := &resource.StateChangeConf{
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("CCE cluster (%s) to become available", clusterID),
 		Pending:      []string{"Creating"},
 		Target:       []string{"Available"},
+		Failed:       []string{"Error"},
 		Refresh:      waitForCCEClusterActive(cceClient, clusterID),
+		FailReason:   cceClusterFailReason,
 		Timeout:      d.Timeout(schema.TimeoutCreate),
 		Delay:        20 * time.Second,
 		PollInterval: 20 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.WaitForState(ctx)
 	if err != nil {
 		return fmtp.DiagErrorf("Error creating HuaweiCloud CCE cluster: %s", err)
 	}
@@ -566,12 +570,13 @@ func resourceCCEClusterV3Read(_ context.Context, d *schema.ResourceData, meta in
 		d.Set("enterprise_project_id", n.Spec.ExtendParam["enterpriseProjectId"]),
 		d.Set("service_network_cidr", n.Spec.KubernetesSvcIPRange),
 		d.Set("billing_mode", n.Spec.BillingMode),
-		d.Set("tags", utils.TagsToMap(n.Spec.ClusterTags)),
+		d.Set("tags", utils.FlattenResourceTags(d, n.Spec.ClusterTags, config.TagsConfig)),
 	)
 
 	if n.Spec.BillingMode != 0 {
 		mErr = multierror.Append(mErr, d.Set("charging_mode", "prePaid"))
 	}
+	mErr = multierror.Append(mErr, common.SetPrePaidExpireTime(d, config, d.Id()))
 
 	r := clusters.GetCert(cceClient, d.Id())
 
@@ -658,6 +663,22 @@ func resourceCCEClusterV3Update(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	// the postPaid clusters can not be converted to prePaid through the API
+	if err := common.UpdateChargeInfo(ctx, d, config, d.Id(), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		migrateOpts := common.MigrateResourceOpts{
+			ResourceId:   d.Id(),
+			ResourceType: "cce-cluster",
+			ProjectId:    cceClient.ProjectID,
+		}
+		if err := common.MigrateEnterpriseProject(ctx, d, config, migrateOpts); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceCCEClusterV3Read(ctx, d, meta)
 }
 
@@ -695,7 +716,8 @@ func resourceCCEClusterV3Delete(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("CCE cluster (%s) to be deleted", d.Id()),
 		Pending:      []string{"Deleting", "Available", "Unavailable"},
 		Target:       []string{"Deleted"},
 		Refresh:      waitForCCEClusterDelete(cceClient, d.Id()),
@@ -704,7 +726,7 @@ func resourceCCEClusterV3Delete(ctx context.Context, d *schema.ResourceData, met
 		PollInterval: 20 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.WaitForState(ctx)
 
 	if err != nil {
 		return fmtp.DiagErrorf("Error deleting HuaweiCloud CCE cluster: %s", err)
@@ -725,6 +747,20 @@ func waitForCCEClusterActive(cceClient *golangsdk.ServiceClient, clusterId strin
 	}
 }
 
+func cceClusterFailReason(result interface{}) string {
+	if cluster, ok := result.(*clusters.Clusters); ok {
+		return cluster.Status.Reason
+	}
+	return ""
+}
+
+func cceJobFailReason(result interface{}) string {
+	if job, ok := result.(*nodes.Job); ok {
+		return strings.TrimSpace(job.Status.Reason + " " + job.Status.Message)
+	}
+	return ""
+}
+
 func waitForCCEClusterDelete(cceClient *golangsdk.ServiceClient, clusterId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		logp.Printf("[DEBUG] Attempting to delete HuaweiCloud CCE cluster %s.\n", clusterId)
@@ -747,24 +783,21 @@ func waitForCCEClusterDelete(cceClient *golangsdk.ServiceClient, clusterId strin
 }
 
 func getCCEClusterIDFromJob(ctx context.Context, client *golangsdk.ServiceClient, jobID string, timeout time.Duration) (string, error) {
-	stateJob := &resource.StateChangeConf{
+	stateJob := &waiter.Conf{
+		Description:  fmt.Sprintf("CCE job (%s) to become success", jobID),
 		Pending:      []string{"Initializing", "Running"},
 		Target:       []string{"Success"},
+		Failed:       []string{"Failed"},
 		Refresh:      waitForJobStatus(client, jobID),
+		FailReason:   cceJobFailReason,
 		Timeout:      timeout,
 		Delay:        150 * time.Second,
 		PollInterval: 20 * time.Second,
 	}
 
-	v, err := stateJob.WaitForStateContext(ctx)
+	v, err := stateJob.WaitForState(ctx)
 	if err != nil {
-		if job, ok := v.(*nodes.Job); ok {
-			return "", fmtp.Errorf("Error waiting for job (%s) to become success: %s, reason: %s",
-				jobID, err, job.Status.Reason)
-		} else {
-			return "", fmtp.Errorf("Error waiting for job (%s) to become success: %s", jobID, err)
-		}
-
+		return "", fmtp.Errorf("Error waiting for job (%s) to become success: %s", jobID, err)
 	}
 
 	job := v.(*nodes.Job)
@@ -783,16 +816,19 @@ func resourceCCEClusterV3Hibernate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	logp.Printf("[DEBUG] Waiting for HuaweiCloud CCE cluster (%s) to become hibernating", clusterID)
-	stateConf := &resource.StateChangeConf{
-		Pending:      []string{"Available"},
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("CCE cluster (%s) to become hibernating", clusterID),
+		Pending:      []string{"Available", "Hibernating"},
 		Target:       []string{"Hibernation"},
+		Failed:       []string{"Error"},
 		Refresh:      waitForCCEClusterActive(cceClient, clusterID),
+		FailReason:   cceClusterFailReason,
 		Timeout:      d.Timeout(schema.TimeoutUpdate),
 		Delay:        20 * time.Second,
 		PollInterval: 20 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.WaitForState(ctx)
 	if err != nil {
 		return fmtp.Errorf("Error hibernating HuaweiCloud CCE cluster: %s", err)
 	}
@@ -807,16 +843,19 @@ func resourceCCEClusterV3Awake(ctx context.Context, d *schema.ResourceData, cceC
 	}
 
 	logp.Printf("[DEBUG] Waiting for HuaweiCloud CCE cluster (%s) to become available", clusterID)
-	stateConf := &resource.StateChangeConf{
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("CCE cluster (%s) to become available", clusterID),
 		Pending:      []string{"Awaking"},
 		Target:       []string{"Available"},
+		Failed:       []string{"Error"},
 		Refresh:      waitForCCEClusterActive(cceClient, clusterID),
+		FailReason:   cceClusterFailReason,
 		Timeout:      d.Timeout(schema.TimeoutUpdate),
 		Delay:        100 * time.Second,
 		PollInterval: 20 * time.Second,
 	}
 
-	_, err = stateConf.WaitForStateContext(ctx)
+	_, err = stateConf.WaitForState(ctx)
 	if err != nil {
 		return fmtp.Errorf("Error awaking HuaweiCloud CCE cluster: %s", err)
 	}
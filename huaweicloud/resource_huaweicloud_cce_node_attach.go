@@ -21,6 +21,10 @@ func ResourceCCENodeAttachV3() *schema.Resource {
 		UpdateContext: resourceCCENodeAttachV3Update,
 		DeleteContext: resourceCCENodeAttachV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCCENodeV3Import,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -231,10 +235,10 @@ func ResourceCCENodeAttachV3() *schema.Resource {
 	}
 }
 
-func resourceCCENodeAttachV3ServerConfig(d *schema.ResourceData) *nodes.ServerConfig {
+func resourceCCENodeAttachV3ServerConfig(d *schema.ResourceData, conf *config.Config) *nodes.ServerConfig {
 	if hasFilledOpt(d, "tags") || hasFilledOpt(d, "image_id") {
 		serverConfig := nodes.ServerConfig{
-			UserTags: resourceCCENodeTags(d),
+			UserTags: resourceCCENodeTags(d, conf),
 		}
 
 		if v, ok := d.GetOk("image_id"); ok {
@@ -326,7 +330,7 @@ func resourceCCENodeAttachV3Create(ctx context.Context, d *schema.ResourceData,
 		Spec: nodes.AddNodeSpec{
 			Os:            d.Get("os").(string),
 			Name:          d.Get("name").(string),
-			ServerConfig:  resourceCCENodeAttachV3ServerConfig(d),
+			ServerConfig:  resourceCCENodeAttachV3ServerConfig(d, config),
 			VolumeConfig:  resourceCCENodeAttachV3VolumeConfig(d),
 			RuntimeConfig: resourceCCENodeAttachV3RuntimeConfig(d),
 			K8sOptions:    resourceCCENodeAttachV3K8sOptions(d),
@@ -403,7 +407,7 @@ func resourceCCENodeAttachV3Update(ctx context.Context, d *schema.ResourceData,
 			Spec: nodes.AddNodeSpec{
 				Os:            d.Get("os").(string),
 				Name:          d.Get("name").(string),
-				ServerConfig:  resourceCCENodeAttachV3ServerConfig(d),
+				ServerConfig:  resourceCCENodeAttachV3ServerConfig(d, config),
 				VolumeConfig:  resourceCCENodeAttachV3VolumeConfig(d),
 				RuntimeConfig: resourceCCENodeAttachV3RuntimeConfig(d),
 				K8sOptions:    resourceCCENodeAttachV3K8sOptions(d),
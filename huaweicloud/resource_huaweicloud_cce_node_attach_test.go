@@ -53,6 +53,12 @@ func TestAccCCENodeAttachV3_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "os", "CentOS 7.6"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateIdWithAttributes(resourceName, "cluster_id"),
+			},
 		},
 	})
 }
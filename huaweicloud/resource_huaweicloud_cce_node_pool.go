@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/plancheck"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
@@ -22,7 +23,7 @@ import (
 )
 
 func ResourceCCENodePool() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		CreateContext: resourceCCENodePoolCreate,
 		ReadContext:   resourceCCENodePoolRead,
 		UpdateContext: resourceCCENodePoolUpdate,
@@ -31,6 +32,10 @@ func ResourceCCENodePool() *schema.Resource {
 			StateContext: resourceCCENodePoolV3Import,
 		},
 
+		CustomizeDiff: plancheck.CustomizeDiff(validateCCENodePoolPlan),
+
+		SchemaVersion: 1,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
@@ -266,10 +271,19 @@ func ResourceCCENodePool() *schema.Resource {
 			},
 		},
 	}
+
+	resource.StateUpgraders = []schema.StateUpgrader{
+		{
+			Type:    resourceCCEVolumeSchemaV0(resource.Schema).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceCCEVolumeStateUpgradeV0,
+			Version: 0,
+		},
+	}
+	return resource
 }
 
-func resourceCCENodePoolTags(d *schema.ResourceData) []tags.ResourceTag {
-	tagRaw := d.Get("tags").(map[string]interface{})
+func resourceCCENodePoolTags(d *schema.ResourceData, conf *config.Config) []tags.ResourceTag {
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), conf.TagsConfig)
 	return utils.ExpandResourceTags(tagRaw)
 }
 
@@ -319,7 +333,7 @@ func resourceCCENodePoolCreate(ctx context.Context, d *schema.ResourceData, meta
 				},
 				ExtendParam: resourceCCEExtendParam(d),
 				Taints:      resourceCCETaint(d),
-				UserTags:    resourceCCENodePoolTags(d),
+				UserTags:    resourceCCENodePoolTags(d, config),
 			},
 			Autoscaling: nodepools.AutoscalingSpec{
 				Enable:                d.Get("scall_enable").(bool),
@@ -462,7 +476,7 @@ func resourceCCENodePoolRead(_ context.Context, d *schema.ResourceData, meta int
 	}
 	mErr = multierror.Append(mErr, d.Set("root_volume", rootVolume))
 
-	tagmap := utils.TagsToMap(s.Spec.NodeTemplate.UserTags)
+	tagmap := utils.FlattenResourceTags(d, s.Spec.NodeTemplate.UserTags, config.TagsConfig)
 	mErr = multierror.Append(mErr,
 		d.Set("tags", tagmap),
 		d.Set("status", s.Status.Phase),
@@ -521,7 +535,7 @@ func resourceCCENodePoolUpdate(ctx context.Context, d *schema.ResourceData, meta
 				RootVolume:  resourceCCERootVolume(d),
 				DataVolumes: resourceCCEDataVolume(d),
 				Count:       1,
-				UserTags:    resourceCCENodePoolTags(d),
+				UserTags:    resourceCCENodePoolTags(d, config),
 				K8sTags:     resourceCCENodeK8sTags(d),
 				Taints:      resourceCCETaint(d),
 			},
@@ -624,3 +638,39 @@ func resourceCCENodePoolV3Import(_ context.Context, d *schema.ResourceData, meta
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// validateCCENodePoolPlan validates the flavor, availability zone and quotas of the node pool during the plan,
+// it's only called when enable_plan_validation is true.
+func validateCCENodePoolPlan(_ context.Context, d *schema.ResourceDiff, conf *config.Config) error {
+	if !plancheck.ShouldValidate(d, "flavor_id", "availability_zone", "initial_node_count") {
+		return nil
+	}
+
+	region := plancheck.GetRegion(d, conf)
+	flavor := plancheck.GetKnownString(d, "flavor_id")
+	az := plancheck.GetKnownString(d, "availability_zone")
+	// the nodes are distributed in the availability zones by CCE if it's random
+	if az == "random" {
+		az = ""
+	}
+
+	if err := plancheck.CheckComputeAvailabilityZone(conf, region, az); err != nil {
+		return fmtp.Errorf("Error validating availability_zone: %s", err)
+	}
+	if err := plancheck.CheckComputeFlavor(conf, region, flavor, az); err != nil {
+		return fmtp.Errorf("Error validating flavor_id: %s", err)
+	}
+
+	// only the nodes to be added are checked
+	oldCount, newCount := d.GetChange("initial_node_count")
+	count := newCount.(int)
+	if d.Id() != "" {
+		count -= oldCount.(int)
+	}
+	if flavor != "" && count > 0 && d.NewValueKnown("initial_node_count") {
+		if err := plancheck.CheckComputeQuota(conf, region, flavor, count); err != nil {
+			return fmtp.Errorf("Error validating the quotas: %s", err)
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/plancheck"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
@@ -25,7 +26,7 @@ import (
 )
 
 func ResourceCCENodeV3() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		CreateContext: resourceCCENodeV3Create,
 		ReadContext:   resourceCCENodeV3Read,
 		UpdateContext: resourceCCENodeV3Update,
@@ -34,6 +35,10 @@ func ResourceCCENodeV3() *schema.Resource {
 			StateContext: resourceCCENodeV3Import,
 		},
 
+		CustomizeDiff: plancheck.CustomizeDiff(validateCCENodePlan),
+
+		SchemaVersion: 1,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
@@ -470,10 +475,74 @@ func ResourceCCENodeV3() *schema.Resource {
 				Type:       schema.TypeString,
 				Optional:   true,
 				ForceNew:   true,
-				Deprecated: "will be removed after v1.26.0",
+				Deprecated: "will be removed after v1.26.0 and has no replacement",
 			},
 		},
 	}
+
+	resource.StateUpgraders = []schema.StateUpgrader{
+		{
+			Type:    resourceCCEVolumeSchemaV0(resource.Schema).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceCCEVolumeStateUpgradeV0,
+			Version: 0,
+		},
+	}
+	return resource
+}
+
+// resourceCCEVolumeSchemaV0 returns the schema of version 0 based on the current schema of CCE node or node pool,
+// the extend_param of root_volume and data_volumes was a map before it's replaced by extend_params.
+func resourceCCEVolumeSchemaV0(current map[string]*schema.Schema) *schema.Resource {
+	result := make(map[string]*schema.Schema, len(current))
+	for k, v := range current {
+		result[k] = v
+	}
+
+	for _, key := range []string{"root_volume", "data_volumes"} {
+		volume := *current[key]
+		elemSchema := make(map[string]*schema.Schema)
+		for k, v := range volume.Elem.(*schema.Resource).Schema {
+			elemSchema[k] = v
+		}
+		elemSchema["extend_param"] = &schema.Schema{
+			Type:     schema.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		}
+		volume.Elem = &schema.Resource{Schema: elemSchema}
+		result[key] = &volume
+	}
+	return &schema.Resource{Schema: result}
+}
+
+// resourceCCEVolumeStateUpgradeV0 moves the extend_param maps of root_volume and data_volumes into extend_params,
+// the state of version 0 can not be decoded as the type of extend_param has been changed to string.
+func resourceCCEVolumeStateUpgradeV0(_ context.Context, rawState map[string]interface{},
+	_ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for _, key := range []string{"root_volume", "data_volumes"} {
+		volumes, _ := rawState[key].([]interface{})
+		for _, v := range volumes {
+			volume, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			params, ok := volume["extend_param"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if current, ok := volume["extend_params"].(map[string]interface{}); !ok || len(current) == 0 {
+				volume["extend_params"] = params
+			}
+			volume["extend_param"] = ""
+		}
+	}
+	return rawState, nil
 }
 
 func resourceCCENodeAnnotationsV2(d *schema.ResourceData) map[string]string {
@@ -492,8 +561,8 @@ func resourceCCENodeK8sTags(d *schema.ResourceData) map[string]string {
 	return m
 }
 
-func resourceCCENodeTags(d *schema.ResourceData) []tags.ResourceTag {
-	tagRaw := d.Get("tags").(map[string]interface{})
+func resourceCCENodeTags(d *schema.ResourceData, conf *config.Config) []tags.ResourceTag {
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), conf.TagsConfig)
 	return utils.ExpandResourceTags(tagRaw)
 }
 
@@ -766,7 +835,7 @@ func resourceCCENodeV3Create(ctx context.Context, d *schema.ResourceData, meta i
 			ExtendParam: resourceCCEExtendParam(d),
 			Taints:      resourceCCETaint(d),
 			K8sTags:     resourceCCENodeK8sTags(d),
-			UserTags:    resourceCCENodeTags(d),
+			UserTags:    resourceCCENodeTags(d, config),
 		},
 	}
 
@@ -902,7 +971,7 @@ func resourceCCENodeV3Read(_ context.Context, d *schema.ResourceData, meta inter
 	}
 
 	if resourceTags, err := tags.Get(computeClient, "cloudservers", serverId).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		mErr = multierror.Append(mErr, d.Set("tags", tagmap))
 	} else {
 		logp.Printf("[WARN] Error fetching tags of CCE Node (%s): %s", serverId, err)
@@ -940,7 +1009,7 @@ func resourceCCENodeV3Update(ctx context.Context, d *schema.ResourceData, meta i
 		}
 
 		serverId := d.Get("server_id").(string)
-		tagErr := utils.UpdateResourceTags(computeClient, d, "cloudservers", serverId)
+		tagErr := utils.UpdateResourceTags(computeClient, d, "cloudservers", serverId, config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of cce node %s: %s", d.Id(), tagErr)
 		}
@@ -1217,3 +1286,28 @@ func resourceCCENodeV3Import(_ context.Context, d *schema.ResourceData, meta int
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// validateCCENodePlan validates the flavor, availability zone and quotas of the node during the plan,
+// it's only called when enable_plan_validation is true.
+func validateCCENodePlan(_ context.Context, d *schema.ResourceDiff, conf *config.Config) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	region := plancheck.GetRegion(d, conf)
+	flavor := plancheck.GetKnownString(d, "flavor_id")
+	az := plancheck.GetKnownString(d, "availability_zone")
+
+	if err := plancheck.CheckComputeAvailabilityZone(conf, region, az); err != nil {
+		return fmtp.Errorf("Error validating availability_zone: %s", err)
+	}
+	if err := plancheck.CheckComputeFlavor(conf, region, flavor, az); err != nil {
+		return fmtp.Errorf("Error validating flavor_id: %s", err)
+	}
+	if flavor != "" {
+		if err := plancheck.CheckComputeQuota(conf, region, flavor, 1); err != nil {
+			return fmtp.Errorf("Error validating the quotas: %s", err)
+		}
+	}
+	return nil
+}
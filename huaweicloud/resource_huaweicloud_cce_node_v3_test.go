@@ -1,7 +1,9 @@
 package huaweicloud
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -15,6 +17,64 @@ import (
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 )
 
+func TestResourceCCEVolumeStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name": "cce-node",
+		"root_volume": []interface{}{
+			map[string]interface{}{
+				"size":         float64(40),
+				"volumetype":   "SSD",
+				"extend_param": map[string]interface{}{"resourceSpecCode": "SSD"},
+			},
+		},
+		"data_volumes": []interface{}{
+			map[string]interface{}{
+				"size":          float64(100),
+				"volumetype":    "SSD",
+				"extend_param":  map[string]interface{}{"useType": "docker"},
+				"extend_params": map[string]interface{}{"useType": "kubernetes"},
+			},
+			map[string]interface{}{
+				"size":         float64(100),
+				"volumetype":   "SAS",
+				"extend_param": "",
+			},
+		},
+	}
+	expected := map[string]interface{}{
+		"name": "cce-node",
+		"root_volume": []interface{}{
+			map[string]interface{}{
+				"size":          float64(40),
+				"volumetype":    "SSD",
+				"extend_param":  "",
+				"extend_params": map[string]interface{}{"resourceSpecCode": "SSD"},
+			},
+		},
+		"data_volumes": []interface{}{
+			map[string]interface{}{
+				"size":          float64(100),
+				"volumetype":    "SSD",
+				"extend_param":  "",
+				"extend_params": map[string]interface{}{"useType": "kubernetes"},
+			},
+			map[string]interface{}{
+				"size":         float64(100),
+				"volumetype":   "SAS",
+				"extend_param": "",
+			},
+		},
+	}
+
+	actual, err := resourceCCEVolumeStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("error upgrading the state: %s", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
+
 func TestAccCCENodeV3_basic(t *testing.T) {
 	var node nodes.Nodes
 
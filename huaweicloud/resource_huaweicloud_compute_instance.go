@@ -2,7 +2,9 @@ package huaweicloud
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -24,9 +26,13 @@ import (
 	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
 	"github.com/chnsz/golangsdk/openstack/networking/v2/ports"
 	"github.com/chnsz/golangsdk/openstack/networking/v3/security/groups"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/plancheck"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/waiter"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
@@ -45,15 +51,17 @@ var (
 
 func ResourceComputeInstanceV2() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceComputeInstanceV2Create,
-		Read:   resourceComputeInstanceV2Read,
-		Update: resourceComputeInstanceV2Update,
-		Delete: resourceComputeInstanceV2Delete,
+		Create:      resourceComputeInstanceV2Create,
+		ReadContext: resourceComputeInstanceV2ReadContext,
+		Update:      resourceComputeInstanceV2Update,
+		Delete:      resourceComputeInstanceV2Delete,
 
 		Importer: &schema.ResourceImporter{
 			State: resourceComputeInstanceV2ImportState,
 		},
 
+		CustomizeDiff: resourceComputeInstanceCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -80,17 +88,23 @@ func ResourceComputeInstanceV2() *schema.Resource {
 			"image_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Computed:    true,
 				DefaultFunc: schema.EnvDefaultFunc("HW_IMAGE_ID", nil),
 			},
 			"image_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Computed:    true,
 				DefaultFunc: schema.EnvDefaultFunc("HW_IMAGE_NAME", nil),
 			},
+			"image_update_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "recreate",
+				ValidateFunc: validation.StringInSlice([]string{
+					"recreate", "rebuild",
+				}, false),
+			},
 			"flavor_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -111,7 +125,6 @@ func ResourceComputeInstanceV2() *schema.Resource {
 			"key_pair": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"security_groups": {
 				Type:          schema.TypeSet,
@@ -261,7 +274,6 @@ func ResourceComputeInstanceV2() *schema.Resource {
 			"user_data": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				// just stash the hash for state & diff comparisons
 				StateFunc: utils.HashAndHexEncode,
 			},
@@ -273,7 +285,6 @@ func ResourceComputeInstanceV2() *schema.Resource {
 			"enterprise_project_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
-				ForceNew:      true,
 				Computed:      true,
 				ConflictsWith: novaConflicts,
 			},
@@ -284,10 +295,37 @@ func ResourceComputeInstanceV2() *schema.Resource {
 			},
 
 			// charge info: charging_mode, period_unit, period, auto_renew
-			"charging_mode": schemeChargingMode(novaConflicts),
-			"period_unit":   schemaPeriodUnit(novaConflicts),
-			"period":        schemaPeriod(novaConflicts),
-			"auto_renew":    schemaAutoRenew(novaConflicts),
+			"charging_mode": common.SchemaChargingModeWithSpot(novaConflicts),
+			"period_unit":   common.SchemaPeriodUnitUpdatable(novaConflicts),
+			"period":        common.SchemaPeriodUpdatable(novaConflicts),
+			"auto_renew":    common.SchemaAutoRenewUpdatable(novaConflicts),
+			"expire_time":   common.SchemaExpireTime(),
+
+			// spot info: spot_price_limit, spot_duration, spot_duration_count
+			"spot_price_limit": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+(\.\d+)?$`),
+					"the price must be a positive number"),
+			},
+			"spot_duration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(1, 6),
+			},
+			"spot_duration_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"spot_duration"},
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"spot_interruption_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 
 			"user_id": { // required if in prePaid charging mode with key_pair.
 				Type:     schema.TypeString,
@@ -534,7 +572,17 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 			createOpts.SchedulerHints = &schedulerHints
 		}
 
-		logp.Printf("[DEBUG] ECS Create Options: %#v", createOpts)
+		var createBuilder cloudservers.CreateOptsBuilder = createOpts
+		if d.Get("charging_mode") == "spot" {
+			createBuilder = computeSpotCreateOptsExt{
+				CreateOptsBuilder: createOpts,
+				SpotPrice:         d.Get("spot_price_limit").(string),
+				DurationHours:     d.Get("spot_duration").(int),
+				DurationCount:     d.Get("spot_duration_count").(int),
+			}
+		}
+
+		logp.Printf("[DEBUG] ECS Create Options: %#v", createBuilder)
 		// Add password here so it wouldn't go in the above log entry
 		createOpts.AdminPass = d.Get("admin_pass").(string)
 
@@ -548,15 +596,15 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 			job_id = n.JobID
 		} else {
 			// postPaid.
-			n, err := cloudservers.Create(ecsV11Client, createOpts).ExtractJobResponse()
+			n, err := cloudservers.Create(ecsV11Client, createBuilder).ExtractJobResponse()
 			if err != nil {
 				return fmtp.Errorf("Error creating HuaweiCloud server: %s", err)
 			}
 			job_id = n.JobID
 		}
 
-		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutCreate)/time.Second), job_id); err != nil {
-			return err
+		if _, err := waiter.WaitForJob(context.TODO(), ecsClient, job_id, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return fmtp.Errorf("Error waiting for instance to be created: %s", err)
 		}
 
 		entity, err := cloudservers.GetJobEntity(ecsClient, job_id, "server_id")
@@ -651,8 +699,7 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 	}
 
 	// Set tags
-	if hasFilledOpt(d, "tags") {
-		tagRaw := d.Get("tags").(map[string]interface{})
+	if tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig); len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		tagErr := tags.Create(ecsClient, "cloudservers", d.Id(), taglist).ExtractErr()
 		if tagErr != nil {
@@ -695,6 +742,29 @@ func resourceComputeInstanceV2Create(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
+// resourceComputeInstanceV2ReadContext removes the reclaimed spot instance from the state with a warning,
+// as the spot instances are released by ECS when the market price exceeds the limit or the spot block ends.
+func resourceComputeInstanceV2ReadContext(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+	isSpot := d.Get("charging_mode").(string) == "spot"
+	if err := resourceComputeInstanceV2Read(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if isSpot && d.Id() == "" {
+		logp.Printf("[WARN] the spot instance (%s) has been reclaimed, remove it from the state", id)
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "The spot instance has been reclaimed",
+				Detail: fmt.Sprintf("The spot instance (%s) is released by ECS and removed from the state, "+
+					"it will be created again in the next apply.", id),
+			},
+		}
+	}
+	return nil
+}
+
 func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*config.Config)
 	ecsClient, err := config.ComputeV1Client(GetRegion(d, config))
@@ -731,6 +801,12 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 		d.Set("charging_mode", "postPaid")
 	} else if chageMode == "1" {
 		d.Set("charging_mode", "prePaid")
+	} else if chageMode == "2" {
+		d.Set("charging_mode", "spot")
+	}
+	d.Set("spot_interruption_state", getSpotInterruptionState(server))
+	if err := common.SetPrePaidExpireTime(d, config, d.Id()); err != nil {
+		return fmtp.Errorf("Error setting expire_time of compute instance (%s): %s", d.Id(), err)
 	}
 
 	flavorInfo := server.Flavor
@@ -848,7 +924,7 @@ func resourceComputeInstanceV2Read(d *schema.ResourceData, meta interface{}) err
 
 	// Set instance tags
 	if resourceTags, err := tags.Get(ecsClient, "cloudservers", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for compute instance (%s): %s", d.Id(), err)
 		}
@@ -954,7 +1030,16 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
-	if d.HasChange("admin_pass") {
+	// the system disk is rebuilt with the new password, so the password doesn't need to be changed again
+	rebuilt := false
+	if d.Get("image_update_strategy").(string) == "rebuild" && d.HasChanges(computeInstanceRebuildKeys...) {
+		if err := rebuildComputeInstance(d, config); err != nil {
+			return err
+		}
+		rebuilt = true
+	}
+
+	if d.HasChange("admin_pass") && !rebuilt {
 		if newPwd, ok := d.Get("admin_pass").(string); ok {
 			err := servers.ChangeAdminPassword(computeClient, d.Id(), newPwd).ExtractErr()
 			if err != nil {
@@ -990,7 +1075,7 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 			return fmtp.Errorf("Error resizing HuaweiCloud server: %s", err)
 		}
 
-		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutUpdate)/time.Second), job.JobID); err != nil {
+		if _, err := waiter.WaitForJob(context.TODO(), ecsClient, job.JobID, d.Timeout(schema.TimeoutUpdate)); err != nil {
 			return fmtp.Errorf("Error waiting for instance (%s) to be resized: %s", d.Id(), err)
 		}
 	}
@@ -1013,12 +1098,32 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 			return fmtp.Errorf("Error creating HuaweiCloud compute v1 client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", d.Id())
+		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of instance:%s, err:%s", d.Id(), err)
 		}
 	}
 
+	if d.HasChanges("charging_mode", "period_unit", "period", "auto_renew") {
+		changeToPrePaid := func(opts common.ChangeToPrePaidOpts) (string, error) {
+			return changeComputeInstanceToPrePaid(ecsClient, d.Id(), opts)
+		}
+		if err := common.UpdateChargeInfo(context.TODO(), d, config, d.Id(), changeToPrePaid); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		migrateOpts := common.MigrateResourceOpts{
+			ResourceId:   d.Id(),
+			ResourceType: "ecs",
+			ProjectId:    ecsClient.ProjectID,
+		}
+		if err := common.MigrateEnterpriseProject(context.TODO(), d, config, migrateOpts); err != nil {
+			return err
+		}
+	}
+
 	if d.HasChange("system_disk_size") {
 		extendOpts := volumeactions.ExtendSizeOpts{
 			NewSize: d.Get("system_disk_size").(int),
@@ -1037,16 +1142,18 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 			return fmtp.Errorf("Error extending huaweicloud_compute_instance system disk %s size: %s", systemDiskID, err)
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"extending"},
-			Target:     []string{"available", "in-use"},
-			Refresh:    VolumeV2StateRefreshFunc(blockStorageClient, systemDiskID),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+		stateConf := &waiter.Conf{
+			Description:  fmt.Sprintf("system disk (%s) to be extended", systemDiskID),
+			Pending:      []string{"extending"},
+			Target:       []string{"available", "in-use"},
+			Failed:       []string{"error", "error_extending"},
+			Refresh:      VolumeV2StateRefreshFunc(blockStorageClient, systemDiskID),
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+			Delay:        10 * time.Second,
+			PollInterval: 3 * time.Second,
 		}
 
-		_, err = stateConf.WaitForState()
+		_, err = stateConf.WaitForState(context.TODO())
 		if err != nil {
 			return fmtp.Errorf(
 				"Error waiting for huaweicloud_compute_instance system disk %s to become ready: %s", systemDiskID, err)
@@ -1064,6 +1171,171 @@ func resourceComputeInstanceV2Update(d *schema.ResourceData, meta interface{}) e
 	return resourceComputeInstanceV2Read(d, meta)
 }
 
+// changeComputeInstanceToPrePaid converts the postPaid instance and its data disks to prePaid,
+// and returns the order ID.
+func changeComputeInstanceToPrePaid(client *golangsdk.ServiceClient, id string,
+	opts common.ChangeToPrePaidOpts) (string, error) {
+	changeOpts := map[string]interface{}{
+		"charging_mode": "prePaid",
+		"server_ids":    []string{id},
+		"prepaid_options": map[string]interface{}{
+			"include_data_disks_for_change": true,
+			"period_type":                   opts.PeriodUnit,
+			"period_num":                    opts.Period,
+			"is_auto_renew":                 opts.AutoRenew,
+			"is_auto_pay":                   true,
+		},
+	}
+	logp.Printf("[DEBUG] Change compute instance (%s) to prePaid options: %#v", id, changeOpts)
+
+	var r struct {
+		OrderID string `json:"order_id"`
+	}
+	_, err := client.Post(client.ServiceURL("cloudservers", "actions", "change-charge-mode"), changeOpts, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200}})
+	return r.OrderID, err
+}
+
+// rebuildComputeInstance reinstalls the OS of the instance if the image is not changed, otherwise changes the OS
+// to the new image. Only the system disk is rebuilt, the data disks and the NICs are kept.
+func rebuildComputeInstance(d *schema.ResourceData, conf *config.Config) error {
+	region := GetRegion(d, conf)
+	ecsClient, err := conf.ComputeV1Client(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud ecs client: %s", err)
+	}
+	ecsV2Client, err := conf.ComputeV20Client(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud ecs v2 client: %s", err)
+	}
+
+	var imageID string
+	if d.HasChanges("image_id", "image_name") {
+		imsClient, err := conf.ImageV2Client(region)
+		if err != nil {
+			return fmtp.Errorf("Error creating HuaweiCloud image client: %s", err)
+		}
+		imageID, err = getImageIDFromConfig(imsClient, d)
+		if err != nil {
+			return err
+		}
+		if oldImageID, _ := d.GetChange("image_id"); oldImageID.(string) == imageID {
+			imageID = ""
+		}
+	}
+
+	opts := map[string]interface{}{
+		"mode": "withStopServer",
+	}
+	if keyName := d.Get("key_pair").(string); keyName != "" {
+		opts["keyname"] = keyName
+		if userID := getOpSvcUserID(d, conf); userID != "" {
+			opts["userid"] = userID
+		}
+	}
+	if userData := getComputeInstanceUserData(d); userData != "" {
+		opts["metadata"] = map[string]interface{}{
+			"user_data": utils.TryBase64EncodeToString(userData),
+		}
+	}
+	logp.Printf("[DEBUG] Rebuild compute instance (%s) with image (%s) options: %#v", d.Id(), imageID, opts)
+	// Add password here so it wouldn't go in the above log entry
+	if adminPass := d.Get("admin_pass").(string); adminPass != "" {
+		opts["adminpass"] = adminPass
+	}
+
+	jobID, err := changeComputeInstanceOS(ecsV2Client, d.Id(), imageID, opts)
+	if err != nil {
+		return fmtp.Errorf("Error rebuilding HuaweiCloud server (%s): %s", d.Id(), err)
+	}
+	if _, err := waiter.WaitForJob(context.TODO(), ecsClient, jobID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmtp.Errorf("Error waiting for instance (%s) to be rebuilt: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// changeComputeInstanceOS calls the reinstallos API if the image ID is empty, otherwise calls the changeos API
+// with the image, and returns the job ID.
+func changeComputeInstanceOS(client *golangsdk.ServiceClient, id, imageID string,
+	opts map[string]interface{}) (string, error) {
+	action, key := "reinstallos", "os-reinstall"
+	if imageID != "" {
+		action, key = "changeos", "os-change"
+		opts["imageid"] = imageID
+	}
+
+	var r struct {
+		JobID string `json:"job_id"`
+	}
+	_, err := client.Post(client.ServiceURL("cloudservers", id, action), map[string]interface{}{key: opts}, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200}})
+	return r.JobID, err
+}
+
+// getComputeInstanceUserData returns the user data in the configuration, as only the hash of it is saved in the state
+func getComputeInstanceUserData(d *schema.ResourceData) string {
+	if d.HasChange("user_data") {
+		return d.Get("user_data").(string)
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return ""
+	}
+	if v := rawConfig.GetAttr("user_data"); v.IsKnown() && !v.IsNull() {
+		return v.AsString()
+	}
+	return ""
+}
+
+// computeSpotCreateOptsExt adds the spot options to the extendparam of the creation request,
+// they're not supported by cloudservers.ServerExtendParam.
+type computeSpotCreateOptsExt struct {
+	cloudservers.CreateOptsBuilder
+	SpotPrice     string
+	DurationHours int
+	DurationCount int
+}
+
+func (opts computeSpotCreateOptsExt) ToServerCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOptsBuilder.ToServerCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	server := b["server"].(map[string]interface{})
+	extendParam, ok := server["extendparam"].(map[string]interface{})
+	if !ok {
+		extendParam = make(map[string]interface{})
+		server["extendparam"] = extendParam
+	}
+	extendParam["chargingMode"] = "postPaid"
+	extendParam["marketType"] = "spot"
+	if opts.SpotPrice != "" {
+		extendParam["spotPrice"] = opts.SpotPrice
+	}
+	if opts.DurationHours > 0 {
+		extendParam["spotDurationHours"] = opts.DurationHours
+		extendParam["interruptionPolicy"] = "immediate"
+	}
+	if opts.DurationCount > 0 {
+		extendParam["spotDurationCount"] = opts.DurationCount
+	}
+	return b, nil
+}
+
+// getSpotInterruptionState returns reclaiming if the spot instance is being released by ECS, otherwise returns
+// normal. An empty string is returned for the instances which are not in spot pricing.
+func getSpotInterruptionState(server *cloudservers.CloudServer) string {
+	if server.Metadata.ChargingMode != "2" {
+		return ""
+	}
+	if server.TaskState == "deleting" || server.Status == "DELETED" {
+		return "reclaiming"
+	}
+	return "normal"
+}
+
 func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*config.Config)
 	ecsClient, err := config.ComputeV1Client(GetRegion(d, config))
@@ -1108,8 +1380,8 @@ func resourceComputeInstanceV2Delete(d *schema.ResourceData, meta interface{}) e
 			return fmtp.Errorf("Error deleting HuaweiCloud server: %s", err)
 		}
 
-		if err := cloudservers.WaitForJobSuccess(ecsClient, int(d.Timeout(schema.TimeoutCreate)/time.Second), n.JobID); err != nil {
-			return err
+		if _, err := waiter.WaitForJob(context.TODO(), ecsClient, n.JobID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return fmtp.Errorf("Error waiting for instance (%s) to be deleted: %s", d.Id(), err)
 		}
 	}
 
@@ -1158,6 +1430,9 @@ func resourceComputeInstanceV2ImportState(d *schema.ResourceData, meta interface
 
 	logp.Printf("[DEBUG] flatten Instance Networks: %#v", networks)
 	d.Set("network", networks)
+	// set the default value to avoid the changes in the plan after importing
+	d.Set("stop_before_destroy", false)
+	d.Set("image_update_strategy", "recreate")
 
 	return []*schema.ResourceData{d}, nil
 }
@@ -1217,6 +1492,14 @@ func getOpSvcUserID(d *schema.ResourceData, config *config.Config) string {
 }
 
 func validateComputeInstanceConfig(d *schema.ResourceData, config *config.Config) error {
+	if d.Get("charging_mode").(string) != "spot" {
+		for _, key := range []string{"spot_price_limit", "spot_duration", "spot_duration_count"} {
+			if _, ok := d.GetOk(key); ok {
+				return fmtp.Errorf("%s can only be specified when charging_mode is set to spot", key)
+			}
+		}
+	}
+
 	_, hasSSH := d.GetOk("key_pair")
 	if d.Get("charging_mode").(string) == "prePaid" && hasSSH {
 		if getOpSvcUserID(d, config) == "" {
@@ -1324,6 +1607,87 @@ func resourceInstanceSchedulerHintsV2(d *schema.ResourceData, schedulerHintsRaw
 	return schedulerHints
 }
 
+// computeInstanceRebuildKeys are the arguments which are applied by rebuilding the system disk, the instance is
+// recreated when they're changed unless image_update_strategy is rebuild.
+var computeInstanceRebuildKeys = []string{"image_id", "image_name", "key_pair", "user_data"}
+
+func resourceComputeInstanceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		// the spot instances can not be converted to or from the other charging modes
+		if oldMode, newMode := d.GetChange("charging_mode"); d.HasChange("charging_mode") &&
+			(oldMode.(string) == "spot" || newMode.(string) == "spot") {
+			if err := d.ForceNew("charging_mode"); err != nil {
+				return err
+			}
+		}
+
+		if d.Get("image_update_strategy").(string) != "rebuild" {
+			for _, key := range computeInstanceRebuildKeys {
+				if !d.HasChange(key) {
+					continue
+				}
+				if err := d.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		} else if d.HasChange("image_name") && !d.HasChange("image_id") {
+			// the image ID is known after the image name is resolved
+			if err := d.SetNewComputed("image_id"); err != nil {
+				return err
+			}
+		} else if d.HasChange("image_id") && !d.HasChange("image_name") {
+			if err := d.SetNewComputed("image_name"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return plancheck.CustomizeDiff(validateComputeInstancePlan)(ctx, d, meta)
+}
+
+// validateComputeInstancePlan validates the flavor, availability zone, image and quotas during the plan,
+// it's only called when enable_plan_validation is true.
+func validateComputeInstancePlan(_ context.Context, d *schema.ResourceDiff, conf *config.Config) error {
+	region := plancheck.GetRegion(d, conf)
+	az := plancheck.GetKnownString(d, "availability_zone")
+
+	if plancheck.ShouldValidate(d, "flavor_id", "flavor_name", "availability_zone") {
+		flavor := plancheck.GetKnownString(d, "flavor_id")
+		if flavor == "" || (d.HasChange("flavor_name") && !d.HasChange("flavor_id")) {
+			flavor = plancheck.GetKnownString(d, "flavor_name")
+		}
+
+		if err := plancheck.CheckComputeAvailabilityZone(conf, region, az); err != nil {
+			return fmtp.Errorf("Error validating availability_zone: %s", err)
+		}
+		if err := plancheck.CheckComputeFlavor(conf, region, flavor, az); err != nil {
+			return fmtp.Errorf("Error validating the flavor: %s", err)
+		}
+		// the quotas are only checked for the new instances
+		if d.Id() == "" && flavor != "" {
+			if err := plancheck.CheckComputeQuota(conf, region, flavor, 1); err != nil {
+				return fmtp.Errorf("Error validating the quotas: %s", err)
+			}
+		}
+	}
+
+	if plancheck.ShouldValidate(d, "image_id", "image_name") {
+		imageID := plancheck.GetKnownString(d, "image_id")
+		imageName := plancheck.GetKnownString(d, "image_name")
+		// only the changed one is validated for the existing instances, the other one is out of date
+		if d.Id() != "" && !d.HasChange("image_id") {
+			imageID = ""
+		}
+		if d.Id() != "" && !d.HasChange("image_name") {
+			imageName = ""
+		}
+		if err := plancheck.CheckImage(conf, region, imageID, imageName); err != nil {
+			return fmtp.Errorf("Error validating the image: %s", err)
+		}
+	}
+	return nil
+}
+
 func getImage(client *golangsdk.ServiceClient, id, name string) (*cloudimages.Image, error) {
 	listOpts := &cloudimages.ListOpts{
 		ID:                  id,
@@ -1517,16 +1881,18 @@ func checkBlockDeviceConfig(d *schema.ResourceData) error {
 }
 
 func waitForServerTargetState(client *golangsdk.ServiceClient, ID string, pending, target []string, timeout time.Duration) error {
-	stateConf := &resource.StateChangeConf{
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("instance (%s) to become %s", ID, strings.Join(target, " or ")),
 		Pending:      pending,
 		Target:       target,
+		Failed:       []string{"ERROR"},
 		Refresh:      ServerV2StateRefreshFunc(client, ID),
 		Timeout:      timeout,
 		Delay:        5 * time.Second,
 		PollInterval: 5 * time.Second,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForState(context.TODO())
 	if err != nil {
 		return fmtp.Errorf("Error waiting for instance (%s) to become target state (%v): %s", ID, target, err)
 	}
@@ -1561,7 +1927,7 @@ func doPowerAction(client *golangsdk.ServiceClient, d *schema.ResourceData, acti
 	}
 	// The time of the power on/off and reboot is usually between 15 and 35 seconds.
 	timeout := 3 * time.Minute
-	if err := cloudservers.WaitForJobSuccess(client, int(timeout/time.Second), jobResp.JobID); err != nil {
+	if _, err := waiter.WaitForJob(context.TODO(), client, jobResp.JobID, timeout); err != nil {
 		return err
 	}
 	return nil
@@ -1,16 +1,22 @@
 package huaweicloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/chnsz/golangsdk/openstack/common/tags"
 	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/jobs"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
 )
 
@@ -96,6 +102,31 @@ func TestAccComputeV2Instance_prePaid(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Instance_spot(t *testing.T) {
+	var instance cloudservers.CloudServer
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_compute_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_spot(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "charging_mode", "spot"),
+					resource.TestCheckResourceAttr(resourceName, "spot_duration", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spot_interruption_state", "normal"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccComputeV2Instance_tags(t *testing.T) {
 	var instance cloudservers.CloudServer
 
@@ -211,6 +242,245 @@ func TestAccComputeV2Instance_powerAction(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Instance_rebuild(t *testing.T) {
+	var instance cloudservers.CloudServer
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_compute_instance.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeV2Instance_rebuild(rName, "Ubuntu 18.04 server 64bit"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "image_name", "Ubuntu 18.04 server 64bit"),
+					resource.TestCheckResourceAttr(resourceName, "volume_attached.#", "2"),
+				),
+			},
+			{
+				Config: testAccComputeV2Instance_rebuild(rName, "CentOS 7.6 64bit"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceRebuilt(resourceName, &instance),
+					resource.TestCheckResourceAttr(resourceName, "image_name", "CentOS 7.6 64bit"),
+					resource.TestCheckResourceAttr(resourceName, "volume_attached.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceComputeInstanceCustomizeDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "server-id",
+		Attributes: map[string]string{
+			"id":                    "server-id",
+			"name":                  "ecs-test",
+			"image_id":              "image-a",
+			"image_name":            "Image A",
+			"image_update_strategy": "recreate",
+			"flavor_id":             "s6.small.1",
+			"flavor_name":           "s6.small.1",
+			"network.#":             "1",
+			"network.0.uuid":        "subnet-id",
+		},
+	}
+	network := []interface{}{map[string]interface{}{"uuid": "subnet-id"}}
+
+	for strategy, requiresNew := range map[string]bool{"recreate": true, "rebuild": false} {
+		state.Attributes["image_update_strategy"] = strategy
+		diff, err := ResourceComputeInstanceV2().Diff(context.Background(), state,
+			terraform.NewResourceConfigRaw(map[string]interface{}{
+				"name":                  "ecs-test",
+				"image_id":              "image-b",
+				"flavor_id":             "s6.small.1",
+				"image_update_strategy": strategy,
+				"network":               network,
+			}), &config.Config{})
+		if err != nil {
+			t.Fatalf("Error computing the diff with %s strategy: %s", strategy, err)
+		}
+		if diff.RequiresNew() != requiresNew {
+			t.Errorf("expected RequiresNew to be %t with %s strategy, but got %#v", requiresNew, strategy, diff)
+		}
+		if !requiresNew && !diff.Attributes["image_name"].NewComputed {
+			t.Errorf("expected the image name to be computed with %s strategy, but got %#v", strategy, diff)
+		}
+	}
+}
+
+func TestComputeSpotCreateOptsExt(t *testing.T) {
+	opts := computeSpotCreateOptsExt{
+		CreateOptsBuilder: cloudservers.CreateOpts{
+			ImageRef:   "fake-image-id",
+			FlavorRef:  "s6.small.1",
+			Name:       "ecs-test",
+			VpcId:      "vpc-id",
+			Nics:       []cloudservers.Nic{{SubnetId: "subnet-id"}},
+			RootVolume: cloudservers.RootVolume{VolumeType: "SSD"},
+		},
+		SpotPrice:     "0.5",
+		DurationHours: 2,
+		DurationCount: 3,
+	}
+	b, err := opts.ToServerCreateMap()
+	if err != nil {
+		t.Fatalf("Error building the creation request: %s", err)
+	}
+
+	extendParam, ok := b["server"].(map[string]interface{})["extendparam"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected the extendparam in the creation request, but got %#v", b)
+	}
+	expected := map[string]interface{}{
+		"chargingMode":       "postPaid",
+		"marketType":         "spot",
+		"spotPrice":          "0.5",
+		"spotDurationHours":  2,
+		"spotDurationCount":  3,
+		"interruptionPolicy": "immediate",
+	}
+	for k, v := range expected {
+		if extendParam[k] != v {
+			t.Errorf("expected %s to be %v, but got %v", k, v, extendParam[k])
+		}
+	}
+}
+
+func TestResourceComputeInstanceV2ReadContext_spotReclaimed(t *testing.T) {
+	srv := fakecloud.NewServer()
+	defer srv.Close()
+
+	c, err := srv.Config()
+	if err != nil {
+		t.Fatalf("Error loading the config of the fake cloud: %s", err)
+	}
+
+	for mode, warned := range map[string]bool{"spot": true, "postPaid": false} {
+		d := ResourceComputeInstanceV2().TestResourceData()
+		d.SetId("reclaimed-server-id")
+		d.Set("charging_mode", mode)
+
+		diags := ResourceComputeInstanceV2().ReadContext(context.Background(), d, c)
+		if diags.HasError() {
+			t.Fatalf("Error reading the %s instance: %#v", mode, diags)
+		}
+		if d.Id() != "" {
+			t.Errorf("expected the %s instance to be removed from the state, but got ID %s", mode, d.Id())
+		}
+		if (len(diags) == 1 && diags[0].Severity == diag.Warning) != warned {
+			t.Errorf("expected the warning of the %s instance to be %t, but got %#v", mode, warned, diags)
+		}
+	}
+}
+
+func TestChangeComputeInstanceOS(t *testing.T) {
+	srv := fakecloud.NewServer()
+	defer srv.Close()
+	srv.AddImage("fake-image-new", "Fake Ubuntu 20.04 64bit")
+
+	c, err := srv.Config()
+	if err != nil {
+		t.Fatalf("Error loading the config of the fake cloud: %s", err)
+	}
+	vpcClient, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsClient, err := c.ComputeV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsV11Client, err := c.ComputeV11Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsV2Client, err := c.ComputeV20Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, err := vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-test", CIDR: "192.168.0.0/16"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the VPC: %s", err)
+	}
+	subnet, err := subnets.Create(vpcClient, subnets.CreateOpts{
+		Name:      "subnet-test",
+		CIDR:      "192.168.0.0/24",
+		GatewayIP: "192.168.0.1",
+		VPC_ID:    vpc.ID,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the subnet: %s", err)
+	}
+
+	waitJob := func(jobID string) *jobs.Job {
+		for i := 0; i < 5; i++ {
+			if job, err := jobs.Get(ecsClient, jobID); err == nil && job.Status == "SUCCESS" {
+				return job
+			}
+		}
+		t.Fatalf("The job %s is not completed", jobID)
+		return nil
+	}
+
+	job, err := cloudservers.Create(ecsV11Client, cloudservers.CreateOpts{
+		ImageRef:         "fake-image-id",
+		FlavorRef:        "s6.small.1",
+		Name:             "ecs-test",
+		VpcId:            vpc.ID,
+		Nics:             []cloudservers.Nic{{SubnetId: subnet.ID}},
+		RootVolume:       cloudservers.RootVolume{VolumeType: "SSD"},
+		DataVolumes:      []cloudservers.DataVolume{{VolumeType: "SSD", Size: 100}},
+		AvailabilityZone: "cn-north-4a",
+	}).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error creating the server: %s", err)
+	}
+	serverID := waitJob(job.JobID).Entities.SubJobs[0].Entities.ServerId
+	before, err := cloudservers.Get(ecsClient, serverID).Extract()
+	if err != nil {
+		t.Fatalf("Error getting the server: %s", err)
+	}
+
+	for _, imageID := range []string{"", "fake-image-new"} {
+		jobID, err := changeComputeInstanceOS(ecsV2Client, serverID, imageID, map[string]interface{}{
+			"adminpass": "Test@123",
+			"mode":      "withStopServer",
+		})
+		if err != nil {
+			t.Fatalf("Error rebuilding the server with image %q: %s", imageID, err)
+		}
+		waitJob(jobID)
+
+		server, err := cloudservers.Get(ecsClient, serverID).Extract()
+		if err != nil {
+			t.Fatalf("Error getting the server: %s", err)
+		}
+		expected := imageID
+		if expected == "" {
+			expected = before.Image.ID
+		}
+		if server.Image.ID != expected || server.Status != "ACTIVE" {
+			t.Errorf("expected the server to be rebuilt with %s, but got %#v", expected, server)
+		}
+		if len(server.VolumeAttached) != 2 || server.VolumeAttached[1].ID != before.VolumeAttached[1].ID {
+			t.Errorf("expected the data disk to be kept, but got %#v", server.VolumeAttached)
+		}
+		if fmt.Sprint(server.Addresses) != fmt.Sprint(before.Addresses) {
+			t.Errorf("expected the NICs to be kept, but got %#v", server.Addresses)
+		}
+	}
+
+	if n := srv.RequestCount("POST", "/v2/"+srv.ProjectID+"/cloudservers/"+serverID+"/changeos"); n != 1 {
+		t.Errorf("expected 1 changeos request, but got %d", n)
+	}
+}
+
 func testAccCheckComputeV2InstanceDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	computeClient, err := config.ComputeV1Client(HW_REGION_NAME)
@@ -338,6 +608,19 @@ data "huaweicloud_networking_secgroup" "test" {
 }
 `
 
+func testAccCheckComputeV2InstanceRebuilt(n string, instance *cloudservers.CloudServer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmtp.Errorf("Not found: %s", n)
+		}
+		if rs.Primary.ID != instance.ID {
+			return fmtp.Errorf("Instance is recreated: %s, the original one is %s", rs.Primary.ID, instance.ID)
+		}
+		return nil
+	}
+}
+
 func testAccComputeV2Instance_basic(rName string) string {
 	return fmt.Sprintf(`
 %s
@@ -404,6 +687,29 @@ resource "huaweicloud_compute_instance" "test" {
 `, testAccCompute_data, rName)
 }
 
+func testAccComputeV2Instance_spot(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_compute_instance" "test" {
+  name               = "%s"
+  image_id           = data.huaweicloud_images_image.test.id
+  flavor_id          = data.huaweicloud_compute_flavors.test.ids[0]
+  security_group_ids = [data.huaweicloud_networking_secgroup.test.id]
+  availability_zone  = data.huaweicloud_availability_zones.test.names[0]
+
+  network {
+    uuid = data.huaweicloud_vpc_subnet.test.id
+  }
+
+  charging_mode       = "spot"
+  spot_price_limit    = "0.5"
+  spot_duration       = 1
+  spot_duration_count = 1
+}
+`, testAccCompute_data, rName)
+}
+
 func testAccComputeV2Instance_tags(rName string) string {
 	return fmt.Sprintf(`
 %s
@@ -486,3 +792,34 @@ resource "huaweicloud_compute_instance" "test" {
 }
 `, testAccCompute_data, rName, powerAction)
 }
+
+func testAccComputeV2Instance_rebuild(rName, imageName string) string {
+	return fmt.Sprintf(`
+%s
+
+data "huaweicloud_images_image" "rebuild" {
+  name        = "%s"
+  most_recent = true
+}
+
+resource "huaweicloud_compute_instance" "test" {
+  name                  = "%s"
+  image_id              = data.huaweicloud_images_image.rebuild.id
+  image_update_strategy = "rebuild"
+  flavor_id             = data.huaweicloud_compute_flavors.test.ids[0]
+  security_group_ids    = [data.huaweicloud_networking_secgroup.test.id]
+  availability_zone     = data.huaweicloud_availability_zones.test.names[0]
+  admin_pass            = "Test@123"
+  user_data             = "#!/bin/bash\necho hello"
+
+  network {
+    uuid = data.huaweicloud_vpc_subnet.test.id
+  }
+
+  data_disks {
+    type = "SSD"
+    size = "10"
+  }
+}
+`, testAccCompute_data, imageName, rName)
+}
@@ -21,7 +21,7 @@ func resourceCSBSBackupPolicyV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		DeprecationMessage: "this is deprecated",
+		DeprecationMessage: "use huaweicloud_cbr_policy resource instead",
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -20,7 +20,7 @@ func resourceCSBSBackupV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		DeprecationMessage: "this is deprecated",
+		DeprecationMessage: "use huaweicloud_cbr_vault resource instead",
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
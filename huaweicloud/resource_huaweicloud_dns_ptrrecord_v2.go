@@ -158,7 +158,7 @@ func resourceDNSPtrRecordV2Read(d *schema.ResourceData, meta interface{}) error
 
 	// save tags
 	if resourceTags, err := tags.Get(dnsClient, "DNS-ptr_record", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for DNS ptr record (%s): %s", d.Id(), err)
 		}
@@ -212,7 +212,7 @@ func resourceDNSPtrRecordV2Update(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(dnsClient, d, "DNS-ptr_record", d.Id())
+	tagErr := utils.UpdateResourceTags(dnsClient, d, "DNS-ptr_record", d.Id(), config.TagsConfig)
 	if tagErr != nil {
 		return fmtp.Errorf("Error updating tags of DNS PTR record %s: %s", d.Id(), tagErr)
 	}
@@ -140,7 +140,7 @@ func resourceDNSRecordSetV2Create(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), meta.(*config.Config).TagsConfig)
 	if len(tagRaw) > 0 {
 		resourceType, err := utils.GetDNSRecordSetTagType(zoneType)
 		if err != nil {
@@ -193,7 +193,7 @@ func resourceDNSRecordSetV2Read(d *schema.ResourceData, meta interface{}) error
 		return fmtp.Errorf("Error getting resource type of DNS record set %s: %s", recordsetID, err)
 	}
 	if resourceTags, err := tags.Get(dnsClient, resourceType, recordsetID).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for DNS record set (%s): %s", recordsetID, err)
 		}
@@ -265,7 +265,7 @@ func resourceDNSRecordSetV2Update(d *schema.ResourceData, meta interface{}) erro
 		return fmtp.Errorf("Error getting resource type of DNS record set %s: %s", d.Id(), err)
 	}
 
-	tagErr := utils.UpdateResourceTags(dnsClient, d, resourceType, recordsetID)
+	tagErr := utils.UpdateResourceTags(dnsClient, d, resourceType, recordsetID, meta.(*config.Config).TagsConfig)
 	if tagErr != nil {
 		return fmtp.Errorf("Error updating tags of DNS record set %s: %s", d.Id(), tagErr)
 	}
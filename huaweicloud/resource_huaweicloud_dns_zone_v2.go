@@ -226,7 +226,7 @@ func resourceDNSZoneV2Create(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		resourceType, err := utils.GetDNSZoneTagType(zoneType)
 		if err != nil {
@@ -289,7 +289,7 @@ func resourceDNSZoneV2Read(d *schema.ResourceData, meta interface{}) error {
 	if resourceType, err := utils.GetDNSZoneTagType(zoneInfo.ZoneType); err == nil {
 		resourceTags, err := tags.Get(dnsClient, resourceType, d.Id()).Extract()
 		if err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 			d.Set("tags", tagmap)
 		} else {
 			logp.Printf("[WARN] Error fetching HuaweiCloud DNS zone tags: %s", err)
@@ -430,7 +430,7 @@ func resourceDNSZoneV2Update(d *schema.ResourceData, meta interface{}) error {
 		return fmtp.Errorf("Error getting resource type of DNS zone %s: %s", d.Id(), err)
 	}
 
-	tagErr := utils.UpdateResourceTags(dnsClient, d, resourceType, d.Id())
+	tagErr := utils.UpdateResourceTags(dnsClient, d, resourceType, d.Id(), config.TagsConfig)
 	if tagErr != nil {
 		return fmtp.Errorf("Error updating tags of DNS zone %s: %s", d.Id(), tagErr)
 	}
@@ -299,8 +299,8 @@ func resourceEcsInstanceV1Create(d *schema.ResourceData, meta interface{}) error
 	if instance_id != "" {
 		d.SetId(instance_id)
 
-		if hasFilledOpt(d, "tags") {
-			tagRaw := d.Get("tags").(map[string]interface{})
+		tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
+		if len(tagRaw) > 0 {
 			taglist := utils.ExpandResourceTags(tagRaw)
 			tagErr := tags.Create(computeV1Client, "cloudservers", instance_id, taglist).ExtractErr()
 			if tagErr != nil {
@@ -354,7 +354,7 @@ func resourceEcsInstanceV1Read(d *schema.ResourceData, meta interface{}) error {
 
 	// Set instance tags
 	if resourceTags, err := tags.Get(computeClient, "cloudservers", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for ECS instance (%s): %s", d.Id(), err)
 		}
@@ -480,7 +480,7 @@ func resourceEcsInstanceV1Update(d *schema.ResourceData, meta interface{}) error
 			return fmtp.Errorf("Error creating HuaweiCloud compute v1 client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", d.Id())
+		tagErr := utils.UpdateResourceTags(ecsClient, d, "cloudservers", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of instance:%s, err:%s", d.Id(), err)
 		}
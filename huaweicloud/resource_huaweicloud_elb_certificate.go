@@ -20,6 +20,10 @@ func ResourceCertificateV3() *schema.Resource {
 		Update: resourceCertificateV3Update,
 		Delete: resourceCertificateV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -18,6 +18,10 @@ func ResourceIpGroupV3() *schema.Resource {
 		Update: resourceIpGroupV3Update,
 		Delete: resourceIpGroupV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
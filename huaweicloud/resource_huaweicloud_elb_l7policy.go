@@ -20,6 +20,10 @@ func ResourceL7PolicyV3() *schema.Resource {
 		Update: resourceL7PolicyV3Update,
 		Delete: resourceL7PolicyV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -1,6 +1,7 @@
 package huaweicloud
 
 import (
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -21,6 +22,10 @@ func ResourceL7RuleV3() *schema.Resource {
 		Update: resourceL7RuleV3Update,
 		Delete: resourceL7RuleV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceL7RuleV3ImportState,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -229,3 +234,15 @@ func resourceElbV3RuleRefreshFunc(elbClient *golangsdk.ServiceClient,
 		return rule, rule.ProvisioningStatus, nil
 	}
 }
+
+func resourceL7RuleV3ImportState(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmtp.Errorf("Invalid format specified for L7 Rule. Format must be <l7policy_id>/<l7rule_id>")
+	}
+
+	d.SetId(parts[1])
+	d.Set("l7policy_id", parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -45,6 +45,12 @@ func TestAccElbV3L7Rule_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "value", "/images"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateIdWithAttributes(resourceName, "l7policy_id"),
+			},
 		},
 	})
 }
@@ -1,6 +1,7 @@
 package huaweicloud
 
 import (
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +19,10 @@ func ResourceMemberV3() *schema.Resource {
 		Update: resourceMemberV3Update,
 		Delete: resourceMemberV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceMemberV3ImportState,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -172,3 +177,15 @@ func resourceMemberV3Delete(d *schema.ResourceData, meta interface{}) error {
 	}
 	return nil
 }
+
+func resourceMemberV3ImportState(d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmtp.Errorf("Invalid format specified for member. Format must be <pool_id>/<member_id>")
+	}
+
+	d.SetId(parts[1])
+	d.Set("pool_id", parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
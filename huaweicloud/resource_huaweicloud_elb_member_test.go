@@ -36,6 +36,12 @@ func TestAccElbV3Member_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("huaweicloud_elb_member.member_2", "weight", "15"),
 				),
 			},
+			{
+				ResourceName:      "huaweicloud_elb_member.member_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateIdWithAttributes("huaweicloud_elb_member.member_1", "pool_id"),
+			},
 		},
 	})
 }
@@ -16,6 +16,10 @@ func ResourceMonitorV3() *schema.Resource {
 		Update: resourceMonitorV3Update,
 		Delete: resourceMonitorV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceMonitorV3ImportState,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -178,3 +182,23 @@ func resourceMonitorV3Delete(d *schema.ResourceData, meta interface{}) error {
 
 	return nil
 }
+
+func resourceMonitorV3ImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	lbClient, err := config.ElbV3Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud elb v3 client: %s", err)
+	}
+
+	monitor, err := monitors.Get(lbClient, d.Id()).Extract()
+	if err != nil {
+		return nil, CheckDeleted(d, err, "monitor")
+	}
+
+	logp.Printf("[DEBUG] Retrieved monitor %s during the import: %#v", d.Id(), monitor)
+	if len(monitor.Pools) > 0 {
+		d.Set("pool_id", monitor.Pools[0].ID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
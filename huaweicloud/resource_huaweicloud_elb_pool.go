@@ -21,6 +21,10 @@ func ResourcePoolV3() *schema.Resource {
 		Update: resourcePoolV3Update,
 		Delete: resourcePoolV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourcePoolV3ImportState,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -296,3 +300,26 @@ func resourceElbV3PoolRefreshFunc(elbClient *golangsdk.ServiceClient, poolID str
 		return pool, "ACTIVE", nil
 	}
 }
+
+func resourcePoolV3ImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	elbClient, err := config.ElbV3Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud elb v3 client: %s", err)
+	}
+
+	pool, err := pools.Get(elbClient, d.Id()).Extract()
+	if err != nil {
+		return nil, CheckDeleted(d, err, "pool")
+	}
+
+	logp.Printf("[DEBUG] Retrieved pool %s during the import: %#v", d.Id(), pool)
+	// the pool is associated with the listener or the loadbalancer, and the listener is preferred
+	if len(pool.Listeners) > 0 {
+		d.Set("listener_id", pool.Listeners[0].ID)
+	} else if len(pool.Loadbalancers) > 0 {
+		d.Set("loadbalancer_id", pool.Loadbalancers[0].ID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
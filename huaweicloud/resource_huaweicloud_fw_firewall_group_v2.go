@@ -63,7 +63,7 @@ func resourceFWFirewallGroupV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"ports": {
 				Type:     schema.TypeSet,
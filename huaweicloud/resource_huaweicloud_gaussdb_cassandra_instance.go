@@ -429,7 +429,7 @@ func resourceGeminiDBInstanceV3Create(d *schema.ResourceData, meta interface{})
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(client, "instances", d.Id(), taglist).ExtractErr(); tagErr != nil {
@@ -551,7 +551,7 @@ func resourceGeminiDBInstanceV3Read(d *schema.ResourceData, meta interface{}) er
 
 	//save geminidb tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for geminidb (%s): %s", d.Id(), err)
 		}
@@ -617,7 +617,7 @@ func resourceGeminiDBInstanceV3Update(d *schema.ResourceData, meta interface{})
 	}
 	//update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id())
+		tagErr := utils.UpdateResourceTags(client, d, "instances", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of GeminiDB %q: %s", d.Id(), tagErr)
 		}
@@ -1,13 +1,20 @@
 package huaweicloud
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/waiter"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/ims"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
 
@@ -23,7 +30,7 @@ func ResourceImsImage() *schema.Resource {
 		Update: resourceImsImageUpdate,
 		Delete: resourceImsImageDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceImsImageImportState,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -31,6 +38,8 @@ func ResourceImsImage() *schema.Resource {
 			Delete: schema.DefaultTimeout(3 * time.Minute),
 		},
 
+		CustomizeDiff: resourceImsImageCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -53,12 +62,44 @@ func ResourceImsImage() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
-			// instance_id is required for creating an image from an ECS
+			// image_type is system by default, whole and data_disk are valid for creating an image from an ECS or EVS
+			"image_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"system", "whole", "data_disk",
+				}, false),
+			},
+			// instance_id is required for creating a system or whole image from an ECS
 			"instance_id": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{"image_url"},
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"image_url"},
+			},
+			// vault_id is required for creating a whole image, it's the CBR vault which stores the ECS backup
+			"vault_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"image_url", "volume_id"},
+			},
+			// volume_id is required for creating a data disk image from an EVS volume
+			"volume_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instance_id", "image_url"},
+			},
+			// data_volume_ids are the data disks converted to images together with the system disk of the ECS
+			"data_volume_ids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"image_url", "vault_id", "volume_id"},
 			},
 			// image_url and min_disk are required for creating an image from an OBS
 			"image_url": {
@@ -130,14 +171,19 @@ func ResourceImsImage() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"data_image_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
-func resourceContainerImageTags(d *schema.ResourceData) []cloudimages.ImageTag {
+func resourceContainerImageTags(d *schema.ResourceData, tagsConfig *utils.TagsConfig) []cloudimages.ImageTag {
 	var tags []cloudimages.ImageTag
 
-	rawTags := d.Get("tags").(map[string]interface{})
+	rawTags := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), tagsConfig)
 	for key, val := range rawTags {
 		tagRequest := cloudimages.ImageTag{
 			Key:   key,
@@ -148,16 +194,136 @@ func resourceContainerImageTags(d *schema.ResourceData) []cloudimages.ImageTag {
 	return tags
 }
 
+// imageWholeCreateOpts is the request body of creating a whole image from an ECS and the CBR vault
+type imageWholeCreateOpts struct {
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description,omitempty"`
+	InstanceID          string                 `json:"instance_id"`
+	VaultID             string                 `json:"vault_id"`
+	MaxRam              int                    `json:"max_ram,omitempty"`
+	MinRam              int                    `json:"min_ram,omitempty"`
+	ImageTags           []cloudimages.ImageTag `json:"image_tags,omitempty"`
+	EnterpriseProjectID string                 `json:"enterprise_project_id,omitempty"`
+}
+
+// createWholeImage creates a whole image which contains the system and data disks of the ECS,
+// the client is the IMS v1 client.
+func createWholeImage(client *golangsdk.ServiceClient, opts imageWholeCreateOpts) (*cloudimages.JobResponse, error) {
+	var r cloudimages.JobResponse
+	_, err := client.Post(client.ServiceURL("cloudimages", "wholeimages", "action"), opts, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// resourceImsImageCustomizeDiff validates the image sources during the plan, the arguments which refer to the
+// resources not created yet are regarded as specified.
+func resourceImsImageCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	isSpecified := func(key string) bool {
+		if !d.NewValueKnown(key) {
+			return true
+		}
+		_, ok := d.GetOk(key)
+		return ok
+	}
+	hasInstance := isSpecified("instance_id")
+	hasURL := isSpecified("image_url")
+	hasVault := isSpecified("vault_id")
+	hasVolume := isSpecified("volume_id")
+
+	switch d.Get("image_type").(string) {
+	case "whole":
+		if !hasInstance || !hasVault {
+			return fmtp.Errorf("instance_id and vault_id must be specified when image_type is set to whole")
+		}
+		if _, ok := d.GetOk("data_volume_ids"); ok {
+			return fmtp.Errorf("data_volume_ids can not be specified when image_type is set to whole, " +
+				"the data disks are included in the whole image")
+		}
+	case "data_disk":
+		if !hasVolume {
+			return fmtp.Errorf("volume_id must be specified when image_type is set to data_disk")
+		}
+	default:
+		if !hasInstance && !hasURL {
+			return fmtp.Errorf("one of instance_id and image_url must be specified")
+		}
+		if hasVault {
+			return fmtp.Errorf("vault_id can only be specified when image_type is set to whole")
+		}
+		if hasVolume {
+			return fmtp.Errorf("volume_id can only be specified when image_type is set to data_disk")
+		}
+	}
+	return nil
+}
+
+// getImageJobResult returns the image and the data disk images created by the IMS job
+func getImageJobResult(job *waiter.Job) (string, []string) {
+	var imageIDs []string
+	if job.Entities.ImageID != "" {
+		imageIDs = append(imageIDs, job.Entities.ImageID)
+	}
+	for _, subJob := range job.Entities.SubJobsResult {
+		if id := subJob.Entities.ImageID; id != "" && id != job.Entities.ImageID {
+			imageIDs = append(imageIDs, id)
+		}
+	}
+
+	if len(imageIDs) == 0 {
+		return "", nil
+	}
+	return imageIDs[0], imageIDs[1:]
+}
+
 func resourceImsImageCreate(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*config.Config)
-	imsClient, err := config.ImageV2Client(GetRegion(d, config))
+	region := GetRegion(d, config)
+	imsClient, err := config.ImageV2Client(region)
 	if err != nil {
 		return fmtp.Errorf("Error creating HuaweiCloud image client: %s", err)
 	}
+	imsV1Client, err := config.ImageV1Client(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud image v1 client: %s", err)
+	}
 
 	v := new(cloudimages.JobResponse)
-	imageTags := resourceContainerImageTags(d)
-	if val, ok := d.GetOk("instance_id"); ok {
+	imageType := d.Get("image_type").(string)
+	imageTags := resourceContainerImageTags(d, config.TagsConfig)
+	if imageType == "whole" {
+		createOpts := imageWholeCreateOpts{
+			Name:                d.Get("name").(string),
+			Description:         d.Get("description").(string),
+			InstanceID:          d.Get("instance_id").(string),
+			VaultID:             d.Get("vault_id").(string),
+			MaxRam:              d.Get("max_ram").(int),
+			MinRam:              d.Get("min_ram").(int),
+			ImageTags:           imageTags,
+			EnterpriseProjectID: GetEnterpriseProjectID(d, config),
+		}
+		logp.Printf("[DEBUG] Create Options: %#v", createOpts)
+		v, err = createWholeImage(imsV1Client, createOpts)
+	} else if imageType == "data_disk" {
+		// the tags of the data disk image are set after it's created
+		createOpts := &cloudimages.CreateDataImageByServerOpts{
+			DataImages: []cloudimages.DataImage{
+				{
+					Name:        d.Get("name").(string),
+					VolumeId:    d.Get("volume_id").(string),
+					Description: d.Get("description").(string),
+				},
+			},
+		}
+		logp.Printf("[DEBUG] Create Options: %#v", createOpts)
+		v, err = cloudimages.CreateDataImageByServer(imsClient, createOpts).ExtractJobResponse()
+	} else if val, ok := d.GetOk("instance_id"); ok {
 		createOpts := &cloudimages.CreateByServerOpts{
 			Name:                d.Get("name").(string),
 			Description:         d.Get("description").(string),
@@ -167,6 +333,13 @@ func resourceImsImageCreate(d *schema.ResourceData, meta interface{}) error {
 			ImageTags:           imageTags,
 			EnterpriseProjectID: GetEnterpriseProjectID(d, config),
 		}
+		// the data disk images are named after the system disk image
+		for i, volumeID := range utils.ExpandToStringList(d.Get("data_volume_ids").([]interface{})) {
+			createOpts.DataImages = append(createOpts.DataImages, cloudimages.DataImage{
+				Name:     fmt.Sprintf("%s-data-%d", createOpts.Name, i+1),
+				VolumeId: volumeID,
+			})
+		}
 		logp.Printf("[DEBUG] Create Options: %#v", createOpts)
 		v, err = cloudimages.CreateImageByServer(imsClient, createOpts).ExtractJobResponse()
 	} else {
@@ -191,54 +364,85 @@ func resourceImsImageCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return fmtp.Errorf("Error creating HuaweiCloud IMS: %s", err)
 	}
-	logp.Printf("[INFO] IMS Job ID: %s", v.JobID)
+	jobID := v.JobID
+	logp.Printf("[INFO] IMS Job ID: %s", jobID)
 
 	// Wait for the ims to become available.
 	logp.Printf("[DEBUG] Waiting for IMS to become available")
-	err = cloudimages.WaitForJobSuccess(imsClient, int(d.Timeout(schema.TimeoutCreate)/time.Second), v.JobID)
+	job, err := ims.WaitForImageJob(context.TODO(), imsV1Client, jobID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return fmtp.Errorf("Error waiting for IMS job %s: %s", jobID, err)
 	}
 
-	entity, err := cloudimages.GetJobEntity(imsClient, v.JobID, "image_id")
-	if err != nil {
-		return err
+	id, dataImageIDs := getImageJobResult(job)
+	if id == "" {
+		return fmtp.Errorf("Error creating HuaweiCloud IMS: the image ID is not found in job %s", jobID)
+	}
+	logp.Printf("[INFO] IMS ID: %s, data disk images: %v", id, dataImageIDs)
+	// Store the ID now
+	d.SetId(id)
+	if err := d.Set("data_image_ids", dataImageIDs); err != nil {
+		return fmtp.Errorf("Error saving the data disk images of image %s: %s", id, err)
 	}
 
-	if id, ok := entity.(string); ok {
-		logp.Printf("[INFO] IMS ID: %s", id)
-		// Store the ID now
-		d.SetId(id)
-		return resourceImsImageRead(d, meta)
+	tagmap := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
+	if imageType == "data_disk" && len(tagmap) > 0 {
+		if err := setTagForImage(d, meta, id, tagmap); err != nil {
+			return fmtp.Errorf("Error setting HuaweiCloud tags of image %s: %s", id, err)
+		}
 	}
-	return fmtp.Errorf("Unexpected conversion error in resourceImsImageCreate.")
+
+	return resourceImsImageRead(d, meta)
 }
 
 func getCloudimage(client *golangsdk.ServiceClient, id string) (*cloudimages.Image, error) {
+	img, _, err := getCloudimageWithType(client, id)
+	return img, err
+}
+
+// getCloudimageWithType returns the image and its type, which is whole, data_disk or system
+func getCloudimageWithType(client *golangsdk.ServiceClient, id string) (*cloudimages.Image, string, error) {
 	listOpts := &cloudimages.ListOpts{
 		ID:    id,
 		Limit: 1,
 	}
 	allPages, err := cloudimages.List(client, listOpts).AllPages()
 	if err != nil {
-		return nil, fmtp.Errorf("Unable to query images: %s", err)
+		return nil, "", fmtp.Errorf("Unable to query images: %s", err)
 	}
 
 	allImages, err := cloudimages.ExtractImages(allPages)
 	if err != nil {
-		return nil, fmtp.Errorf("Unable to retrieve images: %s", err)
+		return nil, "", fmtp.Errorf("Unable to retrieve images: %s", err)
 	}
 
 	if len(allImages) < 1 {
-		return nil, fmtp.Errorf("Unable to find images %s: Maybe not existed", id)
+		return nil, "", fmtp.Errorf("Unable to find images %s: Maybe not existed", id)
 	}
 
 	img := allImages[0]
 	if img.ID != id {
-		return nil, fmtp.Errorf("Unexpected images ID")
+		return nil, "", fmtp.Errorf("Unexpected images ID")
 	}
 	logp.Printf("[DEBUG] Retrieved Image %s: %#v", id, img)
-	return &img, nil
+
+	// the whole image flag is not parsed by the SDK
+	var extra struct {
+		Images []struct {
+			WholeImage string `json:"__whole_image"`
+		} `json:"images"`
+	}
+	if err := allPages.(cloudimages.ImagePage).ExtractInto(&extra); err != nil {
+		return nil, "", fmtp.Errorf("Unable to retrieve images: %s", err)
+	}
+
+	imageType := "system"
+	if len(extra.Images) > 0 && extra.Images[0].WholeImage == "true" {
+		imageType = "whole"
+	} else if img.VirtualEnvType == "DataImage" {
+		imageType = "data_disk"
+	}
+	return &img, imageType, nil
 }
 
 func resourceImsImageRead(d *schema.ResourceData, meta interface{}) error {
@@ -248,13 +452,14 @@ func resourceImsImageRead(d *schema.ResourceData, meta interface{}) error {
 		return fmtp.Errorf("Error creating HuaweiCloud image client: %s", err)
 	}
 
-	img, err := getCloudimage(imsClient, d.Id())
+	img, imageType, err := getCloudimageWithType(imsClient, d.Id())
 	if err != nil {
 		return fmtp.Errorf("Image %s not found: %s", d.Id(), err)
 	}
 	logp.Printf("[DEBUG] Retrieved Image %s: %#v", d.Id(), img)
 
 	d.Set("name", img.Name)
+	d.Set("image_type", imageType)
 	d.Set("visibility", img.Visibility)
 	d.Set("data_origin", img.DataOrigin)
 	d.Set("disk_format", img.DiskFormat)
@@ -272,7 +477,7 @@ func resourceImsImageRead(d *schema.ResourceData, meta interface{}) error {
 		for _, val := range Taglist.Tags {
 			tagmap[val.Key] = val.Value
 		}
-		if err := d.Set("tags", tagmap); err != nil {
+		if err := d.Set("tags", utils.FlattenTagsMap(d, tagmap, config.TagsConfig)); err != nil {
 			return fmtp.Errorf("[DEBUG] Error saving tags for HuaweiCloud image (%s): %s", d.Id(), err)
 		}
 	} else {
@@ -282,6 +487,59 @@ func resourceImsImageRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourceImsImageImportState refuses to import the system disk image which is created together with the data disk
+// images, the data disk images can not be found by the image, so they would be left behind after it's deleted.
+func resourceImsImageImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	imsClient, err := config.ImageV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud image client: %s", err)
+	}
+
+	img, imageType, err := getCloudimageWithType(imsClient, d.Id())
+	if err != nil {
+		return nil, fmtp.Errorf("Image %s not found: %s", d.Id(), err)
+	}
+	if imageType != "system" || !strings.HasPrefix(img.DataOrigin, "instance,") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	dataImageIDs, err := listDataImagesByOrigin(imsClient, img.DataOrigin)
+	if err != nil {
+		return nil, err
+	}
+	if len(dataImageIDs) > 0 {
+		return nil, fmtp.Errorf("the image %s is created from the ECS together with the data disk images %v, "+
+			"importing it is not supported", d.Id(), dataImageIDs)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// listDataImagesByOrigin returns the private data disk images which are created from the data origin,
+// e.g. "instance,{instance_id}"
+func listDataImagesByOrigin(client *golangsdk.ServiceClient, dataOrigin string) ([]string, error) {
+	listOpts := &cloudimages.ListOpts{
+		Imagetype:      "private",
+		VirtualEnvType: "DataImage",
+	}
+	allPages, err := cloudimages.List(client, listOpts).AllPages()
+	if err != nil {
+		return nil, fmtp.Errorf("Unable to query the data disk images: %s", err)
+	}
+	allImages, err := cloudimages.ExtractImages(allPages)
+	if err != nil {
+		return nil, fmtp.Errorf("Unable to retrieve the data disk images: %s", err)
+	}
+
+	var imageIDs []string
+	for _, img := range allImages {
+		if img.DataOrigin == dataOrigin {
+			imageIDs = append(imageIDs, img.ID)
+		}
+	}
+	return imageIDs, nil
+}
+
 func setTagForImage(d *schema.ResourceData, meta interface{}, imageID string, tagmap map[string]interface{}) error {
 	config := meta.(*config.Config)
 	client, err := config.ImageV2Client(GetRegion(d, config))
@@ -331,22 +589,27 @@ func resourceImsImageUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmtp.Errorf("Error fetching HuaweiCloud image tags: %s", err)
 		}
-		if len(oldTags.Tags) > 0 {
-			deleteopts := tags.BatchOpts{Action: tags.ActionDelete, Tags: oldTags.Tags}
+		// the ignored tags are managed outside Terraform, keep them as they are
+		var removedTags []tags.Tag
+		for _, tag := range oldTags.Tags {
+			if !utils.IsIgnoredTagKey(tag.Key, config.TagsConfig) {
+				removedTags = append(removedTags, tag)
+			}
+		}
+		if len(removedTags) > 0 {
+			deleteopts := tags.BatchOpts{Action: tags.ActionDelete, Tags: removedTags}
 			deleteTags := tags.BatchAction(imsClient, d.Id(), deleteopts)
 			if deleteTags.Err != nil {
 				return fmtp.Errorf("Error deleting HuaweiCloud image tags: %s", deleteTags.Err)
 			}
 		}
 
-		if hasFilledOpt(d, "tags") {
-			tagmap := d.Get("tags").(map[string]interface{})
-			if len(tagmap) > 0 {
-				logp.Printf("[DEBUG] Setting tags: %v", tagmap)
-				err = setTagForImage(d, meta, d.Id(), tagmap)
-				if err != nil {
-					return fmtp.Errorf("Error updating HuaweiCloud tags of image:%s", err)
-				}
+		tagmap := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
+		if len(tagmap) > 0 {
+			logp.Printf("[DEBUG] Setting tags: %v", tagmap)
+			err = setTagForImage(d, meta, d.Id(), tagmap)
+			if err != nil {
+				return fmtp.Errorf("Error updating HuaweiCloud tags of image:%s", err)
 			}
 		}
 	}
@@ -361,27 +624,42 @@ func resourceImsImageDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmtp.Errorf("Error creating HuaweiCloud image client: %s", err)
 	}
 
-	logp.Printf("[DEBUG] Deleting Image %s", d.Id())
-	if err := imageservice_v2.Delete(imageClient, d.Id()).Err; err != nil {
-		return fmtp.Errorf("Error deleting Image: %s", err)
+	if err := deleteImsImage(imageClient, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return fmtp.Errorf("Error deleting Huaweicloud Image: %s", err)
+	}
+
+	// the data disk images created together with the system disk image are deleted as well
+	for _, id := range utils.ExpandToStringList(d.Get("data_image_ids").([]interface{})) {
+		if err := deleteImsImage(imageClient, id, d.Timeout(schema.TimeoutDelete)); err != nil {
+			if common.IsNotFound(err) {
+				logp.Printf("[WARN] the data disk image %s has been deleted", id)
+				continue
+			}
+			return fmtp.Errorf("Error deleting the data disk image %s: %s", id, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func deleteImsImage(imageClient *golangsdk.ServiceClient, imageID string, timeout time.Duration) error {
+	logp.Printf("[DEBUG] Deleting Image %s", imageID)
+	if err := imageservice_v2.Delete(imageClient, imageID).Err; err != nil {
+		return err
 	}
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"ACTIVE"},
 		Target:     []string{"DELETED"},
-		Refresh:    waitForImageDelete(imageClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Refresh:    waitForImageDelete(imageClient, imageID),
+		Timeout:    timeout,
 		Delay:      5 * time.Second,
 		MinTimeout: 3 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
-	if err != nil {
-		return fmtp.Errorf("Error deleting Huaweicloud Image: %s", err)
-	}
-
-	d.SetId("")
-	return nil
+	_, err := stateConf.WaitForState()
+	return err
 }
 
 func waitForImageDelete(imageClient *golangsdk.ServiceClient, imageID string) resource.StateRefreshFunc {
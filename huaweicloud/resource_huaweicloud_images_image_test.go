@@ -1,13 +1,19 @@
 package huaweicloud
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
 
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/jobs"
 	"github.com/chnsz/golangsdk/openstack/ims/v2/cloudimages"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -79,6 +85,187 @@ func TestAccImsImage_withEpsId(t *testing.T) {
 	})
 }
 
+func TestAccImsImage_wholeImage(t *testing.T) {
+	var image cloudimages.Image
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_images_image.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckImsImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImsImage_wholeImage(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImsImageExists(resourceName, &image),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "image_type", "whole"),
+					resource.TestCheckResourceAttr(resourceName, "status", "active"),
+					resource.TestCheckResourceAttrPair(resourceName, "vault_id", "huaweicloud_cbr_vault.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccImsImage_dataDisk(t *testing.T) {
+	var image, systemImage cloudimages.Image
+
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_images_image.test"
+	systemResourceName := "huaweicloud_images_image.system"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckImsImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImsImage_dataDisk(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImsImageExists(resourceName, &image),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "image_type", "data_disk"),
+					resource.TestCheckResourceAttr(resourceName, "status", "active"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+					testAccCheckImsImageExists(systemResourceName, &systemImage),
+					resource.TestCheckResourceAttr(systemResourceName, "image_type", "system"),
+					resource.TestCheckResourceAttr(systemResourceName, "data_image_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceImsImage_dataVolumes(t *testing.T) {
+	srv := fakecloud.NewServer()
+	defer srv.Close()
+
+	c, err := srv.Config()
+	if err != nil {
+		t.Fatalf("Error loading the config of the fake cloud: %s", err)
+	}
+	vpcClient, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsClient, err := c.ComputeV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsV11Client, err := c.ComputeV11Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imsClient, err := c.ImageV2Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, err := vpcs.Create(vpcClient, vpcs.CreateOpts{Name: "vpc-test", CIDR: "192.168.0.0/16"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the VPC: %s", err)
+	}
+	subnet, err := subnets.Create(vpcClient, subnets.CreateOpts{
+		Name:      "subnet-test",
+		CIDR:      "192.168.0.0/24",
+		GatewayIP: "192.168.0.1",
+		VPC_ID:    vpc.ID,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the subnet: %s", err)
+	}
+	job, err := cloudservers.Create(ecsV11Client, cloudservers.CreateOpts{
+		ImageRef:         "fake-image-id",
+		FlavorRef:        "s6.small.1",
+		Name:             "ecs-test",
+		VpcId:            vpc.ID,
+		Nics:             []cloudservers.Nic{{SubnetId: subnet.ID}},
+		RootVolume:       cloudservers.RootVolume{VolumeType: "SSD"},
+		DataVolumes:      []cloudservers.DataVolume{{VolumeType: "SSD", Size: 100}},
+		AvailabilityZone: "cn-north-4a",
+	}).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error creating the server: %s", err)
+	}
+	var serverID string
+	for i := 0; i < 5 && serverID == ""; i++ {
+		if j, err := jobs.Get(ecsClient, job.JobID); err == nil && j.Status == "SUCCESS" {
+			serverID = j.Entities.SubJobs[0].Entities.ServerId
+		}
+	}
+	server, err := cloudservers.Get(ecsClient, serverID).Extract()
+	if err != nil {
+		t.Fatalf("Error getting the server: %s", err)
+	}
+
+	// the system disk image is created together with the data disk image of the server
+	d := ResourceImsImage().TestResourceData()
+	d.Set("name", "image-test")
+	d.Set("instance_id", serverID)
+	d.Set("data_volume_ids", []string{server.VolumeAttached[1].ID})
+	if err := resourceImsImageCreate(d, c); err != nil {
+		t.Fatalf("Error creating the image: %s", err)
+	}
+	dataImageIDs := d.Get("data_image_ids").([]interface{})
+	if d.Get("image_type").(string) != "system" || d.Get("status").(string) != "active" || len(dataImageIDs) != 1 {
+		t.Fatalf("unexpected image: %#v", d.State())
+	}
+	dataImage, err := getCloudimage(imsClient, dataImageIDs[0].(string))
+	if err != nil {
+		t.Fatalf("Error getting the data disk image: %s", err)
+	}
+	if dataImage.Name != "image-test-data-1" || dataImage.Status != "active" {
+		t.Errorf("unexpected data disk image: %#v", dataImage)
+	}
+
+	// the system disk image with the data disk images can not be imported
+	importData := ResourceImsImage().TestResourceData()
+	importData.SetId(d.Id())
+	if _, err := resourceImsImageImportState(importData, c); err == nil {
+		t.Errorf("expected an error when importing the image %s with the data disk images", d.Id())
+	}
+
+	// the data disk image is deleted with the system disk image
+	if err := resourceImsImageDelete(d, c); err != nil {
+		t.Fatalf("Error deleting the image: %s", err)
+	}
+	if _, err := getCloudimage(imsClient, dataImage.ID); err == nil {
+		t.Errorf("expected the data disk image %s to be deleted", dataImage.ID)
+	}
+}
+
+// testUnknownValue is the value of the arguments which are known after apply in the raw configurations
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestResourceImsImage_customizeDiff(t *testing.T) {
+	cases := []struct {
+		raw   map[string]interface{}
+		valid bool
+	}{
+		{map[string]interface{}{"name": "test", "instance_id": "ecs-id"}, true},
+		{map[string]interface{}{"name": "test", "image_url": "bucket:file.vhd", "min_disk": 40}, true},
+		{map[string]interface{}{"name": "test"}, false},
+		{map[string]interface{}{"name": "test", "instance_id": "ecs-id", "vault_id": "vault-id"}, false},
+		{map[string]interface{}{"name": "test", "image_type": "whole", "instance_id": "ecs-id"}, false},
+		{map[string]interface{}{"name": "test", "image_type": "whole", "instance_id": "ecs-id",
+			"vault_id": "vault-id"}, true},
+		{map[string]interface{}{"name": "test", "image_type": "data_disk", "instance_id": "ecs-id"}, false},
+		{map[string]interface{}{"name": "test", "image_type": "data_disk", "volume_id": "volume-id"}, true},
+		// the instance ID which is known after apply is regarded as specified
+		{map[string]interface{}{"name": "test", "instance_id": testUnknownValue}, true},
+	}
+
+	for i, tc := range cases {
+		_, err := ResourceImsImage().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tc.raw), nil)
+		if tc.valid != (err == nil) {
+			t.Errorf("case %d: expected the validity %t, but got the error: %v", i, tc.valid, err)
+		}
+	}
+}
+
 func testAccCheckImsImageDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*config.Config)
 	imageClient, err := config.ImageV2Client(HW_REGION_NAME)
@@ -260,3 +447,92 @@ resource "huaweicloud_images_image" "test" {
 }
 `, rName, rName, HW_ENTERPRISE_PROJECT_ID_TEST)
 }
+
+func testAccImsImage_wholeImage(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_cbr_vault" "test" {
+  name             = "%s"
+  type             = "server"
+  protection_type  = "backup"
+  consistent_level = "crash_consistent"
+  size             = 200
+
+  resources {
+    server_id = huaweicloud_compute_instance.test.id
+  }
+}
+
+resource "huaweicloud_images_image" "test" {
+  name        = "%s"
+  image_type  = "whole"
+  instance_id = huaweicloud_compute_instance.test.id
+  vault_id    = huaweicloud_cbr_vault.test.id
+  description = "created by Terraform AccTest"
+}
+`, testAccImsImage_instanceWithDataDisk(rName), rName, rName)
+}
+
+func testAccImsImage_dataDisk(rName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "huaweicloud_images_image" "test" {
+  name        = "%s"
+  image_type  = "data_disk"
+  volume_id   = huaweicloud_compute_instance.test.volume_attached[1].volume_id
+  description = "created by Terraform AccTest"
+
+  tags = {
+    foo = "bar"
+  }
+}
+
+resource "huaweicloud_images_image" "system" {
+  name            = "%s-system"
+  instance_id     = huaweicloud_compute_instance.test.id
+  data_volume_ids = [huaweicloud_compute_instance.test.volume_attached[1].volume_id]
+
+  depends_on = [huaweicloud_images_image.test]
+}
+`, testAccImsImage_instanceWithDataDisk(rName), rName, rName)
+}
+
+func testAccImsImage_instanceWithDataDisk(rName string) string {
+	return fmt.Sprintf(`
+data "huaweicloud_availability_zones" "test" {}
+
+data "huaweicloud_compute_flavors" "test" {
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+data "huaweicloud_vpc_subnet" "test" {
+  name = "subnet-default"
+}
+
+data "huaweicloud_networking_secgroup" "test" {
+  name = "default"
+}
+
+resource "huaweicloud_compute_instance" "test" {
+  name               = "%s"
+  image_name         = "Ubuntu 18.04 server 64bit"
+  flavor_id          = data.huaweicloud_compute_flavors.test.ids[0]
+  security_group_ids = [data.huaweicloud_networking_secgroup.test.id]
+  availability_zone  = data.huaweicloud_availability_zones.test.names[0]
+
+  network {
+    uuid = data.huaweicloud_vpc_subnet.test.id
+  }
+
+  data_disks {
+    type = "SAS"
+    size = 10
+  }
+}
+`, rName)
+}
@@ -150,7 +150,7 @@ func resourceKmsKeyV1Create(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		tagErr := tags.Create(kmsKeyV1Client, "kms", v.KeyID, taglist).ExtractErr()
@@ -202,7 +202,7 @@ func resourceKmsKeyV1Read(d *schema.ResourceData, meta interface{}) error {
 
 	// Set kms tags
 	if resourceTags, err := tags.Get(kmsKeyV1Client, "kms", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for kms key(%s): %s", d.Id(), err)
 		}
@@ -270,7 +270,7 @@ func resourceKmsKeyV1Update(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(kmsKeyV1Client, d, "kms", d.Id())
+		tagErr := utils.UpdateResourceTags(kmsKeyV1Client, d, "kms", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of kms:%s, err:%s", d.Id(), err)
 		}
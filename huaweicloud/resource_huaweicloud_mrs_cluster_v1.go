@@ -496,7 +496,7 @@ func resourceClusterV1Create(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// create tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(client, "clusters", d.Id(), taglist).ExtractErr(); tagErr != nil {
@@ -614,7 +614,7 @@ func resourceClusterV1Read(d *schema.ResourceData, meta interface{}) error {
 
 	// set tags
 	if resourceTags, err := tags.Get(client, "clusters", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		d.Set("tags", tagmap)
 	} else {
 		logp.Printf("[WARN] fetching tags of MRS cluster failed: %s", err)
@@ -631,7 +631,7 @@ func resourceClusterV1Update(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(client, d, "clusters", d.Id())
+	tagErr := utils.UpdateResourceTags(client, d, "clusters", d.Id(), config.TagsConfig)
 	if tagErr != nil {
 		return fmtp.Errorf("Error updating tags of MRS cluster:%s, err:%s", d.Id(), tagErr)
 	}
@@ -3,6 +3,7 @@ package huaweicloud
 import (
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -24,6 +25,10 @@ func ResourceNetworkACL() *schema.Resource {
 		Update: resourceNetworkACLUpdate,
 		Delete: resourceNetworkACLDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceNetworkACLImport,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -488,3 +493,63 @@ func updateNetworkACLPolicyRules(d *schema.ResourceData, client *golangsdk.Servi
 
 	return nil
 }
+
+func resourceNetworkACLImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	fwClient, err := config.FwV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud fw client: %s", err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud networking client: %s", err)
+	}
+
+	var fwGroup FirewallGroup
+	if err := firewall_groups.Get(fwClient, d.Id()).ExtractInto(&fwGroup); err != nil {
+		return nil, fmtp.Errorf("Error retrieving HuaweiCloud Firewall group %s: %s", d.Id(), err)
+	}
+	logp.Printf("[DEBUG] Retrieved HuaweiCloud Firewall group %s during the import", d.Id())
+
+	// the rules and subnets are not set in the read function, so fetch them from the policies and ports
+	inboundRules, err := getNetworkACLPolicyRules(fwClient, fwGroup.IngressPolicyID)
+	if err != nil {
+		return nil, err
+	}
+	outboundRules, err := getNetworkACLPolicyRules(fwClient, fwGroup.EgressPolicyID)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetIDs := make([]string, len(fwGroup.PortIDs))
+	for i, portID := range fwGroup.PortIDs {
+		port, err := ports.Get(networkingClient, portID).Extract()
+		if err != nil {
+			return nil, fmtp.Errorf("Error retrieving HuaweiCloud port %s: %s", portID, err)
+		}
+		subnetIDs[i] = port.NetworkID
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("inbound_rules", inboundRules),
+		d.Set("outbound_rules", outboundRules),
+		d.Set("subnets", subnetIDs),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return nil, fmtp.Errorf("Error setting network ACL fields during the import: %s", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func getNetworkACLPolicyRules(client *golangsdk.ServiceClient, policyID string) ([]string, error) {
+	if policyID == "" {
+		return nil, nil
+	}
+
+	policy, err := policies.Get(client, policyID).Extract()
+	if err != nil {
+		return nil, fmtp.Errorf("Error retrieving HuaweiCloud firewall policy %s: %s", policyID, err)
+	}
+	return policy.Rules, nil
+}
@@ -45,6 +45,11 @@ func TestAccNetworkACL_basic(t *testing.T) {
 					testAccCheckFWFirewallPortCount(&fwGroup, 2),
 				),
 			},
+			{
+				ResourceName:      resourceKey,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
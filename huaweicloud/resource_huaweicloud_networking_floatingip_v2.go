@@ -65,7 +65,7 @@ func resourceNetworkingFloatingIPV2() *schema.Resource {
 				Optional:   true,
 				Computed:   true,
 				ForceNew:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"fixed_ip": {
 				Type:     schema.TypeString,
@@ -24,7 +24,7 @@ func resourceNetworkingNetworkV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		DeprecationMessage: "use VPC resources instead",
+		DeprecationMessage: "use huaweicloud_vpc and huaweicloud_vpc_subnet resources instead",
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
@@ -57,7 +57,7 @@ func resourceNetworkingNetworkV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"segments": {
 				Type:     schema.TypeList,
@@ -64,7 +64,7 @@ func ResourceNetworkingPortV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"device_owner": {
 				Type:     schema.TypeString,
@@ -22,7 +22,7 @@ func resourceNetworkingRouterInterfaceV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		DeprecationMessage: "use VPC resources instead",
+		DeprecationMessage: "use huaweicloud_vpc_subnet resource instead",
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
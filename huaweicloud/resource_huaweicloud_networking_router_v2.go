@@ -83,7 +83,7 @@ func resourceNetworkingRouterV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"value_specs": {
 				Type:     schema.TypeMap,
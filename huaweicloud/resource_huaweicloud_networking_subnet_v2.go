@@ -56,7 +56,7 @@ func resourceNetworkingSubnetV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"allocation_pools": {
 				Type:     schema.TypeList,
@@ -2,6 +2,7 @@ package huaweicloud
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -9,6 +10,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/obs"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
@@ -289,7 +291,6 @@ func ResourceObsBucket() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 
 			"bucket_domain_name": {
@@ -420,6 +421,12 @@ func resourceObsBucketUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	if d.HasChange("enterprise_project_id") && !d.IsNewResource() {
+		if err := resourceObsBucketEnterpriseProjectUpdate(config, d); err != nil {
+			return err
+		}
+	}
+
 	return resourceObsBucketRead(d, meta)
 }
 
@@ -630,6 +637,17 @@ func resourceObsBucketClassUpdate(obsClient *obs.ObsClient, d *schema.ResourceDa
 	return nil
 }
 
+func resourceObsBucketEnterpriseProjectUpdate(config *config.Config, d *schema.ResourceData) error {
+	bucket := d.Get("bucket").(string)
+	migrateOpts := common.MigrateResourceOpts{
+		ResourceId:   bucket,
+		ResourceType: "bucket",
+	}
+	logp.Printf("[DEBUG] migrate OBS bucket %s to enterprise project %s", bucket, d.Get("enterprise_project_id"))
+
+	return common.MigrateEnterpriseProject(context.TODO(), d, config, migrateOpts)
+}
+
 func resourceObsBucketVersioningUpdate(obsClient *obs.ObsClient, d *schema.ResourceData) error {
 	bucket := d.Get("bucket").(string)
 	version := d.Get("versioning").(bool)
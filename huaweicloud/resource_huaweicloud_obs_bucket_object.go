@@ -20,6 +20,10 @@ func ResourceObsBucketObject() *schema.Resource {
 		Update: resourceObsBucketObjectPut,
 		Delete: resourceObsBucketObjectDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceObsBucketObjectImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -294,3 +298,17 @@ func resourceObsBucketObjectDelete(d *schema.ResourceData, meta interface{}) err
 
 	return nil
 }
+
+func resourceObsBucketObjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmtp.Errorf("Invalid format specified for OBS bucket object. Format must be <bucket>/<key>")
+	}
+
+	bucket, key := parts[0], parts[1]
+	d.SetId(key)
+	d.Set("bucket", bucket)
+	d.Set("key", key)
+
+	return []*schema.ResourceData{d}, nil
+}
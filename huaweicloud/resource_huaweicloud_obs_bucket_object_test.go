@@ -73,6 +73,13 @@ func TestAccObsBucketObject_content(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "size", "19"),
 				),
 			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccImportStateIdWithAttributes(resourceName, "bucket"),
+				ImportStateVerifyIgnore: []string{"content", "version_id"},
+			},
 		},
 	})
 }
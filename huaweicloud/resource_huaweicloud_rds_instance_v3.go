@@ -1,6 +1,8 @@
 package huaweicloud
 
 import (
+	"context"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +14,9 @@ import (
 	"github.com/chnsz/golangsdk/openstack/rds/v3/securities"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/plancheck"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/waiter"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
@@ -28,6 +33,8 @@ func ResourceRdsInstanceV3() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: plancheck.CustomizeDiff(validateRdsInstancePlan),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create:  schema.DefaultTimeout(30 * time.Minute),
 			Update:  schema.DefaultTimeout(30 * time.Minute),
@@ -162,7 +169,6 @@ func ResourceRdsInstanceV3() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 
 			"fixed_ip": {
@@ -256,10 +262,11 @@ func ResourceRdsInstanceV3() *schema.Resource {
 			},
 
 			// charge info: charging_mode, period_unit, period, auto_renew
-			"charging_mode": schemeChargingMode(nil),
-			"period_unit":   schemaPeriodUnit(nil),
-			"period":        schemaPeriod(nil),
-			"auto_renew":    schemaAutoRenew(nil),
+			"charging_mode": common.SchemaChargingModeUpdatable(nil),
+			"period_unit":   common.SchemaPeriodUnitUpdatable(nil),
+			"period":        common.SchemaPeriodUpdatable(nil),
+			"auto_renew":    common.SchemaAutoRenewUpdatable(nil),
+			"expire_time":   common.SchemaExpireTime(),
 		},
 	}
 }
@@ -341,15 +348,17 @@ func resourceRdsInstanceV3Create(d *schema.ResourceData, meta interface{}) error
 		}
 	} else {
 		// for prePaid charge mode
-		stateConf := &resource.StateChangeConf{
+		stateConf := &waiter.Conf{
+			Description:  fmt.Sprintf("RDS instance (%s) to become active", instanceID),
 			Pending:      []string{"BUILD"},
 			Target:       []string{"ACTIVE", "BACKING UP"},
+			Failed:       []string{"FAILED"},
 			Refresh:      rdsInstanceStateRefreshFunc(client, instanceID),
 			Timeout:      d.Timeout(schema.TimeoutCreate),
 			Delay:        20 * time.Second,
 			PollInterval: 10 * time.Second,
 		}
-		if _, err = stateConf.WaitForState(); err != nil {
+		if _, err = stateConf.WaitForState(context.TODO()); err != nil {
 			return fmtp.Errorf("Error waiting for RDS instance (%s) creation completed: %s", instanceID, err)
 		}
 	}
@@ -365,7 +374,7 @@ func resourceRdsInstanceV3Create(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(client, "instances", instanceID, taglist).ExtractErr(); tagErr != nil {
@@ -406,6 +415,9 @@ func resourceRdsInstanceV3Read(d *schema.ResourceData, meta interface{}) error {
 	d.Set("time_zone", instance.TimeZone)
 	d.Set("enterprise_project_id", instance.EnterpriseProjectId)
 	d.Set("charging_mode", instance.ChargeInfo.ChargeMode)
+	if err := common.SetPrePaidExpireTime(d, config, d.Id()); err != nil {
+		return fmtp.Errorf("Error setting expire_time of RDS instance (%s): %s", d.Id(), err)
+	}
 
 	publicIps := make([]interface{}, len(instance.PublicIps))
 	for i, v := range instance.PublicIps {
@@ -471,7 +483,7 @@ func resourceRdsInstanceV3Read(d *schema.ResourceData, meta interface{}) error {
 		return fmtp.Errorf("[DEBUG] Error saving nodes to RDS instance (%s): %s", instanceID, err)
 	}
 
-	d.Set("tags", utils.TagsToMap(instance.Tags))
+	d.Set("tags", utils.FlattenResourceTags(d, instance.Tags, config.TagsConfig))
 
 	az1 := instance.Nodes[0].AvailabilityZone
 	if strings.HasSuffix(d.Get("flavor").(string), ".ha") {
@@ -501,8 +513,10 @@ func resourceRdsInstanceV3Update(d *schema.ResourceData, meta interface{}) error
 	instanceID := d.Id()
 	// Since the instance will throw an exception when making an API interface call in 'BACKING UP' state,
 	// wait for the instance state to be updated to 'ACTIVE' before calling the interface.
-	stateConf := &resource.StateChangeConf{
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("RDS instance (%s) to become active", instanceID),
 		Target:       []string{"ACTIVE"},
+		Failed:       []string{"FAILED"},
 		Refresh:      rdsInstanceStateRefreshFunc(client, instanceID),
 		Timeout:      d.Timeout(schema.TimeoutDefault),
 		Delay:        5 * time.Second,
@@ -510,7 +524,7 @@ func resourceRdsInstanceV3Update(d *schema.ResourceData, meta interface{}) error
 		// Provide 10 seconds to check whether the instance is 'ACTIVE' or is about to enter 'BACKING UP'.
 		ContinuousTargetOccurence: 3,
 	}
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = stateConf.WaitForState(context.TODO()); err != nil {
 		return fmtp.Errorf("Error waiting for RDS instance (%s) become active state: %s", instanceID, err)
 	}
 
@@ -542,8 +556,16 @@ func resourceRdsInstanceV3Update(d *schema.ResourceData, meta interface{}) error
 		return fmtp.Errorf("[ERROR] %s", err)
 	}
 
+	if err := updateRdsInstanceEnterpriseProject(d, config, client, instanceID); err != nil {
+		return fmtp.Errorf("[ERROR] %s", err)
+	}
+
+	if err := updateRdsInstanceChargeInfo(d, config, client, instanceID); err != nil {
+		return fmtp.Errorf("[ERROR] %s", err)
+	}
+
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID)
+		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID, config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of RDS instance (%s): %s", instanceID, tagErr)
 		}
@@ -572,16 +594,18 @@ func resourceRdsInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"ACTIVE"},
-		Target:     []string{"DELETED"},
-		Refresh:    rdsInstanceStateRefreshFunc(client, id),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      15 * time.Second,
-		MinTimeout: 5 * time.Second,
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("RDS instance (%s) to be deleted", id),
+		Pending:      []string{"ACTIVE"},
+		Target:       []string{"DELETED"},
+		Failed:       []string{"FAILED"},
+		Refresh:      rdsInstanceStateRefreshFunc(client, id),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Delay:        15 * time.Second,
+		PollInterval: 5 * time.Second,
 	}
 
-	_, err = stateConf.WaitForState()
+	_, err = stateConf.WaitForState(context.TODO())
 	if err != nil {
 		return fmtp.Errorf(
 			"Error waiting for rds instance (%s) to be deleted: %s ",
@@ -692,15 +716,17 @@ func updateRdsInstanceName(d *schema.ResourceData, client *golangsdk.ServiceClie
 		return fmtp.Errorf("Error renaming HuaweiCloud RDS instance (%s): %s", instanceID, r.Err)
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"MODIFYING"},
-		Target:     []string{"ACTIVE"},
-		Refresh:    rdsInstanceStateRefreshFunc(client, instanceID),
-		Timeout:    d.Timeout(schema.TimeoutUpdate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("RDS instance (%s) to be renamed", instanceID),
+		Pending:      []string{"MODIFYING"},
+		Target:       []string{"ACTIVE"},
+		Failed:       []string{"FAILED"},
+		Refresh:      rdsInstanceStateRefreshFunc(client, instanceID),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        5 * time.Second,
+		PollInterval: 3 * time.Second,
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForState(context.TODO()); err != nil {
 		return fmtp.Errorf("Error waiting for RDS instance (%s) flavor to be updated: %s ", instanceID, err)
 	}
 
@@ -723,15 +749,17 @@ func updateRdsInstanceFlavor(d *schema.ResourceData, client *golangsdk.ServiceCl
 		return fmtp.Errorf("Error updating instance Flavor from result: %s ", err)
 	}
 
-	stateConf := &resource.StateChangeConf{
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("RDS instance (%s) flavor to be updated", instanceID),
 		Pending:      []string{"MODIFYING"},
 		Target:       []string{"ACTIVE"},
+		Failed:       []string{"FAILED"},
 		Refresh:      rdsInstanceStateRefreshFunc(client, instanceID),
 		Timeout:      d.Timeout(schema.TimeoutCreate),
 		Delay:        15 * time.Second,
 		PollInterval: 15 * time.Second,
 	}
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = stateConf.WaitForState(context.TODO()); err != nil {
 		return fmtp.Errorf("Error waiting for instance (%s) flavor to be Updated: %s ", instanceID, err)
 	}
 	return nil
@@ -783,15 +811,17 @@ func updateRdsInstanceBackpStrategy(d *schema.ResourceData, client *golangsdk.Se
 		return fmtp.Errorf("Error updating FlexibleEngine RDS instance (%s): %s", instanceID, err)
 	}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"BACKING UP"},
-		Target:     []string{"ACTIVE"},
-		Refresh:    rdsInstanceStateRefreshFunc(client, instanceID),
-		Timeout:    d.Timeout(schema.TimeoutUpdate),
-		Delay:      15 * time.Second,
-		MinTimeout: 3 * time.Second,
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("RDS instance (%s) backup strategy to be updated", instanceID),
+		Pending:      []string{"BACKING UP"},
+		Target:       []string{"ACTIVE"},
+		Failed:       []string{"FAILED"},
+		Refresh:      rdsInstanceStateRefreshFunc(client, instanceID),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		Delay:        15 * time.Second,
+		PollInterval: 3 * time.Second,
 	}
-	if _, err = stateConf.WaitForState(); err != nil {
+	if _, err = stateConf.WaitForState(context.TODO()); err != nil {
 		return fmtp.Errorf("Error waiting for RDS instance (%s) backup to be updated: %s ", instanceID, err)
 	}
 
@@ -812,15 +842,17 @@ func updateRdsInstanceDBPort(d *schema.ResourceData, client *golangsdk.ServiceCl
 		return fmtp.Errorf("Error updating instance database port: %s ", err)
 	}
 	// for prePaid charge mode
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"MODIFYING DATABASE PORT"},
-		Target:     []string{"ACTIVE"},
-		Refresh:    rdsInstanceStateRefreshFunc(client, instanceID),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      5 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-	if _, err = stateConf.WaitForState(); err != nil {
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("RDS instance (%s) database port to be updated", instanceID),
+		Pending:      []string{"MODIFYING DATABASE PORT"},
+		Target:       []string{"ACTIVE"},
+		Failed:       []string{"FAILED"},
+		Refresh:      rdsInstanceStateRefreshFunc(client, instanceID),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Delay:        5 * time.Second,
+		PollInterval: 3 * time.Second,
+	}
+	if _, err = stateConf.WaitForState(context.TODO()); err != nil {
 		return fmtp.Errorf("Error waiting for RDS instance (%s) creation completed: %s", instanceID, err)
 	}
 
@@ -867,21 +899,67 @@ func configRdsInstanceSSL(d *schema.ResourceData, client *golangsdk.ServiceClien
 	return nil
 }
 
+func updateRdsInstanceEnterpriseProject(d *schema.ResourceData, config *config.Config, client *golangsdk.ServiceClient,
+	instanceID string) error {
+	if !d.HasChange("enterprise_project_id") {
+		return nil
+	}
+
+	migrateOpts := common.MigrateResourceOpts{
+		ResourceId:   instanceID,
+		ResourceType: "rds",
+		ProjectId:    client.ProjectID,
+	}
+	return common.MigrateEnterpriseProject(context.TODO(), d, config, migrateOpts)
+}
+
+func updateRdsInstanceChargeInfo(d *schema.ResourceData, config *config.Config, client *golangsdk.ServiceClient,
+	instanceID string) error {
+	changeToPrePaid := func(opts common.ChangeToPrePaidOpts) (string, error) {
+		changeOpts := map[string]interface{}{
+			"period_type": strings.ToUpper(opts.PeriodUnit),
+			"period_num":  opts.Period,
+			"auto_renew":  opts.AutoRenew,
+			"is_auto_pay": true,
+		}
+		logp.Printf("[DEBUG] Change RDS instance (%s) to prePaid options: %#v", instanceID, changeOpts)
+
+		var r struct {
+			OrderID string `json:"order_id"`
+		}
+		_, err := client.Post(client.ServiceURL("instances", instanceID, "to-period"), changeOpts, &r,
+			&golangsdk.RequestOpts{OkCodes: []int{200}})
+		return r.OrderID, err
+	}
+
+	return common.UpdateChargeInfo(context.TODO(), d, config, instanceID, changeToPrePaid)
+}
+
 func checkRDSInstanceJobFinish(client *golangsdk.ServiceClient, jobID string, timeout time.Duration) error {
-	stateConf := &resource.StateChangeConf{
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("RDS job (%s) to be completed", jobID),
 		Pending:      []string{"Running"},
-		Target:       []string{"Completed", "Failed"},
+		Target:       []string{"Completed"},
+		Failed:       []string{"Failed"},
 		Refresh:      rdsInstanceJobRefreshFunc(client, jobID),
+		FailReason:   rdsJobFailReason,
 		Timeout:      timeout,
 		Delay:        20 * time.Second,
 		PollInterval: 10 * time.Second,
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := stateConf.WaitForState(context.TODO()); err != nil {
 		return fmtp.Errorf("Error waiting for RDS instance (%s) job to be completed: %s ", jobID, err)
 	}
 	return nil
 }
 
+func rdsJobFailReason(result interface{}) string {
+	if job, ok := result.(instances.Job); ok {
+		return job.FailReason
+	}
+	return ""
+}
+
 func rdsInstanceJobRefreshFunc(client *golangsdk.ServiceClient, jobID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		jobOpts := instances.RDSJobOpts{
@@ -909,3 +987,22 @@ func rdsInstanceStateRefreshFunc(client *golangsdk.ServiceClient, instanceID str
 		return instance, instance.Status, nil
 	}
 }
+
+// validateRdsInstancePlan validates the flavor and availability zones during the plan,
+// it's only called when enable_plan_validation is true.
+func validateRdsInstancePlan(_ context.Context, d *schema.ResourceDiff, conf *config.Config) error {
+	if !plancheck.ShouldValidate(d, "flavor", "availability_zone") {
+		return nil
+	}
+
+	region := plancheck.GetRegion(d, conf)
+	dbType := plancheck.GetKnownString(d, "db.0.type")
+	dbVersion := plancheck.GetKnownString(d, "db.0.version")
+	flavor := plancheck.GetKnownString(d, "flavor")
+	azs := plancheck.GetKnownStringList(d, "availability_zone")
+
+	if err := plancheck.CheckRdsFlavor(conf, region, dbType, dbVersion, flavor, azs); err != nil {
+		return fmtp.Errorf("Error validating flavor: %s", err)
+	}
+	return nil
+}
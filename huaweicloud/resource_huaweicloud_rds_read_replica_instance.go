@@ -196,7 +196,7 @@ func resourceRdsReadReplicaInstanceCreate(d *schema.ResourceData, meta interface
 		return fmtp.Errorf("Error creating instance (%s): %s", instanceID, err)
 	}
 
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		tagList := utils.ExpandResourceTags(tagRaw)
 		err := tags.Create(client, "instances", instanceID, tagList).ExtractErr()
@@ -237,7 +237,7 @@ func resourceRdsReadReplicaInstanceRead(d *schema.ResourceData, meta interface{}
 	d.Set("type", instance.Type)
 	d.Set("status", instance.Status)
 	d.Set("enterprise_project_id", instance.EnterpriseProjectId)
-	d.Set("tags", utils.TagsToMap(instance.Tags))
+	d.Set("tags", utils.FlattenResourceTags(d, instance.Tags, config.TagsConfig))
 
 	az := expandAvailabilityZone(instance)
 	d.Set("availability_zone", az)
@@ -287,7 +287,7 @@ func resourceRdsReadReplicaInstanceUpdate(d *schema.ResourceData, meta interface
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID)
+		tagErr := utils.UpdateResourceTags(client, d, "instances", instanceID, config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of RDS read replica instance: %s, err: %s", instanceID, tagErr)
 		}
@@ -208,7 +208,7 @@ func resourceSFSFileSystemV2Create(d *schema.ResourceData, meta interface{}) err
 	}
 
 	// create tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(sfsClient, "sfs", d.Id(), taglist).ExtractErr(); tagErr != nil {
@@ -309,7 +309,7 @@ func resourceSFSFileSystemV2Read(d *schema.ResourceData, meta interface{}) error
 
 	// set tags
 	if resourceTags, err := tags.Get(sfsClient, "sfs", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for SFS file system (%s): %s", d.Id(), err)
 		}
@@ -393,7 +393,7 @@ func resourceSFSFileSystemV2Update(d *schema.ResourceData, meta interface{}) err
 
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(sfsClient, d, "sfs", d.Id())
+		tagErr := utils.UpdateResourceTags(sfsClient, d, "sfs", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of sfs:%s, err:%s", d.Id(), tagErr)
 		}
@@ -16,6 +16,10 @@ func ResourceSubscription() *schema.Resource {
 		Read:   resourceSubscriptionRead,
 		Delete: resourceSubscriptionDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -134,9 +138,13 @@ func resourceSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
 			d.Set("owner", subscription.Owner)
 			d.Set("remark", subscription.Remark)
 			d.Set("status", subscription.Status)
+
+			logp.Printf("[DEBUG] Successfully get subscription %s", id)
+			return nil
 		}
 	}
 
-	logp.Printf("[DEBUG] Successfully get subscription %s", id)
+	logp.Printf("[WARN] Removing subscription %s because it's gone", id)
+	d.SetId("")
 	return nil
 }
@@ -35,6 +35,11 @@ func TestAccSMNV2Subscription_basic(t *testing.T) {
 						"13600000000"),
 				),
 			},
+			{
+				ResourceName:      "huaweicloud_smn_subscription_v2.subscription_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -17,6 +17,10 @@ func ResourceTopic() *schema.Resource {
 		Delete: resourceTopicDelete,
 		Update: resourceTopicUpdate,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
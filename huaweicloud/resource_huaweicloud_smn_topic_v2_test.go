@@ -44,6 +44,11 @@ func TestAccSMNV2Topic_basic(t *testing.T) {
 						update_displayName),
 				),
 			},
+			{
+				ResourceName:      "huaweicloud_smn_topic_v2.topic_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -23,7 +23,7 @@ func resourceVBSBackupPolicyV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		DeprecationMessage: "this is deprecated",
+		DeprecationMessage: "use huaweicloud_cbr_policy resource instead",
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
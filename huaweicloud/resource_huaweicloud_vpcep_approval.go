@@ -29,6 +29,10 @@ func ResourceVPCEndpointApproval() *schema.Resource {
 		Update: resourceVPCEndpointApprovalUpdate,
 		Delete: resourceVPCEndpointApprovalDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceVPCEndpointApprovalImport,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(3 * time.Minute),
@@ -230,3 +234,30 @@ func waitForVPCEndpointConnected(vpcepClient *golangsdk.ServiceClient, serviceId
 		return connections, "deleted", nil
 	}
 }
+
+func resourceVPCEndpointApprovalImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	vpcepClient, err := config.VPCEPClient(GetRegion(d, config))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating Huaweicloud VPC endpoint client: %s", err)
+	}
+
+	serviceID := d.Id()
+	allConns, err := services.ListConnections(vpcepClient, serviceID, nil)
+	if err != nil {
+		return nil, fmtp.Errorf("Error retrieving connections of VPC endpoint service %s: %s", serviceID, err)
+	}
+	logp.Printf("[DEBUG] Retrieved connections of VPC endpoint service %s during the import", serviceID)
+
+	// the endpoints are the connections which have been accepted
+	endpoints := make([]string, 0, len(allConns))
+	for _, conn := range allConns {
+		if conn.Status == approvalActionStatusMap[actionReceive] {
+			endpoints = append(endpoints, conn.EndpointID)
+		}
+	}
+
+	d.Set("service_id", serviceID)
+	d.Set("endpoints", endpoints)
+	return []*schema.ResourceData{d}, nil
+}
@@ -32,6 +32,11 @@ func TestAccVPCEndpointApproval_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "connections.0.status", "accepted"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccVPCEndpointApproval_Update(rName),
 				Check: resource.ComposeTestCheckFunc(
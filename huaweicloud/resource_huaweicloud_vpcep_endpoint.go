@@ -127,7 +127,7 @@ func resourceVPCEndpointCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		createOpts.Tags = taglist
@@ -197,11 +197,7 @@ func resourceVPCEndpointRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// fetch tags from endpoints.Endpoint
-	tagmap := make(map[string]string)
-	for _, val := range ep.Tags {
-		tagmap[val.Key] = val.Value
-	}
-	d.Set("tags", tagmap)
+	d.Set("tags", utils.FlattenResourceTags(d, ep.Tags, config.TagsConfig))
 
 	return nil
 }
@@ -215,7 +211,7 @@ func resourceVPCEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	//update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(vpcepClient, d, tagVPCEP, d.Id())
+		tagErr := utils.UpdateResourceTags(vpcepClient, d, tagVPCEP, d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of VPC endpoint service %s: %s", d.Id(), tagErr)
 		}
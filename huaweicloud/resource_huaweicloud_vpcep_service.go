@@ -190,7 +190,7 @@ func resourceVPCEndpointServiceCreate(d *schema.ResourceData, meta interface{})
 		Ports:       expandPortMappingOpts(d),
 	}
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		createOpts.Tags = taglist
@@ -272,11 +272,7 @@ func resourceVPCEndpointServiceRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("port_mapping", ports)
 
 	// fetch tags from Services.Service
-	tagmap := make(map[string]string)
-	for _, val := range n.Tags {
-		tagmap[val.Key] = val.Value
-	}
-	d.Set("tags", tagmap)
+	d.Set("tags", utils.FlattenResourceTags(d, n.Tags, config.TagsConfig))
 
 	// fetch connections
 	if conns, err := flattenVPCEndpointConnections(vpcepClient, d.Id()); err == nil {
@@ -321,7 +317,7 @@ func resourceVPCEndpointServiceUpdate(d *schema.ResourceData, meta interface{})
 
 	//update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(vpcepClient, d, tagVPCEPService, d.Id())
+		tagErr := utils.UpdateResourceTags(vpcepClient, d, tagVPCEPService, d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of VPC endpoint service %s: %s", d.Id(), tagErr)
 		}
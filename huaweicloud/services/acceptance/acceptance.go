@@ -45,10 +45,16 @@ var (
 
 	HW_DEPRECATED_ENVIRONMENT = os.Getenv("HW_DEPRECATED_ENVIRONMENT")
 
+	// HW_ACCEPTANCE_MODE can be "record" or "replay", the acceptance tests run against the cassette
+	// HW_ACCEPTANCE_CASSETTE (defaults to testdata/cassette.json) in replay mode without network access
+	HW_ACCEPTANCE_MODE     = os.Getenv("HW_ACCEPTANCE_MODE")
+	HW_ACCEPTANCE_CASSETTE = os.Getenv("HW_ACCEPTANCE_CASSETTE")
+
 	HW_WAF_ENABLE_FLAG = os.Getenv("HW_WAF_ENABLE_FLAG")
 
 	HW_DEST_REGION         = os.Getenv("HW_DEST_REGION")
 	HW_DEST_PROJECT_ID     = os.Getenv("HW_DEST_PROJECT_ID")
+	HW_IMS_AGENCY_NAME     = os.Getenv("HW_IMS_AGENCY_NAME")
 	HW_CHARGING_MODE       = os.Getenv("HW_CHARGING_MODE")
 	HW_SWR_SHARING_ACCOUNT = os.Getenv("HW_SWR_SHARING_ACCOUNT")
 
@@ -205,6 +211,27 @@ func TestCheckResourceAttrWithVariable(resourceName, key, varStr string) resourc
 	}
 }
 
+// ImportStateIdWithAttributes returns the composite import ID of the resource, which joins the values of the attributes
+// and the resource ID with slashes, e.g. "<pool_id>/<id>" for ImportStateIdWithAttributes(name, "pool_id").
+func ImportStateIdWithAttributes(resourceName string, attributes ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmtp.Errorf("Can't find %s in state", resourceName)
+		}
+
+		parts := make([]string, 0, len(attributes)+1)
+		for _, attr := range attributes {
+			value := rs.Primary.Attributes[attr]
+			if value == "" {
+				return "", fmtp.Errorf("The attribute %s of %s is empty", attr, resourceName)
+			}
+			parts = append(parts, value)
+		}
+		return strings.Join(append(parts, rs.Primary.ID), "/"), nil
+	}
+}
+
 // CheckResourceDestroy check whether resources destroyed in HuaweiCloud.
 func (rc *resourceCheck) CheckResourceDestroy() resource.TestCheckFunc {
 	if strings.Compare(rc.resourceType, dataSourceTypeCode) == 0 {
@@ -323,6 +350,7 @@ func TestAccPreCheck(t *testing.T) {
 	}
 
 	preCheckRequiredEnvVars(t)
+	preCheckHTTPRecorder(t)
 }
 
 //lintignore:AT003
@@ -400,6 +428,13 @@ func TestAccPreCheckReplication(t *testing.T) {
 	}
 }
 
+//lintignore:AT003
+func TestAccPreCheckImage(t *testing.T) {
+	if HW_IMAGE_ID == "" {
+		t.Skip("HW_IMAGE_ID must be set for the acceptance tests of the private images")
+	}
+}
+
 //lintignore:AT003
 func TestAccPreCheckProject(t *testing.T) {
 	if HW_ENTERPRISE_PROJECT_ID_TEST != "" {
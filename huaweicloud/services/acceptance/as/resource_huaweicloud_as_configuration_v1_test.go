@@ -19,6 +19,7 @@ import (
 func TestAccASV1Configuration_basic(t *testing.T) {
 	var asConfig configurations.Configuration
 	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_as_configuration.hth_as_config"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
@@ -28,9 +29,40 @@ func TestAccASV1Configuration_basic(t *testing.T) {
 			{
 				Config: testAccASV1Configuration_basic(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckASV1ConfigurationExists("huaweicloud_as_configuration.hth_as_config", &asConfig),
+					testAccCheckASV1ConfigurationExists(resourceName, &asConfig),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccASV1Configuration_spot(t *testing.T) {
+	var asConfig configurations.Configuration
+	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_as_configuration.hth_as_config"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckASV1ConfigurationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccASV1Configuration_spot(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckASV1ConfigurationExists(resourceName, &asConfig),
+					resource.TestCheckResourceAttr(resourceName, "instance_config.0.charging_mode", "spot"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -126,3 +158,43 @@ resource "huaweicloud_as_configuration" "hth_as_config"{
 }
 `, rName, rName)
 }
+
+func testAccASV1Configuration_spot(rName string) string {
+	return fmt.Sprintf(`
+data "huaweicloud_availability_zones" "test" {}
+
+data "huaweicloud_images_image" "test" {
+  name        = "Ubuntu 18.04 server 64bit"
+  most_recent = true
+}
+
+data "huaweicloud_compute_flavors" "test" {
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+resource "huaweicloud_compute_keypair" "hth_key" {
+  name       = "%s"
+  public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDAjpC1hwiOCCmKEWxJ4qzTTsJbKzndLo1BCz5PcwtUnflmU+gHJtWMZKpuEGVi29h0A/+ydKek1O18k10Ff+4tyFjiHDQAT9+OfgWf7+b1yK+qDip3X1C0UPMbwHlTfSGWLGZquwhvEFx9k3h/M+VtMvwR1lJ9LUyTAImnNjWG7TAIPmui30HvM2UiFEmqkr4ijq45MyX2+fLIePLRIFuu1p4whjHAQYufqyno3BS48icQb4p6iVEZPo4AE2o9oIyQvj2mx4dk5Y8CgSETOZTYDOR3rU2fZTRDRgPJDH9FWvQjF5tA0p3d9CoWWd2s6GKKbfoUIi8R/Db1BSPJwkqB jrp-hp-pc"
+}
+
+resource "huaweicloud_as_configuration" "hth_as_config" {
+  scaling_configuration_name = "%s"
+
+  instance_config {
+    image         = data.huaweicloud_images_image.test.id
+    flavor        = data.huaweicloud_compute_flavors.test.ids[0]
+    key_name      = huaweicloud_compute_keypair.hth_key.id
+    charging_mode = "spot"
+
+    disk {
+      size        = 40
+      volume_type = "SSD"
+      disk_type   = "SYS"
+    }
+  }
+}
+`, rName, rName)
+}
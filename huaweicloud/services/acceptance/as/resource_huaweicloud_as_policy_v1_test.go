@@ -19,6 +19,7 @@ import (
 func TestAccASV1Policy_basic(t *testing.T) {
 	var asPolicy policies.Policy
 	rName := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(5))
+	resourceName := "huaweicloud_as_policy.acc_as_policy"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
@@ -28,9 +29,14 @@ func TestAccASV1Policy_basic(t *testing.T) {
 			{
 				Config: testASV1Policy_basic(rName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckASV1PolicyExists("huaweicloud_as_policy.acc_as_policy", &asPolicy),
+					testAccCheckASV1PolicyExists(resourceName, &asPolicy),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
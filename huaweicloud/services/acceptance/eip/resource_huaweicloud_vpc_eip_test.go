@@ -173,6 +173,47 @@ func TestAccVpcEIP_prePaid(t *testing.T) {
 	})
 }
 
+func TestAccVpcEIP_changeToPrePaid(t *testing.T) {
+	var eip eips.PublicIp
+
+	randName := acceptance.RandomAccResourceNameWithDash()
+	resourceName := "huaweicloud_vpc_eip.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&eip,
+		getEipResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckChargingMode(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcEip_postPaid(randName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "charging_mode", "postPaid"),
+					resource.TestCheckResourceAttr(resourceName, "expire_time", ""),
+				),
+			},
+			{
+				Config: testAccVpcEip_prePaid(randName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "charging_mode", "prePaid"),
+					resource.TestCheckResourceAttr(resourceName, "period_unit", "month"),
+					resource.TestCheckResourceAttrSet(resourceName, "expire_time"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVpcEIP_ipv6(t *testing.T) {
 	var eip eips.PublicIp
 
@@ -340,6 +381,23 @@ resource "huaweicloud_vpc_eip" "test" {
 `, rName)
 }
 
+func testAccVpcEip_postPaid(rName string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc_eip" "test" {
+  charging_mode = "postPaid"
+
+  publicip {
+    type = "5_bgp"
+  }
+  bandwidth {
+    share_type  = "PER"
+    name        = "%s"
+    size        = 5
+  }
+}
+`, rName)
+}
+
 func testAccVpcEip_ipv6(rName string) string {
 	return fmt.Sprintf(`
 resource "huaweicloud_vpc_eip" "test" {
@@ -71,6 +71,11 @@ func TestAccElbV3LoadBalancer_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.owner", "terraform_update"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -128,6 +133,12 @@ func TestAccElbV3LoadBalancer_withEIP(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "ipv4_eip_id"),
 				),
 			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"iptype", "bandwidth_charge_mode", "sharetype", "bandwidth_size"},
+			},
 		},
 	})
 }
@@ -2,6 +2,7 @@ package evs
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
@@ -93,6 +94,46 @@ func TestAccEvsVolume_basic(t *testing.T) {
 	})
 }
 
+// TestAccEvsVolume_fakeCloud runs the basic case against the in-process fake cloud, no credentials are required.
+func TestAccEvsVolume_fakeCloud(t *testing.T) {
+	var volume cloudvolumes.Volume
+	srv := acceptance.NewFakeCloud(t)
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_evs_volume.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&volume,
+		getVolumeResourceFunc,
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccEvsVolume_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckMultiResourcesExists(4),
+					resource.TestCheckResourceAttr("huaweicloud_evs_volume.test.0", "size", "100"),
+					resource.TestCheckResourceAttr("huaweicloud_evs_volume.test.0", "tags.foo", "bar"),
+					resource.TestCheckResourceAttr("huaweicloud_evs_volume.test.2", "device_type", "SCSI"),
+					resource.TestCheckResourceAttr("huaweicloud_evs_volume.test.3", "multiattach", "true"),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccEvsVolume_update(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckMultiResourcesExists(4),
+					resource.TestCheckResourceAttr("huaweicloud_evs_volume.test.0", "size", "200"),
+					resource.TestCheckResourceAttr("huaweicloud_evs_volume.test.0", "description", "Updated by acc test script."),
+					resource.TestCheckResourceAttr("huaweicloud_evs_volume.test.0", "tags.key", "value1"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccEvsVolume_withEpsId(t *testing.T) {
 	var volume cloudvolumes.Volume
 	rName := acceptance.RandomAccResourceName()
@@ -133,6 +174,52 @@ func TestAccEvsVolume_withEpsId(t *testing.T) {
 	})
 }
 
+// the volume should be migrated to the new enterprise project rather than being replaced
+func TestAccEvsVolume_migrateEpsFakeCloud(t *testing.T) {
+	var volume cloudvolumes.Volume
+	var volumeID string
+	srv := acceptance.NewFakeCloud(t)
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_evs_volume.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&volume,
+		getVolumeResourceFunc,
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccEvsVolume_epsIdWith(rName, "0"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "enterprise_project_id", "0"),
+					func(s *terraform.State) error {
+						volumeID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccEvsVolume_epsIdWith(rName, "fake-eps-id"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "enterprise_project_id", "fake-eps-id"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.ID; id != volumeID {
+							return fmt.Errorf("the volume is replaced: %s -> %s", volumeID, id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccEvsVolume_prePaid(t *testing.T) {
 	var volume cloudvolumes.Volume
 	rName := acceptance.RandomAccResourceName()
@@ -155,6 +242,17 @@ func TestAccEvsVolume_prePaid(t *testing.T) {
 					rc.CheckResourceExists(),
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "charging_mode", "prePaid"),
+					resource.TestCheckResourceAttr(resourceName, "auto_renew", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "expire_time"),
+				),
+			},
+			{
+				Config: testAccEvsVolume_prePaidUpdate(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "charging_mode", "prePaid"),
+					resource.TestCheckResourceAttr(resourceName, "auto_renew", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "expire_time"),
 				),
 			},
 			{
@@ -172,6 +270,121 @@ func TestAccEvsVolume_prePaid(t *testing.T) {
 	})
 }
 
+func TestAccEvsVolume_retype(t *testing.T) {
+	var volume cloudvolumes.Volume
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_evs_volume.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&volume,
+		getVolumeResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEvsVolume_retype(rName, "SAS", ""),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "volume_type", "SAS"),
+					resource.TestCheckResourceAttrPair("huaweicloud_compute_volume_attach.test", "volume_id",
+						resourceName, "id"),
+				),
+			},
+			{
+				// the attached volume is retyped online
+				Config: testAccEvsVolume_retype(rName, "GPSSD2", "iops = 5000\n  throughput = 200"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "volume_type", "GPSSD2"),
+					resource.TestCheckResourceAttr(resourceName, "iops", "5000"),
+					resource.TestCheckResourceAttr(resourceName, "throughput", "200"),
+					resource.TestCheckResourceAttr(resourceName, "attachment.#", "1"),
+				),
+			},
+			{
+				Config: testAccEvsVolume_retype(rName, "GPSSD2", "iops = 6000\n  throughput = 300"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "iops", "6000"),
+					resource.TestCheckResourceAttr(resourceName, "throughput", "300"),
+					resource.TestCheckResourceAttr(resourceName, "attachment.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEvsVolume_retypeFakeCloud(t *testing.T) {
+	var volume cloudvolumes.Volume
+	var volumeID string
+	srv := acceptance.NewFakeCloud(t)
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_evs_volume.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&volume,
+		getVolumeResourceFunc,
+	)
+	checkNotReplaced := func(s *terraform.State) error {
+		id := s.RootModule().Resources[resourceName].Primary.ID
+		if volumeID == "" {
+			volumeID = id
+		} else if id != volumeID {
+			return fmt.Errorf("the volume is replaced: %s -> %s", volumeID, id)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccEvsVolume_qos(rName, "SAS", ""),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "volume_type", "SAS"),
+					resource.TestCheckResourceAttr(resourceName, "iops", "0"),
+					checkNotReplaced,
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccEvsVolume_qos(rName, "GPSSD2", "iops = 5000"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "volume_type", "GPSSD2"),
+					resource.TestCheckResourceAttr(resourceName, "iops", "5000"),
+					resource.TestCheckResourceAttr(resourceName, "throughput", "125"),
+					checkNotReplaced,
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccEvsVolume_qos(rName, "GPSSD2", "iops = 5000\n  throughput = 300"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "iops", "5000"),
+					resource.TestCheckResourceAttr(resourceName, "throughput", "300"),
+					checkNotReplaced,
+				),
+			},
+			{
+				Config:      srv.ProviderConfig() + testAccEvsVolume_qos(rName, "ESSD2", "throughput = 300"),
+				ExpectError: regexp.MustCompile("throughput can not be specified when volume_type is ESSD2"),
+			},
+			{
+				Config:      srv.ProviderConfig() + testAccEvsVolume_qos(rName, "GPSSD2", "iops = 200000"),
+				ExpectError: regexp.MustCompile("the iops of GPSSD2 volume must be between 3000 and 128000"),
+			},
+		},
+	})
+}
+
 func testAccEvsVolume_base() string {
 	return fmt.Sprintf(`
 variable "volume_configuration" {
@@ -253,6 +466,21 @@ resource "huaweicloud_evs_volume" "test" {
 `, rName, acceptance.HW_ENTERPRISE_PROJECT_ID_TEST)
 }
 
+func testAccEvsVolume_epsIdWith(rName, epsID string) string {
+	return fmt.Sprintf(`
+data "huaweicloud_availability_zones" "test" {}
+
+resource "huaweicloud_evs_volume" "test" {
+  name                  = "%s"
+  description           = "test volume for epsID"
+  availability_zone     = data.huaweicloud_availability_zones.test.names[0]
+  volume_type           = "SSD"
+  size                  = 100
+  enterprise_project_id = "%s"
+}
+`, rName, epsID)
+}
+
 func testAccEvsVolume_prePaid(rName string) string {
 	return fmt.Sprintf(`
 data "huaweicloud_availability_zones" "test" {}
@@ -271,3 +499,97 @@ resource "huaweicloud_evs_volume" "test" {
 }
 `, rName)
 }
+
+func testAccEvsVolume_prePaidUpdate(rName string) string {
+	return fmt.Sprintf(`
+data "huaweicloud_availability_zones" "test" {}
+
+resource "huaweicloud_evs_volume" "test" {
+  name              = "%s"
+  description       = "test volume for charging mode"
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  volume_type       = "SSD"
+  size              = 100
+
+  charging_mode = "prePaid"
+  period_unit   = "month"
+  period        = 1
+  auto_renew    = "false"
+}
+`, rName)
+}
+
+func testAccEvsVolume_qos(rName, volumeType, qos string) string {
+	return fmt.Sprintf(`
+data "huaweicloud_availability_zones" "test" {}
+
+resource "huaweicloud_evs_volume" "test" {
+  name              = "%s"
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  volume_type       = "%s"
+  size              = 100
+  %s
+}
+`, rName, volumeType, qos)
+}
+
+func testAccEvsVolume_retype(rName, volumeType, qos string) string {
+	randCidr, randGatewayIp := acceptance.RandomCidrAndGatewayIp()
+
+	return fmt.Sprintf(`
+data "huaweicloud_availability_zones" "test" {}
+
+data "huaweicloud_images_image" "test" {
+  name        = "Ubuntu 18.04 server 64bit"
+  most_recent = true
+}
+
+data "huaweicloud_compute_flavors" "test" {
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  performance_type  = "normal"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+resource "huaweicloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "%[2]s"
+}
+
+resource "huaweicloud_vpc_subnet" "test" {
+  name       = "%[1]s"
+  vpc_id     = huaweicloud_vpc.test.id
+  cidr       = "%[2]s"
+  gateway_ip = "%[3]s"
+}
+
+resource "huaweicloud_networking_secgroup" "test" {
+  name = "%[1]s"
+}
+
+resource "huaweicloud_compute_instance" "test" {
+  availability_zone  = data.huaweicloud_availability_zones.test.names[0]
+  name               = "%[1]s"
+  image_id           = data.huaweicloud_images_image.test.id
+  flavor_id          = data.huaweicloud_compute_flavors.test.ids[0]
+  security_group_ids = [huaweicloud_networking_secgroup.test.id]
+
+  network {
+    uuid = huaweicloud_vpc_subnet.test.id
+  }
+}
+
+resource "huaweicloud_evs_volume" "test" {
+  name              = "%[1]s"
+  availability_zone = data.huaweicloud_availability_zones.test.names[0]
+  volume_type       = "%[4]s"
+  size              = 100
+  %[5]s
+}
+
+resource "huaweicloud_compute_volume_attach" "test" {
+  instance_id = huaweicloud_compute_instance.test.id
+  volume_id   = huaweicloud_evs_volume.test.id
+}
+`, rName, randCidr, randGatewayIp, volumeType, qos)
+}
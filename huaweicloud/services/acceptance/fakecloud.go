@@ -0,0 +1,23 @@
+package acceptance
+
+import (
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance/fakecloud"
+)
+
+// NewFakeCloud starts an in-process fake cloud for the acceptance tests of the core resources, the fake
+// is closed after the test. The region of the fake is HW_REGION_NAME, and it's set to the default region
+// of the fake if empty, so the check functions of the tests can create the clients as usual.
+// The tests should use resource.Test rather than resource.ParallelTest, as the provider instance is shared.
+func NewFakeCloud(t *testing.T) *fakecloud.Server {
+	srv := fakecloud.NewServer()
+	if HW_REGION_NAME != "" {
+		srv.Region = HW_REGION_NAME
+	} else {
+		HW_REGION_NAME = srv.Region
+	}
+
+	t.Cleanup(srv.Close)
+	return srv
+}
@@ -0,0 +1,539 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+func (s *Server) registerECSRoutes() {
+	s.handle("POST", withProject("/v1.1", "cloudservers"), s.createServer)
+	s.handle("GET", withProject("/v1", "cloudservers/detail"), s.listServers)
+	s.handle("GET", withProject("/v1", "cloudservers/"+idPattern), s.getServer)
+	s.handle("POST", withProject("/v1", "cloudservers/delete"), s.deleteServers)
+	s.handle("POST", withProject("/v1", "cloudservers/action"), s.serverAction)
+	s.handle("GET", withProject("/v1", "cloudservers/"+idPattern+"/block_device/"+idPattern), s.getBlockDevice)
+	s.handle("POST", withProject("/v1", "cloudservers/"+idPattern+"/attachvolume"), s.attachVolume)
+	s.handle("DELETE", withProject("/v1", "cloudservers/"+idPattern+"/detachvolume/"+idPattern), s.detachVolume)
+	s.handle("GET", withProject("/v1", "jobs/"+idPattern), s.getJob)
+	s.handle("POST", withProject("/v2", "cloudservers/"+idPattern+"/reinstallos"), s.reinstallServerOS)
+	s.handle("POST", withProject("/v2", "cloudservers/"+idPattern+"/changeos"), s.changeServerOS)
+
+	// the nova APIs are used to list the availability zones and wait for the deletion of the instances
+	s.handle("GET", withProject("/v2.1", "os-availability-zone"), s.listAvailabilityZones)
+	s.handle("GET", withProject("/v2.1", "servers/"+idPattern), s.getNovaServer)
+	s.handle("GET", "/v2.0/ports/"+idPattern, s.getPort)
+	s.handle("GET", withProject("/v1", "ports/"+idPattern), s.getPort)
+	s.handle("GET", "/v2/cloudimages", s.listImages)
+}
+
+// serverView returns the server with the attached volumes
+func (s *Server) serverView(server *object) map[string]interface{} {
+	view := make(map[string]interface{}, len(server.data)+1)
+	for k, v := range server.data {
+		view[k] = v
+	}
+
+	attached := make([]interface{}, 0)
+	for _, volume := range s.attachedVolumes(server.data["id"].(string)) {
+		attached = append(attached, map[string]interface{}{
+			"id":                    volume.data["id"],
+			"device":                volume.refs["device"],
+			"bootIndex":             volume.refs["boot_index"],
+			"delete_on_termination": strconv.FormatBool(volume.refs["boot_index"] == "0"),
+		})
+	}
+	view["os-extended-volumes:volumes_attached"] = attached
+	return view
+}
+
+// attachedVolumes returns the volumes attached to the server which are sorted by the device name
+func (s *Server) attachedVolumes(serverID string) []*object {
+	var result []*object
+	for _, volume := range s.list("volume") {
+		if volume.refs["server_id"] == serverID {
+			result = append(result, volume)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].refs["device"] < result[j].refs["device"]
+	})
+	return result
+}
+
+func (s *Server) createServer(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "server")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	imageID, _ := body["imageRef"].(string)
+	image := s.lookup("image", imageID)
+	if image == nil {
+		writeError(w, http.StatusBadRequest, "Ecs.0023", fmt.Sprintf("the image %s does not exist", imageID))
+		return
+	}
+
+	nics, _ := body["nics"].([]interface{})
+	if len(nics) == 0 {
+		writeError(w, http.StatusBadRequest, "Ecs.0005", "at least one NIC must be specified")
+		return
+	}
+	var subnets []*object
+	for _, raw := range nics {
+		subnetID, _ := raw.(map[string]interface{})["subnet_id"].(string)
+		subnet := s.lookup("subnet", subnetID)
+		if subnet == nil {
+			writeError(w, http.StatusBadRequest, "Ecs.0005", fmt.Sprintf("the subnet %s does not exist", subnetID))
+			return
+		}
+		subnets = append(subnets, subnet)
+	}
+
+	secgroups := make([]interface{}, 0)
+	rawSecgroups, _ := body["security_groups"].([]interface{})
+	for _, raw := range rawSecgroups {
+		sgID, _ := raw.(map[string]interface{})["id"].(string)
+		secgroup := s.lookup("security_group", sgID)
+		if secgroup == nil {
+			writeError(w, http.StatusBadRequest, "Ecs.0005", fmt.Sprintf("the security group %s does not exist", sgID))
+			return
+		}
+		secgroups = append(secgroups, map[string]interface{}{"id": sgID, "name": secgroup.data["name"]})
+	}
+
+	id := s.newID()
+	vpcID, _ := body["vpcid"].(string)
+	addresses := make([]interface{}, 0, len(nics))
+	for i, raw := range nics {
+		port := s.createPort(id, subnets[i], raw.(map[string]interface{})["ip_address"])
+		fixedIP := port.data["fixed_ips"].([]interface{})[0].(map[string]interface{})
+		addresses = append(addresses, map[string]interface{}{
+			"version":                 "4",
+			"addr":                    fixedIP["ip_address"],
+			"OS-EXT-IPS-MAC:mac_addr": port.data["mac_address"],
+			"OS-EXT-IPS:port_id":      port.data["id"],
+			"OS-EXT-IPS:type":         "fixed",
+		})
+	}
+
+	flavorID, _ := body["flavorRef"].(string)
+	epsID, _ := body["enterprise_project_id"].(string)
+	if extendParam, ok := body["extendparam"].(map[string]interface{}); ok && epsID == "" {
+		epsID, _ = extendParam["enterprise_project_id"].(string)
+	}
+	if epsID == "" {
+		epsID = "0"
+	}
+
+	chargingMode := "0"
+	if extendParam, ok := body["extendparam"].(map[string]interface{}); ok && extendParam["marketType"] == "spot" {
+		chargingMode = "2"
+	}
+	metadata := map[string]interface{}{
+		"charging_mode": chargingMode,
+		"vpc_id":        vpcID,
+		"image_name":    image.data["name"],
+		"os_bit":        "64",
+	}
+	if v, ok := body["metadata"].(map[string]interface{}); ok {
+		mergeInto(metadata, v)
+	}
+
+	server := s.create("server", map[string]interface{}{
+		"id":                          id,
+		"name":                        body["name"],
+		"description":                 body["description"],
+		"flavor":                      map[string]interface{}{"id": flavorID, "name": flavorID},
+		"image":                       map[string]interface{}{"id": imageID},
+		"key_name":                    body["key_name"],
+		"metadata":                    metadata,
+		"addresses":                   map[string]interface{}{vpcID: addresses},
+		"security_groups":             secgroups,
+		"OS-EXT-AZ:availability_zone": body["availability_zone"],
+		"OS-EXT-STS:vm_state":         "active",
+		"enterprise_project_id":       epsID,
+		"tenant_id":                   s.ProjectID,
+		"user_id":                     "fake-user-id",
+		"created":                     nowString(),
+		"updated":                     nowString(),
+		"os:scheduler_hints":          map[string]interface{}{},
+	}, "BUILD", "ACTIVE")
+	server.refs["subnet_id"] = subnets[0].data["id"].(string)
+	server.refs["vpc_id"] = vpcID
+
+	// the system disk and data disks are created with the instance
+	if rootVolume, ok := body["root_volume"].(map[string]interface{}); ok {
+		size := rootVolume["size"]
+		if size == nil {
+			size = image.data["min_disk"]
+		}
+		s.createAttachedVolume(id, body["availability_zone"], rootVolume["volumetype"], size, "0", imageID)
+	}
+	dataVolumes, _ := body["data_volumes"].([]interface{})
+	for i, raw := range dataVolumes {
+		volume := raw.(map[string]interface{})
+		s.createAttachedVolume(id, body["availability_zone"], volume["volumetype"], volume["size"], strconv.Itoa(i+1), "")
+	}
+
+	if tags, ok := body["server_tags"].([]interface{}); ok {
+		server.tags = tags
+	}
+
+	jobID := s.createJob("createServer", map[string]interface{}{"server_id": id}, server)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"job_id":    jobID,
+		"serverIds": []string{id},
+	})
+}
+
+func (s *Server) createPort(serverID string, subnet *object, ipAddress interface{}) *object {
+	seq := s.nextSeq()
+	if ip, ok := ipAddress.(string); !ok || ip == "" {
+		ipAddress = fmt.Sprintf("192.168.%d.%d", seq/250%250, seq%250+2)
+	}
+
+	return s.create("port", map[string]interface{}{
+		"name":       "",
+		"network_id": subnet.data["id"],
+		"fixed_ips": []interface{}{
+			map[string]interface{}{
+				"subnet_id":  subnet.data["neutron_subnet_id"],
+				"ip_address": ipAddress,
+			},
+		},
+		"mac_address":           fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", seq>>16&0xff, seq>>8&0xff, seq&0xff),
+		"allowed_address_pairs": []interface{}{},
+		"device_id":             serverID,
+		"device_owner":          "compute:" + fmt.Sprint(subnet.data["availability_zone"]),
+		"admin_state_up":        true,
+		"tenant_id":             s.ProjectID,
+		"project_id":            s.ProjectID,
+	}, "", "ACTIVE")
+}
+
+func (s *Server) createAttachedVolume(serverID string, az, volumeType, size interface{}, bootIndex, imageID string) {
+	volume := s.create("volume", s.newVolumeData(map[string]interface{}{
+		"name":              fmt.Sprintf("volume-%s-%s", serverID[len(serverID)-4:], bootIndex),
+		"availability_zone": az,
+		"volume_type":       volumeType,
+		"size":              size,
+	}), "", "available")
+	if imageID != "" {
+		volume.data["bootable"] = "true"
+		volume.data["volume_image_metadata"] = map[string]interface{}{"image_id": imageID}
+	}
+	s.attach(volume, serverID, bootIndex)
+}
+
+// attach attaches the volume to the server with the device name of the boot index
+func (s *Server) attach(volume *object, serverID, bootIndex string) {
+	index, _ := strconv.Atoi(bootIndex)
+	volume.refs["server_id"] = serverID
+	volume.refs["boot_index"] = bootIndex
+	volume.refs["device"] = fmt.Sprintf("/dev/vd%c", 'a'+index)
+}
+
+func detach(volume *object) {
+	delete(volume.refs, "server_id")
+	delete(volume.refs, "boot_index")
+	delete(volume.refs, "device")
+}
+
+func (s *Server) getServer(w http.ResponseWriter, r *http.Request, params []string) {
+	server := s.get("server", params[0])
+	if server == nil {
+		writeError(w, http.StatusNotFound, "Ecs.0114", fmt.Sprintf("the instance %s does not exist", params[0]))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"server": s.serverView(server)})
+}
+
+func (s *Server) listServers(w http.ResponseWriter, r *http.Request, _ []string) {
+	servers := make([]*object, 0)
+	for _, server := range s.list("server") {
+		servers = append(servers, &object{data: s.serverView(server)})
+	}
+
+	result := filterObjects(servers, r, "name", "status", "enterprise_project_id")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"servers": result,
+		"count":   len(result),
+	})
+}
+
+// listAvailabilityZones returns three available zones of the region, e.g. cn-north-4a
+func (s *Server) listAvailabilityZones(w http.ResponseWriter, r *http.Request, _ []string) {
+	zones := make([]interface{}, 0, 3)
+	for _, suffix := range []string{"a", "b", "c"} {
+		zones = append(zones, map[string]interface{}{
+			"zoneName":  s.Region + suffix,
+			"zoneState": map[string]interface{}{"available": true},
+			"hosts":     nil,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"availabilityZoneInfo": zones})
+}
+
+// getNovaServer returns the server with the fields of nova API, the deleted server returns 404
+func (s *Server) getNovaServer(w http.ResponseWriter, r *http.Request, params []string) {
+	server := s.get("server", params[0])
+	if server == nil {
+		writeError(w, http.StatusNotFound, "itemNotFound", fmt.Sprintf("Instance %s could not be found.", params[0]))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"server": map[string]interface{}{
+			"id":        server.data["id"],
+			"name":      server.data["name"],
+			"status":    server.data["status"],
+			"tenant_id": s.ProjectID,
+			"user_id":   "fake-user-id",
+			"flavor":    server.data["flavor"],
+			"image":     server.data["image"],
+			"metadata":  map[string]interface{}{},
+			"addresses": server.data["addresses"],
+			"created":   server.data["created"],
+			"updated":   server.data["updated"],
+		},
+	})
+}
+
+// deleteServers deletes the servers asynchronously with their ports and system disks,
+// the data disks are deleted if delete_volume is true, otherwise they are detached.
+func (s *Server) deleteServers(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	deleteVolume, _ := body["delete_volume"].(bool)
+	servers, _ := body["servers"].([]interface{})
+	var serverIDs []string
+	for _, raw := range servers {
+		id, _ := raw.(map[string]interface{})["id"].(string)
+		server := s.lookup("server", id)
+		if server == nil || server.deleting {
+			writeError(w, http.StatusNotFound, "Ecs.0114", fmt.Sprintf("the instance %s does not exist", id))
+			return
+		}
+		serverIDs = append(serverIDs, id)
+	}
+
+	var targets []*object
+	for _, id := range serverIDs {
+		for _, volume := range s.attachedVolumes(id) {
+			if deleteVolume || volume.refs["boot_index"] == "0" {
+				detach(volume)
+				s.remove("volume", volume.data["id"].(string), "deleting")
+			} else {
+				detach(volume)
+			}
+		}
+		for _, port := range s.list("port") {
+			if port.data["device_id"] == id {
+				s.remove("port", port.data["id"].(string), "")
+			}
+		}
+
+		server := s.lookup("server", id)
+		s.remove("server", id, server.data["status"].(string))
+		targets = append(targets, server)
+	}
+
+	jobID := s.createJob("deleteServer", map[string]interface{}{"server_ids": serverIDs}, targets...)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+// serverAction starts, stops or reboots the servers
+func (s *Server) serverAction(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	actions := map[string]string{
+		"os-start": "ACTIVE",
+		"os-stop":  "SHUTOFF",
+		"reboot":   "ACTIVE",
+	}
+	for action, status := range actions {
+		opts, ok := body[action].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		servers, _ := opts["servers"].([]interface{})
+		for _, raw := range servers {
+			id, _ := raw.(map[string]interface{})["id"].(string)
+			if server := s.lookup("server", id); server != nil && !server.deleting {
+				server.data["status"] = status
+				server.readyStatus = status
+			}
+		}
+		jobID := s.createJob(action, map[string]interface{}{})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+		return
+	}
+	writeError(w, http.StatusBadRequest, "Ecs.0005", "the action is not supported")
+}
+
+func (s *Server) reinstallServerOS(w http.ResponseWriter, r *http.Request, params []string) {
+	s.rebuildServer(w, r, params[0], "os-reinstall")
+}
+
+func (s *Server) changeServerOS(w http.ResponseWriter, r *http.Request, params []string) {
+	s.rebuildServer(w, r, params[0], "os-change")
+}
+
+// rebuildServer rebuilds the system disk of the server with the current image for os-reinstall, or with the
+// image of the request for os-change. The data disks and the NICs are kept.
+func (s *Server) rebuildServer(w http.ResponseWriter, r *http.Request, serverID, key string) {
+	server := s.lookup("server", serverID)
+	if server == nil || server.deleting {
+		writeError(w, http.StatusNotFound, "Ecs.0114", fmt.Sprintf("the instance %s does not exist", serverID))
+		return
+	}
+
+	body, err := readBody(r, key)
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	if body["adminpass"] == nil && body["keyname"] == nil {
+		writeError(w, http.StatusBadRequest, "Ecs.0005", "either adminpass or keyname must be specified")
+		return
+	}
+
+	imageID, _ := server.data["image"].(map[string]interface{})["id"].(string)
+	if key == "os-change" {
+		imageID, _ = body["imageid"].(string)
+	}
+	image := s.lookup("image", imageID)
+	if image == nil {
+		writeError(w, http.StatusBadRequest, "Ecs.0023", fmt.Sprintf("the image %s does not exist", imageID))
+		return
+	}
+
+	server.data["image"] = map[string]interface{}{"id": imageID}
+	server.data["key_name"] = body["keyname"]
+	server.data["metadata"].(map[string]interface{})["image_name"] = image.data["name"]
+	if metadata, ok := body["metadata"].(map[string]interface{}); ok {
+		server.data["OS-EXT-SRV-ATTR:user_data"] = metadata["user_data"]
+	}
+	server.data["updated"] = nowString()
+	server.readyStatus = "ACTIVE"
+	server.data["status"] = "ACTIVE"
+	if s.PendingPolls > 0 {
+		server.pending = s.PendingPolls
+		server.data["status"] = "REBUILD"
+	}
+	for _, volume := range s.attachedVolumes(serverID) {
+		if volume.refs["boot_index"] == "0" {
+			volume.data["volume_image_metadata"] = map[string]interface{}{"image_id": imageID}
+		}
+	}
+
+	jobID := s.createJob(key, map[string]interface{}{"server_id": serverID}, server)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+func (s *Server) getBlockDevice(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.lookup("volume", params[1])
+	if s.lookup("server", params[0]) == nil || volume == nil || volume.refs["server_id"] != params[0] {
+		writeError(w, http.StatusNotFound, "Ecs.0041", fmt.Sprintf("the volume %s is not attached to the instance %s",
+			params[1], params[0]))
+		return
+	}
+
+	bootIndex, _ := strconv.Atoi(volume.refs["boot_index"])
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"volumeAttachment": map[string]interface{}{
+			"serverId":   params[0],
+			"volumeId":   params[1],
+			"id":         params[1],
+			"device":     volume.refs["device"],
+			"pciAddress": fmt.Sprintf("0000:02:%02x.0", bootIndex+1),
+			"size":       volume.data["size"],
+			"bootIndex":  bootIndex,
+			"bus":        "virtio",
+		},
+	})
+}
+
+// attachVolume attaches an available volume to the server, the volume in use returns 409
+func (s *Server) attachVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	if server := s.lookup("server", params[0]); server == nil || server.deleting {
+		writeError(w, http.StatusNotFound, "Ecs.0114", fmt.Sprintf("the instance %s does not exist", params[0]))
+		return
+	}
+
+	body, err := readBody(r, "volumeAttachment")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	volumeID, _ := body["volumeId"].(string)
+	volume := s.lookup("volume", volumeID)
+	if volume == nil || volume.deleting {
+		writeError(w, http.StatusBadRequest, "Ecs.0005", fmt.Sprintf("the volume %s does not exist", volumeID))
+		return
+	}
+	if volume.refs["server_id"] != "" {
+		writeError(w, http.StatusConflict, "Ecs.0044", fmt.Sprintf("the volume %s is in use", volumeID))
+		return
+	}
+
+	bootIndex := len(s.attachedVolumes(params[0]))
+	s.attach(volume, params[0], strconv.Itoa(bootIndex))
+	jobID := s.createJob("attachVolume", map[string]interface{}{"server_id": params[0], "volume_id": volumeID})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+func (s *Server) detachVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.lookup("volume", params[1])
+	if s.lookup("server", params[0]) == nil || volume == nil || volume.refs["server_id"] != params[0] {
+		writeError(w, http.StatusNotFound, "Ecs.0041", fmt.Sprintf("the volume %s is not attached to the instance %s",
+			params[1], params[0]))
+		return
+	}
+
+	detach(volume)
+	jobID := s.createJob("detachVolume", map[string]interface{}{"server_id": params[0], "volume_id": params[1]})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request, params []string) {
+	job := s.get("job", params[0])
+	if job == nil {
+		writeError(w, http.StatusNotFound, "Common.0011", fmt.Sprintf("the job %s does not exist", params[0]))
+		return
+	}
+	if job.data["status"] == "SUCCESS" {
+		if _, ok := job.data["end_time"]; !ok {
+			job.data["end_time"] = nowString()
+		}
+		s.completeJob(params[0])
+	}
+	writeJSON(w, http.StatusOK, job.data)
+}
+
+func (s *Server) getPort(w http.ResponseWriter, r *http.Request, params []string) {
+	port := s.get("port", params[0])
+	if port == nil {
+		writeError(w, http.StatusNotFound, "PortNotFound", fmt.Sprintf("Port %s could not be found.", params[0]))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"port": port.data})
+}
+
+func (s *Server) listImages(w http.ResponseWriter, r *http.Request, _ []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"images": filterObjects(s.list("image"), r, "id", "name", "__imagetype", "virtual_env_type"),
+	})
+}
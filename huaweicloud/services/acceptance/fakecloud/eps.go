@@ -0,0 +1,81 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// the kinds of the objects which can be migrated between the enterprise projects, by the resource types of EPS
+var epsResourceKinds = map[string]string{
+	"ecs":  "server",
+	"disk": "volume",
+	"vpc":  "vpc",
+}
+
+func (s *Server) registerEPSRoutes() {
+	s.handle("POST", "/v1.0/enterprise-projects/"+idPattern+"/resources-migrate", s.migrateResource)
+	s.handle("POST", "/v1.0/enterprise-projects/"+idPattern+"/resources/filter", s.filterResources)
+}
+
+// migrateResource moves the resource to the enterprise project immediately
+func (s *Server) migrateResource(w http.ResponseWriter, r *http.Request, params []string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	resourceType, _ := body["resource_type"].(string)
+	kind, ok := epsResourceKinds[resourceType]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "EPS.0004",
+			fmt.Sprintf("the resource type %s is not supported by the fake cloud", resourceType))
+		return
+	}
+	if projectID, _ := body["project_id"].(string); projectID != s.ProjectID {
+		writeError(w, http.StatusBadRequest, "EPS.0004", fmt.Sprintf("the project %s does not exist", projectID))
+		return
+	}
+
+	resourceID, _ := body["resource_id"].(string)
+	obj := s.lookup(kind, resourceID)
+	if obj == nil || obj.deleting {
+		writeError(w, http.StatusNotFound, "EPS.0006", fmt.Sprintf("the %s %s does not exist", resourceType, resourceID))
+		return
+	}
+
+	obj.data["enterprise_project_id"] = params[0]
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// filterResources lists the resources of the enterprise project with the resource types
+func (s *Server) filterResources(w http.ResponseWriter, r *http.Request, params []string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	resources := make([]interface{}, 0)
+	types, _ := body["resource_types"].([]interface{})
+	for _, raw := range types {
+		resourceType := fmt.Sprint(raw)
+		for _, obj := range s.list(epsResourceKinds[resourceType]) {
+			if obj.data["enterprise_project_id"] != params[0] {
+				continue
+			}
+			resources = append(resources, map[string]interface{}{
+				"resource_id":   obj.data["id"],
+				"resource_name": obj.data["name"],
+				"resource_type": resourceType,
+				"project_id":    s.ProjectID,
+			})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"resources":   resources,
+		"errors":      []interface{}{},
+		"total_count": len(resources),
+	})
+}
@@ -0,0 +1,347 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// the volume types supported by the fake
+var volumeTypes = map[string]bool{
+	"SATA":   true,
+	"SAS":    true,
+	"SSD":    true,
+	"GPSSD":  true,
+	"ESSD":   true,
+	"GPSSD2": true,
+	"ESSD2":  true,
+}
+
+func (s *Server) registerEVSRoutes() {
+	s.handle("POST", withProject("/v2.1", "cloudvolumes"), s.createVolume)
+	s.handle("POST", withProject("/v2.1", "cloudvolumes/"+idPattern+"/action"), s.volumeAction)
+	s.handle("GET", withProject("/v2", "cloudvolumes/detail"), s.listVolumes)
+	s.handle("GET", withProject("/v2", "cloudvolumes/"+idPattern), s.getVolume)
+	s.handle("PUT", withProject("/v2", "cloudvolumes/"+idPattern), s.updateVolume)
+	s.handle("DELETE", withProject("/v2", "cloudvolumes/"+idPattern), s.deleteVolume)
+	s.handle("POST", withProject("/v2", "volumes/"+idPattern+"/retype"), s.retypeVolume)
+	s.handle("PUT", withProject("/v5", "cloudvolumes/"+idPattern+"/qos"), s.updateVolumeQoS)
+}
+
+// the default and the valid ranges of the provisioned IOPS and throughput of the volume types
+var volumeQoSLimits = map[string]map[string][3]float64{
+	"GPSSD2": {"iops": {3000, 3000, 128000}, "throughput": {125, 125, 1000}},
+	"ESSD2":  {"iops": {3000, 100, 256000}},
+}
+
+// buildVolumeQoS returns the provisioned IOPS and throughput of the volume type with the values of the request
+// body, the unspecified values are the defaults. False is returned if the values are invalid for the volume type.
+func buildVolumeQoS(volumeType string, body map[string]interface{}) (map[string]float64, bool) {
+	qos := make(map[string]float64)
+	for _, key := range []string{"iops", "throughput"} {
+		value, specified := body[key].(float64)
+		limit, ok := volumeQoSLimits[volumeType][key]
+		if !ok {
+			if specified {
+				return nil, false
+			}
+			continue
+		}
+
+		if !specified {
+			value = limit[0]
+		}
+		if value < limit[1] || value > limit[2] {
+			return nil, false
+		}
+		qos[key] = value
+	}
+	return qos, true
+}
+
+// setVolumeQoS replaces the provisioned IOPS and throughput of the volume
+func setVolumeQoS(volume *object, qos map[string]float64) {
+	for _, key := range []string{"iops", "throughput"} {
+		value, ok := qos[key]
+		if !ok {
+			delete(volume.data, key)
+			continue
+		}
+		volume.data[key] = map[string]interface{}{
+			"id":        fmt.Sprintf("fake-%s-id", key),
+			"volume_id": volume.data["id"],
+			"frozened":  false,
+			"total_val": value,
+		}
+	}
+}
+
+// newVolumeData returns the volume with the default values of the fields which are not in the request body
+func (s *Server) newVolumeData(body map[string]interface{}) map[string]interface{} {
+	data := map[string]interface{}{
+		"name":                         "",
+		"description":                  "",
+		"bootable":                     "false",
+		"multiattach":                  false,
+		"encrypted":                    false,
+		"service_type":                 "EVS",
+		"enterprise_project_id":        "0",
+		"os-vol-tenant-attr:tenant_id": s.ProjectID,
+		"user_id":                      "fake-user-id",
+		"links":                        []interface{}{},
+		"created_at":                   nowString(),
+		"updated_at":                   nowString(),
+		"metadata":                     map[string]interface{}{},
+	}
+	mergeInto(data, body)
+	delete(data, "tags")
+	data["wwn"] = fmt.Sprintf("688860300000%020d", s.nextSeq())
+	return data
+}
+
+// volumeView returns the volume with the attachments and tags, the status of a ready volume
+// is in-use or available according to the attachments.
+func (s *Server) volumeView(volume *object) map[string]interface{} {
+	view := make(map[string]interface{}, len(volume.data)+2)
+	for k, v := range volume.data {
+		view[k] = v
+	}
+
+	attachments := make([]interface{}, 0)
+	if serverID := volume.refs["server_id"]; serverID != "" {
+		attachments = append(attachments, map[string]interface{}{
+			"id":            volume.data["id"],
+			"attachment_id": volume.data["id"],
+			"volume_id":     volume.data["id"],
+			"server_id":     serverID,
+			"device":        volume.refs["device"],
+			"attached_at":   nowString(),
+		})
+		if view["status"] == "available" {
+			view["status"] = "in-use"
+		}
+	}
+	view["attachments"] = attachments
+
+	tags := make(map[string]interface{})
+	for _, raw := range volume.tags {
+		tag := raw.(map[string]interface{})
+		tags[fmt.Sprint(tag["key"])] = tag["value"]
+	}
+	view["tags"] = tags
+	return view
+}
+
+// createVolume creates a pay-per-use volume with a job, the prepaid volumes are not supported
+func (s *Server) createVolume(w http.ResponseWriter, r *http.Request, _ []string) {
+	raw, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	body, ok := raw["volume"].(map[string]interface{})
+	if !ok {
+		writeBadRequest(w, fmt.Errorf("the \"volume\" object is missing in the request body"))
+		return
+	}
+	if _, ok := raw["bss_param"]; ok {
+		writeError(w, http.StatusBadRequest, "EVS.2024", "the prepaid volumes are not supported by the fake cloud")
+		return
+	}
+
+	volumeType, _ := body["volume_type"].(string)
+	if !volumeTypes[volumeType] {
+		writeError(w, http.StatusBadRequest, "EVS.2029", fmt.Sprintf("the volume type %s is invalid", volumeType))
+		return
+	}
+	if size, _ := body["size"].(float64); size < 10 || size > 32768 {
+		writeError(w, http.StatusBadRequest, "EVS.5400", fmt.Sprintf("the volume size %v is invalid", body["size"]))
+		return
+	}
+
+	qos, ok := buildVolumeQoS(volumeType, body)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "EVS.2060",
+			fmt.Sprintf("the IOPS or throughput is invalid for the volume type %s", volumeType))
+		return
+	}
+
+	volume := s.create("volume", s.newVolumeData(body), "creating", "available")
+	setVolumeQoS(volume, qos)
+	if metadata, ok := body["metadata"].(map[string]interface{}); ok && metadata["__system__encrypted"] == "1" {
+		volume.data["encrypted"] = true
+	}
+	if tags, ok := body["tags"].(map[string]interface{}); ok {
+		for k, v := range tags {
+			volume.tags = append(volume.tags, map[string]interface{}{"key": k, "value": v})
+		}
+	}
+
+	id := volume.data["id"].(string)
+	jobID := s.createJob("createVolume", map[string]interface{}{"volume_id": id}, volume)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"job_id":     jobID,
+		"volume_ids": []string{id},
+	})
+}
+
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request, _ []string) {
+	volumes := make([]*object, 0)
+	for _, volume := range s.list("volume") {
+		volumes = append(volumes, &object{data: s.volumeView(volume)})
+	}
+
+	result := filterObjects(volumes, r, "id", "name", "status", "availability_zone", "volume_type_id")
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"volumes": result,
+		"count":   len(result),
+	})
+}
+
+func (s *Server) getVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.get("volume", params[0])
+	if volume == nil {
+		writeError(w, http.StatusNotFound, "EVS.0001", fmt.Sprintf("the volume %s does not exist", params[0]))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": s.volumeView(volume)})
+}
+
+func (s *Server) updateVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.lookup("volume", params[0])
+	if volume == nil || volume.deleting {
+		writeError(w, http.StatusNotFound, "EVS.0001", fmt.Sprintf("the volume %s does not exist", params[0]))
+		return
+	}
+
+	body, err := readBody(r, "volume")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	mergeInto(volume.data, body)
+	volume.data["updated_at"] = nowString()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"volume": s.volumeView(volume)})
+}
+
+// deleteVolume deletes the volume asynchronously, the volume in use returns 409
+func (s *Server) deleteVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.lookup("volume", params[0])
+	if volume == nil || volume.deleting {
+		writeError(w, http.StatusNotFound, "EVS.0001", fmt.Sprintf("the volume %s does not exist", params[0]))
+		return
+	}
+	if volume.refs["server_id"] != "" {
+		writeError(w, http.StatusConflict, "EVS.2009",
+			fmt.Sprintf("the volume %s is attached to the instance %s", params[0], volume.refs["server_id"]))
+		return
+	}
+
+	s.remove("volume", params[0], "deleting")
+	jobID := s.createJob("deleteVolume", map[string]interface{}{"volume_id": params[0]}, volume)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+// volumeAction extends the size of the volume, the volume stays in extending status for PendingPolls GET requests
+func (s *Server) volumeAction(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.lookup("volume", params[0])
+	if volume == nil || volume.deleting {
+		writeError(w, http.StatusNotFound, "EVS.0001", fmt.Sprintf("the volume %s does not exist", params[0]))
+		return
+	}
+
+	body, err := readBody(r, "os-extend")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	newSize, _ := body["new_size"].(float64)
+	oldSize, _ := volume.data["size"].(float64)
+	if newSize <= oldSize {
+		writeError(w, http.StatusBadRequest, "EVS.2027",
+			fmt.Sprintf("the new size %v must be greater than the current size %v", newSize, oldSize))
+		return
+	}
+
+	volume.data["size"] = newSize
+	if s.PendingPolls > 0 {
+		volume.data["status"] = "extending"
+		volume.pending = s.PendingPolls
+	}
+	jobID := s.createJob("extendVolume", map[string]interface{}{"volume_id": params[0]}, volume)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"job_id": jobID})
+}
+
+// retypeVolume changes the volume type online, the volume stays in retyping status for PendingPolls GET requests
+func (s *Server) retypeVolume(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.lookup("volume", params[0])
+	if volume == nil || volume.deleting {
+		writeError(w, http.StatusNotFound, "EVS.0001", fmt.Sprintf("the volume %s does not exist", params[0]))
+		return
+	}
+
+	body, err := readBody(r, "os-retype")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	newType, _ := body["new_type"].(string)
+	if !volumeTypes[newType] || newType == volume.data["volume_type"] {
+		writeError(w, http.StatusBadRequest, "EVS.2029", fmt.Sprintf("the volume type %s is invalid", newType))
+		return
+	}
+
+	// the IOPS and throughput of the old type are not inherited
+	qos, ok := buildVolumeQoS(newType, body)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "EVS.2060",
+			fmt.Sprintf("the IOPS or throughput is invalid for the volume type %s", newType))
+		return
+	}
+
+	setVolumeQoS(volume, qos)
+	volume.data["volume_type"] = newType
+	volume.data["updated_at"] = nowString()
+	if s.PendingPolls > 0 {
+		volume.data["status"] = "retyping"
+		volume.pending = s.PendingPolls
+	}
+	jobID := s.createJob("retypeVolume", map[string]interface{}{"volume_id": params[0]}, volume)
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{"job_id": jobID})
+}
+
+// updateVolumeQoS changes the provisioned IOPS and throughput of the GPSSD2 and ESSD2 volumes
+func (s *Server) updateVolumeQoS(w http.ResponseWriter, r *http.Request, params []string) {
+	volume := s.lookup("volume", params[0])
+	if volume == nil || volume.deleting {
+		writeError(w, http.StatusNotFound, "EVS.0001", fmt.Sprintf("the volume %s does not exist", params[0]))
+		return
+	}
+
+	body, err := readBody(r, "qos_modify")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	// the unspecified values are not changed
+	volumeType, _ := volume.data["volume_type"].(string)
+	for _, key := range []string{"iops", "throughput"} {
+		if current, ok := volume.data[key].(map[string]interface{}); ok && body[key] == nil {
+			body[key] = current["total_val"]
+		}
+	}
+	qos, ok := buildVolumeQoS(volumeType, body)
+	if _, supported := volumeQoSLimits[volumeType]; !supported || !ok {
+		writeError(w, http.StatusBadRequest, "EVS.2060",
+			fmt.Sprintf("the IOPS or throughput is invalid for the volume type %s", volumeType))
+		return
+	}
+
+	setVolumeQoS(volume, qos)
+	volume.data["updated_at"] = nowString()
+	if s.PendingPolls > 0 {
+		volume.data["status"] = "updating"
+		volume.pending = s.PendingPolls
+	}
+	jobID := s.createJob("modifyVolumeQoS", map[string]interface{}{"volume_id": params[0]}, volume)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
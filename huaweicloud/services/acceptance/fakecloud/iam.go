@@ -0,0 +1,126 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+func (s *Server) registerIAMRoutes() {
+	s.handle("POST", "/v3/auth/tokens", s.createToken)
+	s.handle("GET", "/v3/projects", s.listProjects)
+	s.handle("GET", "/v3/auth/projects", s.listProjects)
+	s.handle("GET", "/v3/auth/domains", s.listDomains)
+	s.handle("GET", "/v3/auth/catalog", s.listCatalog)
+}
+
+func (s *Server) project() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        s.ProjectID,
+		"name":      s.Region,
+		"domain_id": s.DomainID,
+		"enabled":   true,
+		"is_domain": false,
+		"parent_id": s.DomainID,
+	}
+}
+
+// createToken issues a token for any password, token or agency authentication
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request, _ []string) {
+	if _, err := readBody(r, "auth"); err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	w.Header().Set("X-Subject-Token", fmt.Sprintf("fake-token-%d", s.nextSeq()))
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"token": map[string]interface{}{
+			"methods":    []string{"password"},
+			"expires_at": time.Now().Add(24 * time.Hour).UTC().Format("2006-01-02T15:04:05.000000Z"),
+			"issued_at":  time.Now().UTC().Format("2006-01-02T15:04:05.000000Z"),
+			"user": map[string]interface{}{
+				"id":   "fake-user-id",
+				"name": "fake-user",
+				"domain": map[string]interface{}{
+					"id":   s.DomainID,
+					"name": s.DomainName,
+				},
+			},
+			"project": map[string]interface{}{
+				"id":   s.ProjectID,
+				"name": s.Region,
+				"domain": map[string]interface{}{
+					"id":   s.DomainID,
+					"name": s.DomainName,
+				},
+			},
+			"catalog": []interface{}{},
+		},
+	})
+}
+
+// listProjects returns the only project of the region, it's used by loadUserProjects
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, _ []string) {
+	projects := []interface{}{}
+	if name := r.URL.Query().Get("name"); name == "" || name == s.Region {
+		projects = append(projects, s.project())
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"projects": projects,
+		"links": map[string]interface{}{
+			"self": s.server.URL + r.URL.Path,
+		},
+	})
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request, _ []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domains": []interface{}{
+			map[string]interface{}{
+				"id":      s.DomainID,
+				"name":    s.DomainName,
+				"enabled": true,
+			},
+		},
+		"links": map[string]interface{}{
+			"self": s.server.URL + r.URL.Path,
+		},
+	})
+}
+
+// listCatalog returns the catalog in which all the services of the fake are served by the fake itself,
+// the global services have the region "*"
+func (s *Server) listCatalog(w http.ResponseWriter, r *http.Request, _ []string) {
+	services := []interface{}{}
+	for _, srv := range []struct{ name, region, path string }{
+		{"iam", "*", "/v3"},
+		{"eps", "*", "/v1.0"},
+		{"vpc", s.Region, "/v1/" + s.ProjectID},
+		{"ecs", s.Region, "/v2.1/" + s.ProjectID},
+		{"evs", s.Region, "/v2/" + s.ProjectID},
+		{"ims", s.Region, "/v2"},
+	} {
+		services = append(services, map[string]interface{}{
+			"id":   "fake-" + srv.name,
+			"name": srv.name,
+			"type": srv.name,
+			"endpoints": []interface{}{
+				map[string]interface{}{
+					"id":        "fake-" + srv.name + "-public",
+					"interface": "public",
+					"region":    srv.region,
+					"region_id": srv.region,
+					"url":       s.server.URL + srv.path,
+				},
+			},
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"catalog": services,
+		"links": map[string]interface{}{
+			"self": s.server.URL + r.URL.Path,
+		},
+	})
+}
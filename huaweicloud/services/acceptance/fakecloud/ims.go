@@ -0,0 +1,325 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *Server) registerIMSRoutes() {
+	s.handle("POST", "/v1/cloudimages/members", s.shareImages)
+	s.handle("PUT", "/v1/cloudimages/members", s.updateImageMembers)
+	s.handle("DELETE", "/v1/cloudimages/members", s.unshareImages)
+	s.handle("POST", "/v1/cloudimages/"+idPattern+"/copy", s.copyImage)
+	s.handle("POST", "/v1/cloudimages/"+idPattern+"/cross_region_copy", s.copyImage)
+	s.handle("POST", "/v1/cloudimages/wholeimages/action", s.createWholeImage)
+	s.handle("POST", "/v2/cloudimages/action", s.createImage)
+	s.handle("GET", "/v2/images/"+idPattern+"/members", s.listImageMembers)
+	s.handle("GET", "/v2/images/"+idPattern+"/members/"+idPattern, s.getImageMember)
+	s.handle("GET", "/v2/images/"+idPattern, s.getGlanceImage)
+	s.handle("DELETE", "/v2/images/"+idPattern, s.deleteImage)
+}
+
+// imageMemberID returns the key of the project which the image is shared with
+func imageMemberID(imageID, projectID string) string {
+	return imageID + "/" + projectID
+}
+
+// readImageMembersBody parses the request body of the image sharing APIs, all the images should exist
+func (s *Server) readImageMembersBody(w http.ResponseWriter, r *http.Request) (map[string]interface{}, []string, bool) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return nil, nil, false
+	}
+
+	rawImages, _ := body["images"].([]interface{})
+	if len(rawImages) == 0 {
+		writeError(w, http.StatusBadRequest, "IMG.0001", "the images are missing in the request body")
+		return nil, nil, false
+	}
+	images := make([]string, len(rawImages))
+	for i, raw := range rawImages {
+		images[i], _ = raw.(string)
+		if s.lookup("image", images[i]) == nil {
+			writeError(w, http.StatusNotFound, "IMG.0057", fmt.Sprintf("the image %s does not exist", images[i]))
+			return nil, nil, false
+		}
+	}
+	return body, images, true
+}
+
+// shareImages shares the images with the projects, the members are pending until they are accepted
+func (s *Server) shareImages(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, images, ok := s.readImageMembersBody(w, r)
+	if !ok {
+		return
+	}
+
+	projects, _ := body["projects"].([]interface{})
+	for _, imageID := range images {
+		for _, project := range projects {
+			projectID, _ := project.(string)
+			s.create("image_member", map[string]interface{}{
+				"id":         imageMemberID(imageID, projectID),
+				"member_id":  projectID,
+				"image_id":   imageID,
+				"created_at": nowString(),
+				"updated_at": nowString(),
+				"schema":     "/v2/schemas/member",
+			}, "", "pending")
+		}
+	}
+
+	jobID := s.createJob("batchAddMembers", map[string]interface{}{"image_ids": images})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+// updateImageMembers accepts or rejects the images shared with the project
+func (s *Server) updateImageMembers(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, images, ok := s.readImageMembersBody(w, r)
+	if !ok {
+		return
+	}
+
+	projectID, _ := body["project_id"].(string)
+	status, _ := body["status"].(string)
+	for _, imageID := range images {
+		member := s.lookup("image_member", imageMemberID(imageID, projectID))
+		if member == nil {
+			writeError(w, http.StatusNotFound, "IMG.0058",
+				fmt.Sprintf("the image %s is not shared with the project %s", imageID, projectID))
+			return
+		}
+		member.data["status"] = status
+		member.readyStatus = status
+		member.data["updated_at"] = nowString()
+	}
+
+	jobID := s.createJob("batchUpdateMembers", map[string]interface{}{"image_ids": images})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+// unshareImages stops sharing the images with the projects
+func (s *Server) unshareImages(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, images, ok := s.readImageMembersBody(w, r)
+	if !ok {
+		return
+	}
+
+	projects, _ := body["projects"].([]interface{})
+	for _, imageID := range images {
+		for _, project := range projects {
+			projectID, _ := project.(string)
+			s.remove("image_member", imageMemberID(imageID, projectID), "")
+		}
+	}
+
+	jobID := s.createJob("batchDeleteMembers", map[string]interface{}{"image_ids": images})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+func (s *Server) listImageMembers(w http.ResponseWriter, r *http.Request, params []string) {
+	if s.lookup("image", params[0]) == nil {
+		writeNotFound(w, "image", params[0])
+		return
+	}
+
+	members := make([]interface{}, 0)
+	for _, member := range s.list("image_member") {
+		if member.data["image_id"] == params[0] {
+			members = append(members, member.data)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"members": members,
+		"schema":  "/v2/schemas/members",
+	})
+}
+
+func (s *Server) getImageMember(w http.ResponseWriter, r *http.Request, params []string) {
+	member := s.lookup("image_member", imageMemberID(params[0], params[1]))
+	if member == nil {
+		writeError(w, http.StatusNotFound, "IMG.0058",
+			fmt.Sprintf("the image %s is not shared with the project %s", params[0], params[1]))
+		return
+	}
+	writeJSON(w, http.StatusOK, member.data)
+}
+
+// copyImage creates a private image from the source image, the new image is queued until the job succeeds.
+// The fake serves a single region, so the image copied to another region is also stored in the fake.
+func (s *Server) copyImage(w http.ResponseWriter, r *http.Request, params []string) {
+	source := s.lookup("image", params[0])
+	if source == nil {
+		writeNotFound(w, "image", params[0])
+		return
+	}
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	epsID, _ := body["enterprise_project_id"].(string)
+	if epsID == "" {
+		epsID = "0"
+	}
+	image := s.create("image", map[string]interface{}{
+		"name":                  body["name"],
+		"__description":         body["description"],
+		"__system__cmkid":       body["cmk_id"],
+		"enterprise_project_id": epsID,
+		"visibility":            "private",
+		"__os_type":             source.data["__os_type"],
+		"__os_bit":              source.data["__os_bit"],
+		"disk_format":           source.data["disk_format"],
+		"min_disk":              source.data["min_disk"],
+		"__image_size":          "1073741824",
+		"__imagetype":           "private",
+	}, "queued", "active")
+
+	imageID := image.data["id"].(string)
+	jobID := s.createImageJob("copyImageInRegion", map[string]interface{}{"image_id": imageID}, image)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+// newPrivateImage creates a queued private image from the ECS or the volume of the data origin
+func (s *Server) newPrivateImage(body map[string]interface{}, dataOrigin string, minDisk interface{}) *object {
+	epsID, _ := body["enterprise_project_id"].(string)
+	if epsID == "" {
+		epsID = "0"
+	}
+	return s.create("image", map[string]interface{}{
+		"name":                  body["name"],
+		"__description":         body["description"],
+		"enterprise_project_id": epsID,
+		"visibility":            "private",
+		"__data_origin":         dataOrigin,
+		"disk_format":           "zvhd2",
+		"min_disk":              minDisk,
+		"__image_size":          "1073741824",
+		"__imagetype":           "private",
+		"virtual_env_type":      "FusionCompute",
+	}, "queued", "active")
+}
+
+// createImageJob creates the IMS job, the created images are returned in the entities of the job
+func (s *Server) createImageJob(jobType string, entities map[string]interface{}, images ...*object) string {
+	jobID := s.createJob(jobType, entities, images...)
+	mergeInto(s.lookup("job", jobID).data["entities"].(map[string]interface{}), entities)
+	return jobID
+}
+
+// createImage creates the system disk image from the ECS together with the data disk images from the volumes,
+// or only the data disk images. The system disk image is returned by image_id of the job entities and the data
+// disk images are returned by sub_jobs_result. Creating the images from the OBS files is not supported.
+func (s *Server) createImage(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	instanceID, _ := body["instance_id"].(string)
+	rawDataImages, _ := body["data_images"].([]interface{})
+	if instanceID == "" && len(rawDataImages) == 0 {
+		writeError(w, http.StatusBadRequest, "IMG.0001", "the instance_id or data_images must be specified")
+		return
+	}
+	if instanceID != "" && s.lookup("server", instanceID) == nil {
+		writeError(w, http.StatusBadRequest, "IMG.0010", fmt.Sprintf("the instance %s does not exist", instanceID))
+		return
+	}
+	dataImages := make([]map[string]interface{}, len(rawDataImages))
+	volumes := make([]*object, len(rawDataImages))
+	for i, raw := range rawDataImages {
+		dataImages[i], _ = raw.(map[string]interface{})
+		volumeID, _ := dataImages[i]["volume_id"].(string)
+		if volumes[i] = s.lookup("volume", volumeID); volumes[i] == nil {
+			writeError(w, http.StatusBadRequest, "IMG.0011", fmt.Sprintf("the volume %s does not exist", volumeID))
+			return
+		}
+	}
+
+	var images []*object
+	entities := make(map[string]interface{})
+	if instanceID != "" {
+		image := s.newPrivateImage(body, "instance,"+instanceID, float64(40))
+		image.data["__os_type"] = "Linux"
+		image.data["__os_bit"] = "64"
+		images = append(images, image)
+		entities["image_id"] = image.data["id"]
+	}
+	subJobs := make([]interface{}, 0, len(dataImages))
+	for i, dataImage := range dataImages {
+		// the data disk images created together with the system disk image share its data origin
+		dataOrigin := "volume," + volumes[i].data["id"].(string)
+		if instanceID != "" {
+			dataOrigin = "instance," + instanceID
+		}
+		image := s.newPrivateImage(dataImage, dataOrigin, volumes[i].data["size"])
+		image.data["virtual_env_type"] = "DataImage"
+		images = append(images, image)
+		subJobs = append(subJobs, map[string]interface{}{
+			"status":   "SUCCESS",
+			"entities": map[string]interface{}{"image_id": image.data["id"]},
+		})
+	}
+	if len(subJobs) > 0 {
+		entities["sub_jobs_result"] = subJobs
+	}
+
+	jobID := s.createImageJob("createImageByInstance", entities, images...)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+// createWholeImage creates a whole image from the ECS, the backup in the CBR vault is not simulated
+func (s *Server) createWholeImage(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	instanceID, _ := body["instance_id"].(string)
+	if s.lookup("server", instanceID) == nil {
+		writeError(w, http.StatusBadRequest, "IMG.0010", fmt.Sprintf("the instance %s does not exist", instanceID))
+		return
+	}
+	if vaultID, _ := body["vault_id"].(string); vaultID == "" {
+		writeError(w, http.StatusBadRequest, "IMG.0001", "the vault_id must be specified")
+		return
+	}
+
+	image := s.newPrivateImage(body, "instance,"+instanceID, float64(40))
+	image.data["__whole_image"] = "true"
+	image.data["__os_type"] = "Linux"
+	image.data["__os_bit"] = "64"
+	jobID := s.createImageJob("createWholeImage", map[string]interface{}{"image_id": image.data["id"]}, image)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"job_id": jobID})
+}
+
+func (s *Server) getGlanceImage(w http.ResponseWriter, r *http.Request, params []string) {
+	image := s.get("image", params[0])
+	if image == nil {
+		writeNotFound(w, "image", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, image.data)
+}
+
+// deleteImage removes the image and the projects which it is shared with
+func (s *Server) deleteImage(w http.ResponseWriter, r *http.Request, params []string) {
+	if s.lookup("image", params[0]) == nil {
+		writeNotFound(w, "image", params[0])
+		return
+	}
+
+	for _, member := range s.list("image_member") {
+		if member.data["image_id"] == params[0] {
+			s.remove("image_member", member.data["id"].(string), "")
+		}
+	}
+	s.remove("image", params[0], "")
+	writeJSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,538 @@
+// Package fakecloud provides an in-process fake of the IAM, ECS, VPC, EVS, IMS and EPS APIs, it's only used by the
+// tests.
+//
+// The fake issues tokens, lists the projects and serves the CRUD APIs of VPCs, subnets, security groups,
+// ECS instances and EVS volumes, which can be migrated between the enterprise projects, as well as creating,
+// sharing and copying the images. The created resources
+// and jobs stay in the pending status for PendingPolls GET requests, the deleted resources return 404, and the
+// faults such as 409 conflicts and 429 throttling can be injected to exercise the error handling of the provider.
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	defaultRegion     = "cn-north-4"
+	defaultProjectID  = "0970dd7a1300f5672ff2c003c60ae115"
+	defaultDomainID   = "0970d7b7d400f2470fbec00316a03560"
+	defaultDomainName = "fake-domain"
+	defaultImageID    = "fake-image-id"
+
+	fakeAccessKey = "fake-access-key"
+	fakeSecretKey = "fake-secret-key"
+)
+
+// the patterns of the path parameters
+const (
+	projectPattern = `[^/]+`
+	idPattern      = `([^/]+)`
+)
+
+// Fault is the error response returned by the fake instead of calling the API.
+type Fault struct {
+	// Method is the HTTP method to match, all methods are matched if it's empty
+	Method string
+	// PathPattern is the regular expression to match the request path
+	PathPattern string
+	// StatusCode is the status code of the error response, e.g. 409 or 429
+	StatusCode int
+	// ErrorCode is the error_code in the response body
+	ErrorCode string
+	// RetryAfter is the value of the Retry-After header in seconds
+	RetryAfter int
+	// Times is the number of requests to fail, 0 means all the matched requests fail
+	Times int
+
+	pattern *regexp.Regexp
+	count   int
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params []string)
+
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	handler handlerFunc
+}
+
+// object is a resource or a job stored in the fake
+type object struct {
+	data map[string]interface{}
+	tags []interface{}
+	// refs stores the IDs of the referenced objects which are not in the response, e.g. the subnet of an instance
+	refs map[string]string
+	// pending is the number of GET requests before the object becomes ready
+	pending     int
+	readyStatus string
+	// deleting objects are removed after the pending GET requests
+	deleting bool
+}
+
+// Server is an in-process fake cloud which serves the APIs with httptest.Server.
+type Server struct {
+	Region     string
+	ProjectID  string
+	DomainID   string
+	DomainName string
+	// PendingPolls is the number of GET requests before the created resources and jobs become ready,
+	// and before the resources which are deleted asynchronously return 404
+	PendingPolls int
+
+	server *httptest.Server
+	lock   sync.Mutex
+	routes []route
+	// store stores the objects of each kind by ID
+	store  map[string]map[string]*object
+	faults []*Fault
+	seq    int
+	// jobTargets stores the objects which are changed by each running job
+	jobTargets map[string][]*object
+	// requests stores the count of requests by "METHOD path"
+	requests map[string]int
+}
+
+// NewServer starts a fake cloud with a project of cn-north-4, it should be closed after the test.
+func NewServer() *Server {
+	s := &Server{
+		Region:       defaultRegion,
+		ProjectID:    defaultProjectID,
+		DomainID:     defaultDomainID,
+		DomainName:   defaultDomainName,
+		PendingPolls: 1,
+		store:        make(map[string]map[string]*object),
+		requests:     make(map[string]int),
+		jobTargets:   make(map[string][]*object),
+	}
+
+	s.registerIAMRoutes()
+	s.registerVPCRoutes()
+	s.registerECSRoutes()
+	s.registerEVSRoutes()
+	s.registerIMSRoutes()
+	s.registerEPSRoutes()
+	s.AddImage(defaultImageID, "Fake CentOS 7.6 64bit")
+
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// Close shuts down the fake cloud
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the base URL of the fake cloud, e.g. http://127.0.0.1:8080
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// AuthURL returns the identity endpoint of the fake cloud
+func (s *Server) AuthURL() string {
+	return s.server.URL + "/v3"
+}
+
+// Endpoints returns the custom endpoints of the provider which point to the fake cloud
+func (s *Server) Endpoints() map[string]string {
+	endpoints := make(map[string]string)
+	for _, srv := range []string{"iam", "vpc", "ecs", "evs", "ims", "eps"} {
+		endpoints[srv] = s.server.URL + "/"
+		for _, k := range config.GetServiceDerivedCatalogKeys(srv) {
+			endpoints[k] = s.server.URL + "/"
+		}
+	}
+	return endpoints
+}
+
+// ProviderConfig returns the provider block of the acceptance tests which run against the fake cloud.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "huaweicloud" {
+  region      = "%[1]s"
+  access_key  = "%[2]s"
+  secret_key  = "%[3]s"
+  auth_url    = "%[4]s/v3"
+  max_retries = 3
+
+  endpoints = {
+    iam = "%[4]s/"
+    vpc = "%[4]s/"
+    ecs = "%[4]s/"
+    evs = "%[4]s/"
+    ims = "%[4]s/"
+    eps = "%[4]s/"
+  }
+}
+`, s.Region, fakeAccessKey, fakeSecretKey, s.server.URL)
+}
+
+// ProviderArguments returns the raw arguments of the provider which sends the requests to the fake cloud,
+// it's the same as ProviderConfig.
+func (s *Server) ProviderArguments() map[string]interface{} {
+	endpoints := make(map[string]interface{})
+	for _, srv := range []string{"iam", "vpc", "ecs", "evs", "ims", "eps"} {
+		endpoints[srv] = s.server.URL + "/"
+	}
+
+	return map[string]interface{}{
+		"region":      s.Region,
+		"access_key":  fakeAccessKey,
+		"secret_key":  fakeSecretKey,
+		"auth_url":    s.AuthURL(),
+		"max_retries": 3,
+		"endpoints":   endpoints,
+	}
+}
+
+// Config returns a loaded provider config which sends the requests to the fake cloud.
+func (s *Server) Config() (*config.Config, error) {
+	c := config.Config{
+		AccessKey:          fakeAccessKey,
+		SecretKey:          fakeSecretKey,
+		Region:             s.Region,
+		TenantName:         s.Region,
+		Cloud:              "myhuaweicloud.com",
+		IdentityEndpoint:   s.AuthURL(),
+		Endpoints:          s.Endpoints(),
+		MaxRetries:         3,
+		RegionProjectIDMap: make(map[string]string),
+		RPLock:             new(sync.Mutex),
+		SecurityKeyLock:    new(sync.Mutex),
+		ClientCache:        config.NewServiceClientCache(),
+	}
+	if err := c.LoadAndValidate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// InjectFault makes the matched requests fail with the fault
+func (s *Server) InjectFault(fault Fault) {
+	fault.pattern = regexp.MustCompile(fault.PathPattern)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// RequestCount returns the count of requests with the method and the path
+func (s *Server) RequestCount(method, path string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests[method+" "+path]
+}
+
+// ServeHTTP dispatches the requests to the handlers of the APIs
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.requests[r.Method+" "+r.URL.Path]++
+	w.Header().Set("X-Request-Id", fmt.Sprintf("fake-request-%d", s.nextSeq()))
+
+	if fault := s.matchFault(r); fault != nil {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(fault.RetryAfter))
+		}
+		code := fault.ErrorCode
+		if code == "" {
+			code = fmt.Sprintf("FAKE.%d", fault.StatusCode)
+		}
+		writeError(w, fault.StatusCode, code, "the fault is injected by the fake cloud")
+		return
+	}
+
+	var pathMatched bool
+	for _, rt := range s.routes {
+		matches := rt.pattern.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			continue
+		}
+		pathMatched = true
+		if rt.method == r.Method {
+			rt.handler(w, r, matches[1:])
+			return
+		}
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, "FAKE.0405", "the method is not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "FAKE.0404", fmt.Sprintf("the API %s %s is not supported", r.Method, r.URL.Path))
+}
+
+func (s *Server) handle(method, pattern string, handler handlerFunc) {
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: regexp.MustCompile("^" + pattern + "$"),
+		handler: handler,
+	})
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !fault.pattern.MatchString(r.URL.Path) {
+			continue
+		}
+
+		fault.count++
+		if fault.Times > 0 && fault.count >= fault.Times {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return fault
+	}
+	return nil
+}
+
+func (s *Server) nextSeq() int {
+	s.seq++
+	return s.seq
+}
+
+// newID returns a UUID-like ID which is unique in the server
+func (s *Server) newID() string {
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", time.Now().Unix()&0xffffffff, s.nextSeq())
+}
+
+// create stores a new object of the kind which becomes ready after PendingPolls GET requests
+func (s *Server) create(kind string, data map[string]interface{}, pendingStatus, readyStatus string) *object {
+	id, ok := data["id"].(string)
+	if !ok || id == "" {
+		id = s.newID()
+		data["id"] = id
+	}
+
+	obj := &object{
+		data:        data,
+		refs:        make(map[string]string),
+		pending:     s.PendingPolls,
+		readyStatus: readyStatus,
+	}
+	if pendingStatus == "" || s.PendingPolls == 0 {
+		obj.pending = 0
+		data["status"] = readyStatus
+	} else {
+		data["status"] = pendingStatus
+	}
+
+	if s.store[kind] == nil {
+		s.store[kind] = make(map[string]*object)
+	}
+	s.store[kind][id] = obj
+	return obj
+}
+
+// get returns the object and refreshes its status, nil is returned if it does not exist or has been deleted
+func (s *Server) get(kind, id string) *object {
+	obj, ok := s.store[kind][id]
+	if !ok {
+		return nil
+	}
+
+	if obj.pending > 0 {
+		obj.pending--
+		return obj
+	}
+	if obj.deleting {
+		delete(s.store[kind], id)
+		return nil
+	}
+	if obj.readyStatus != "" {
+		obj.data["status"] = obj.readyStatus
+	}
+	return obj
+}
+
+// lookup returns the object without refreshing its status
+func (s *Server) lookup(kind, id string) *object {
+	return s.store[kind][id]
+}
+
+// list returns the objects of the kind which are sorted by ID
+func (s *Server) list(kind string) []*object {
+	ids := make([]string, 0, len(s.store[kind]))
+	for id, obj := range s.store[kind] {
+		if !obj.deleting {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	result := make([]*object, len(ids))
+	for i, id := range ids {
+		result[i] = s.store[kind][id]
+	}
+	return result
+}
+
+// remove deletes the object immediately, or after PendingPolls GET requests with the status if it's not empty
+func (s *Server) remove(kind, id, deletingStatus string) {
+	obj, ok := s.store[kind][id]
+	if !ok {
+		return
+	}
+
+	if deletingStatus == "" || s.PendingPolls == 0 {
+		delete(s.store[kind], id)
+		return
+	}
+	obj.deleting = true
+	obj.pending = s.PendingPolls
+	obj.data["status"] = deletingStatus
+}
+
+// createJob stores a job which succeeds after PendingPolls GET requests with the entities,
+// the pending objects in targets become ready or are removed when the job succeeds.
+func (s *Server) createJob(jobType string, entities map[string]interface{}, targets ...*object) string {
+	job := s.create("job", map[string]interface{}{
+		"job_type": jobType,
+		"entities": map[string]interface{}{
+			"sub_jobs": []interface{}{
+				map[string]interface{}{
+					"status":   "SUCCESS",
+					"entities": entities,
+				},
+			},
+		},
+		"begin_time": time.Now().UTC().Format(time.RFC3339),
+	}, "RUNNING", "SUCCESS")
+	job.data["job_id"] = job.data["id"]
+	s.jobTargets[job.data["id"].(string)] = targets
+	return job.data["id"].(string)
+}
+
+// completeJob makes the targets of the job ready, the deleting targets are removed
+func (s *Server) completeJob(jobID string) {
+	for _, obj := range s.jobTargets[jobID] {
+		obj.pending = 0
+		if obj.deleting {
+			for _, objects := range s.store {
+				for id, v := range objects {
+					if v == obj {
+						delete(objects, id)
+					}
+				}
+			}
+		} else if obj.readyStatus != "" {
+			obj.data["status"] = obj.readyStatus
+		}
+	}
+	delete(s.jobTargets, jobID)
+}
+
+// AddImage registers an image which can be referenced by the ECS instances
+func (s *Server) AddImage(id, name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.create("image", map[string]interface{}{
+		"id":          id,
+		"name":        name,
+		"visibility":  "public",
+		"__os_type":   "Linux",
+		"__os_bit":    "64",
+		"disk_format": "zvhd2",
+		"min_disk":    float64(40),
+		"__imagetype": "gold",
+	}, "", "active")
+}
+
+// readBody parses the JSON request body, and returns the value of the key if it's not empty
+func readBody(r *http.Request, key string) (map[string]interface{}, error) {
+	raw, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make(map[string]interface{})
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &body); err != nil {
+			return nil, err
+		}
+	}
+	if key == "" {
+		return body, nil
+	}
+
+	value, ok := body[key].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the %q object is missing in the request body", key)
+	}
+	return value, nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if body != nil {
+		json.NewEncoder(w).Encode(body)
+	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error_code": code,
+		"error_msg":  message,
+		"request_id": w.Header().Get("X-Request-Id"),
+	})
+}
+
+func writeBadRequest(w http.ResponseWriter, err error) {
+	writeError(w, http.StatusBadRequest, "FAKE.0400", err.Error())
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "FAKE.0404", fmt.Sprintf("the %s %s does not exist", kind, id))
+}
+
+// mergeInto copies the non-nil values of the update into the data
+func mergeInto(data, update map[string]interface{}) {
+	for k, v := range update {
+		if v != nil {
+			data[k] = v
+		}
+	}
+}
+
+// filterObjects returns the data of the objects which match all the query parameters in keys
+func filterObjects(objects []*object, r *http.Request, keys ...string) []interface{} {
+	query := r.URL.Query()
+	result := make([]interface{}, 0, len(objects))
+	for _, obj := range objects {
+		matched := true
+		for _, k := range keys {
+			if v := query.Get(k); v != "" && fmt.Sprint(obj.data[k]) != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, obj.data)
+		}
+	}
+	return result
+}
+
+func nowString() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func withProject(prefix, path string) string {
+	return strings.TrimSuffix(prefix, "/") + "/" + projectPattern + "/" + strings.TrimPrefix(path, "/")
+}
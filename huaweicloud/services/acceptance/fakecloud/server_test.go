@@ -0,0 +1,825 @@
+package fakecloud
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/block_devices"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/cloudservers"
+	"github.com/chnsz/golangsdk/openstack/ecs/v1/jobs"
+	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
+	"github.com/chnsz/golangsdk/openstack/ims/v2/cloudimages"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/subnets"
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	"github.com/chnsz/golangsdk/openstack/networking/v3/security/groups"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+func newTestConfig(t *testing.T) (*Server, *config.Config) {
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	c, err := srv.Config()
+	if err != nil {
+		t.Fatalf("Error loading the config of the fake cloud: %s", err)
+	}
+	return srv, c
+}
+
+func createTestSubnet(t *testing.T, c *config.Config) (*vpcs.Vpc, *subnets.Subnet) {
+	client, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, err := vpcs.Create(client, vpcs.CreateOpts{Name: "vpc-test", CIDR: "192.168.0.0/16"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the VPC: %s", err)
+	}
+	subnet, err := subnets.Create(client, subnets.CreateOpts{
+		Name:      "subnet-test",
+		CIDR:      "192.168.0.0/24",
+		GatewayIP: "192.168.0.1",
+		VPC_ID:    vpc.ID,
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the subnet: %s", err)
+	}
+	return vpc, subnet
+}
+
+// waitTestJob polls the job directly instead of cloudservers.WaitForJobSuccess which sleeps 5 seconds in each poll
+func waitTestJob(t *testing.T, client *golangsdk.ServiceClient, jobID string) *jobs.Job {
+	for i := 0; i < 5; i++ {
+		job, err := jobs.Get(client, jobID)
+		if err != nil {
+			t.Fatalf("Error getting the job %s: %s", jobID, err)
+		}
+		if job.Status == "SUCCESS" {
+			return job
+		}
+	}
+	t.Fatalf("The job %s is not completed", jobID)
+	return nil
+}
+
+func TestServer_loadAndValidate(t *testing.T) {
+	srv, c := newTestConfig(t)
+
+	if c.DomainID != srv.DomainID {
+		t.Errorf("expected domain ID %s, but got %s", srv.DomainID, c.DomainID)
+	}
+	if projectID := c.RegionProjectIDMap[srv.Region]; projectID != srv.ProjectID {
+		t.Errorf("expected project ID %s, but got %s", srv.ProjectID, projectID)
+	}
+	if srv.RequestCount("GET", "/v3/projects") == 0 {
+		t.Errorf("expected the projects to be loaded from the fake cloud")
+	}
+}
+
+func TestServer_vpcLifecycle(t *testing.T) {
+	srv, c := newTestConfig(t)
+	client, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, err := vpcs.Create(client, vpcs.CreateOpts{Name: "vpc-test", CIDR: "192.168.0.0/16"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the VPC: %s", err)
+	}
+	if vpc.Status != "CREATING" {
+		t.Errorf("expected the VPC to be CREATING, but got %s", vpc.Status)
+	}
+
+	// the VPC becomes ready after PendingPolls GET requests
+	for _, expected := range []string{"CREATING", "OK"} {
+		found, err := vpcs.Get(client, vpc.ID).Extract()
+		if err != nil {
+			t.Fatalf("Error getting the VPC: %s", err)
+		}
+		if found.Status != expected {
+			t.Errorf("expected the VPC to be %s, but got %s", expected, found.Status)
+		}
+	}
+
+	found, err := vpcs.Update(client, vpc.ID, vpcs.UpdateOpts{Name: "vpc-updated"}).Extract()
+	if err != nil {
+		t.Fatalf("Error updating the VPC: %s", err)
+	}
+	if found.Name != "vpc-updated" {
+		t.Errorf("expected the VPC name to be vpc-updated, but got %s", found.Name)
+	}
+
+	if err := vpcs.Delete(client, vpc.ID).ExtractErr(); err != nil {
+		t.Fatalf("Error deleting the VPC: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId(vpc.ID)
+	_, err = vpcs.Get(client, vpc.ID).Extract()
+	if _, ok := err.(golangsdk.ErrDefault404); !ok {
+		t.Fatalf("expected 404 after the VPC is deleted, but got %#v", err)
+	}
+	if err := common.CheckDeleted(d, err, "VPC"); err != nil || d.Id() != "" {
+		t.Errorf("expected the ID to be cleared by CheckDeleted, but got %q, %v", d.Id(), err)
+	}
+
+	if n := srv.RequestCount("DELETE", "/v1/"+srv.ProjectID+"/vpcs/"+vpc.ID); n != 1 {
+		t.Errorf("expected 1 DELETE request, but got %d", n)
+	}
+}
+
+func TestServer_conflict(t *testing.T) {
+	_, c := newTestConfig(t)
+	client, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, subnet := createTestSubnet(t, c)
+	err = vpcs.Delete(client, vpc.ID).ExtractErr()
+	if err == nil {
+		t.Fatalf("expected an error when deleting the VPC with subnets")
+	}
+	if retryErr := common.CheckForRetryableError(err); !retryErr.Retryable {
+		t.Errorf("expected the 409 error to be retryable, but got %#v", err)
+	}
+
+	if err := subnets.Delete(client, vpc.ID, subnet.ID).ExtractErr(); err != nil {
+		t.Fatalf("Error deleting the subnet: %s", err)
+	}
+	if err := vpcs.Delete(client, vpc.ID).ExtractErr(); err != nil {
+		t.Errorf("Error deleting the VPC after the subnet is deleted: %s", err)
+	}
+}
+
+func TestServer_notFound(t *testing.T) {
+	_, c := newTestConfig(t)
+	client, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = vpcs.Get(client, "missing-vpc").Extract()
+	apiErr := common.ParseAPIError(err)
+	if apiErr == nil || apiErr.Kind != common.ErrorKindNotFound {
+		t.Fatalf("expected a NotFound error, but got %#v", err)
+	}
+	if !strings.HasPrefix(apiErr.RequestID, "fake-request-") {
+		t.Errorf("expected the request ID of the fake cloud, but got %q", apiErr.RequestID)
+	}
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("missing-vpc")
+	if diags := common.CheckDeletedDiag(d, err, "Error retrieving VPC"); diags != nil || d.Id() != "" {
+		t.Errorf("expected the resource to be removed from the state, but got %#v", diags)
+	}
+}
+
+func TestServer_migrateEnterpriseProject(t *testing.T) {
+	_, c := newTestConfig(t)
+	client, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, _ := createTestSubnet(t, c)
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"enterprise_project_id": {Type: schema.TypeString, Optional: true},
+	}, map[string]interface{}{
+		"enterprise_project_id": "fake-eps-id",
+	})
+
+	migrateOpts := common.MigrateResourceOpts{
+		ResourceId:   vpc.ID,
+		ResourceType: "vpc",
+	}
+	if err := common.MigrateEnterpriseProject(context.Background(), d, c, migrateOpts); err != nil {
+		t.Fatalf("Error migrating the VPC: %s", err)
+	}
+
+	vpc, err = vpcs.Get(client, vpc.ID).Extract()
+	if err != nil {
+		t.Fatalf("Error getting the VPC: %s", err)
+	}
+	if vpc.EnterpriseProjectID != "fake-eps-id" {
+		t.Errorf("expected the VPC to be migrated to fake-eps-id, but got %s", vpc.EnterpriseProjectID)
+	}
+}
+
+func TestServer_throttling(t *testing.T) {
+	srv, c := newTestConfig(t)
+	client, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, err := vpcs.Create(client, vpcs.CreateOpts{Name: "vpc-test", CIDR: "192.168.0.0/16"}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the VPC: %s", err)
+	}
+
+	srv.InjectFault(Fault{
+		Method:      "GET",
+		PathPattern: "/vpcs/" + vpc.ID + "$",
+		StatusCode:  429,
+		RetryAfter:  1,
+		Times:       1,
+	})
+	if _, err := vpcs.Get(client, vpc.ID).Extract(); err != nil {
+		t.Fatalf("expected the throttled request to be retried, but got %s", err)
+	}
+	if n := srv.RequestCount("GET", "/v1/"+srv.ProjectID+"/vpcs/"+vpc.ID); n != 2 {
+		t.Errorf("expected 2 GET requests, but got %d", n)
+	}
+
+	// the fault without Times fails all the matched requests
+	srv.InjectFault(Fault{
+		Method:      "DELETE",
+		PathPattern: "/vpcs/",
+		StatusCode:  409,
+		ErrorCode:   "VPC.0111",
+	})
+	for i := 0; i < 2; i++ {
+		err := vpcs.Delete(client, vpc.ID).ExtractErr()
+		if e, ok := err.(golangsdk.ErrUnexpectedResponseCode); !ok || e.Actual != 409 {
+			t.Errorf("expected the injected 409 error, but got %#v", err)
+		}
+	}
+}
+
+func TestServer_serverAndVolume(t *testing.T) {
+	srv, c := newTestConfig(t)
+	ecsClient, err := c.ComputeV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsV11Client, err := c.ComputeV11Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evsClient, err := c.BlockStorageV2Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evsV21Client, err := c.BlockStorageV21Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sgClient, err := c.NetworkingV3Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, subnet := createTestSubnet(t, c)
+	secgroup, err := groups.Create(sgClient, groups.CreateOpts{Name: "secgroup-test"})
+	if err != nil {
+		t.Fatalf("Error creating the security group: %s", err)
+	}
+	if len(secgroup.SecurityGroupRules) != 2 {
+		t.Errorf("expected 2 default rules, but got %d", len(secgroup.SecurityGroupRules))
+	}
+
+	createOpts := cloudservers.CreateOpts{
+		ImageRef:         defaultImageID,
+		FlavorRef:        "s6.small.1",
+		Name:             "ecs-test",
+		VpcId:            vpc.ID,
+		Nics:             []cloudservers.Nic{{SubnetId: subnet.ID}},
+		RootVolume:       cloudservers.RootVolume{VolumeType: "SSD"},
+		SecurityGroups:   []cloudservers.SecurityGroup{{ID: secgroup.ID}},
+		AvailabilityZone: "cn-north-4a",
+	}
+	job, err := cloudservers.Create(ecsV11Client, createOpts).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error creating the server: %s", err)
+	}
+	serverID := waitTestJob(t, ecsClient, job.JobID).Entities.SubJobs[0].Entities.ServerId
+
+	server, err := cloudservers.Get(ecsClient, serverID).Extract()
+	if err != nil {
+		t.Fatalf("Error getting the server: %s", err)
+	}
+	if server.Status != "ACTIVE" || len(server.VolumeAttached) != 1 || len(server.SecurityGroups) != 1 {
+		t.Errorf("unexpected server: %#v", server)
+	}
+	systemDisk, err := cloudvolumes.Get(evsClient, server.VolumeAttached[0].ID).Extract()
+	if err != nil {
+		t.Fatalf("Error getting the system disk: %s", err)
+	}
+	if systemDisk.Status != "in-use" || systemDisk.Bootable != "true" || systemDisk.Size != 40 {
+		t.Errorf("unexpected system disk: %#v", systemDisk)
+	}
+
+	// the subnet can not be deleted when it's used by the server
+	vpcClient, err := c.NetworkingV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = subnets.Delete(vpcClient, vpc.ID, subnet.ID).ExtractErr()
+	if e, ok := err.(golangsdk.ErrUnexpectedResponseCode); !ok || e.Actual != 409 {
+		t.Errorf("expected 409 when deleting the subnet in use, but got %#v", err)
+	}
+
+	volumeJob, err := cloudvolumes.Create(evsV21Client, cloudvolumes.CreateOpts{
+		Volume: cloudvolumes.VolumeOpts{
+			AvailabilityZone: "cn-north-4a",
+			VolumeType:       "SAS",
+			Name:             "volume-test",
+			Size:             10,
+		},
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the volume: %s", err)
+	}
+	volumeID := volumeJob.VolumeIDs[0]
+
+	attachJob, err := block_devices.Attach(ecsClient, block_devices.AttachOpts{ServerId: serverID, VolumeId: volumeID})
+	if err != nil {
+		t.Fatalf("Error attaching the volume: %s", err)
+	}
+	waitTestJob(t, ecsClient, attachJob.ID)
+	attachment, err := block_devices.Get(ecsClient, serverID, volumeID).Extract()
+	if err != nil {
+		t.Fatalf("Error getting the attachment: %s", err)
+	}
+	if attachment.BootIndex != 1 || attachment.Device != "/dev/vdb" {
+		t.Errorf("unexpected attachment: %#v", attachment)
+	}
+
+	err = cloudvolumes.Delete(evsClient, volumeID, cloudvolumes.DeleteOpts{}).ExtractErr()
+	if e, ok := err.(golangsdk.ErrUnexpectedResponseCode); !ok || e.Actual != 409 {
+		t.Errorf("expected 409 when deleting the volume in use, but got %#v", err)
+	}
+
+	// the job of Detach is not extracted as the SDK sends it as the request body, the volume is detached at once
+	if _, err := block_devices.Detach(ecsClient, volumeID, block_devices.DetachOpts{ServerId: serverID}); err != nil {
+		t.Fatalf("Error detaching the volume: %s", err)
+	}
+	if err := cloudvolumes.Delete(evsClient, volumeID, cloudvolumes.DeleteOpts{}).ExtractErr(); err != nil {
+		t.Fatalf("Error deleting the volume: %s", err)
+	}
+	for _, expected := range []string{"deleting", ""} {
+		volume, err := cloudvolumes.Get(evsClient, volumeID).Extract()
+		if expected == "" {
+			if _, ok := err.(golangsdk.ErrDefault404); !ok {
+				t.Errorf("expected 404 after the volume is deleted, but got %#v", err)
+			}
+		} else if err != nil || volume.Status != expected {
+			t.Errorf("expected the volume to be %s, but got %#v, %v", expected, volume, err)
+		}
+	}
+
+	deleteJob, err := cloudservers.Delete(ecsClient, cloudservers.DeleteOpts{
+		Servers: []cloudservers.Server{{Id: serverID}},
+	}).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error deleting the server: %s", err)
+	}
+	waitTestJob(t, ecsClient, deleteJob.JobID)
+	if err := subnets.Delete(vpcClient, vpc.ID, subnet.ID).ExtractErr(); err != nil {
+		t.Errorf("Error deleting the subnet after the server is deleted: %s", err)
+	}
+	if n := srv.RequestCount("POST", "/v1/"+srv.ProjectID+"/cloudservers/delete"); n != 1 {
+		t.Errorf("expected 1 delete request, but got %d", n)
+	}
+}
+
+func TestServer_endpointDiscovery(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	// only the identity endpoint is specified, the endpoints of the services are discovered from the catalog
+	c := config.Config{
+		AccessKey:          fakeAccessKey,
+		SecretKey:          fakeSecretKey,
+		Region:             srv.Region,
+		TenantName:         srv.Region,
+		Cloud:              "myhuaweicloud.com",
+		IdentityEndpoint:   srv.AuthURL(),
+		EndpointDiscovery:  true,
+		RegionProjectIDMap: make(map[string]string),
+		RPLock:             new(sync.Mutex),
+		SecurityKeyLock:    new(sync.Mutex),
+		ClientCache:        config.NewServiceClientCache(),
+	}
+	if err := c.LoadAndValidate(); err != nil {
+		t.Fatalf("Error loading the config: %s", err)
+	}
+	if n := c.DiscoveredEndpoints.Len(); n == 0 {
+		t.Errorf("expected the endpoints to be discovered from the catalog, but got none")
+	}
+	if c.DomainID != srv.DomainID {
+		t.Errorf("expected the domain ID to be loaded from the discovered IAM endpoint, but got %q", c.DomainID)
+	}
+
+	for srvName, expected := range map[string]string{
+		"vpc": srv.URL() + "/",
+		"eps": srv.URL() + "/",
+		"rds": "https://rds." + srv.Region + ".myhuaweicloud.com/",
+	} {
+		endpoint, _, err := c.ResolveEndpoint(srvName, srv.Region)
+		if err != nil || endpoint != expected {
+			t.Errorf("expected the endpoint %s of %s, but got %s, %v", expected, srvName, endpoint, err)
+		}
+	}
+
+	_, subnet := createTestSubnet(t, &c)
+	if subnet.Status != "ACTIVE" && subnet.Status != "UNKNOWN" {
+		t.Logf("the subnet is %s", subnet.Status)
+	}
+	if n := srv.RequestCount("POST", "/v1/"+srv.ProjectID+"/vpcs"); n != 1 {
+		t.Errorf("expected the VPC to be created by the discovered endpoint, but got %d requests", n)
+	}
+}
+
+func TestServer_imageShareAndCopy(t *testing.T) {
+	_, c := newTestConfig(t)
+	ecsClient, err := c.ComputeV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imsV1Client, err := c.ImageV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imsClient, err := c.ImageV2Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	membersURL := imsV1Client.ServiceURL("cloudimages", "members")
+	reqOpts := &golangsdk.RequestOpts{OkCodes: []int{200}}
+	listMembers := func() map[string]string {
+		var r struct {
+			Members []struct {
+				MemberID string `json:"member_id"`
+				Status   string `json:"status"`
+			} `json:"members"`
+		}
+		if _, err := imsClient.Get(imsClient.ServiceURL("images", defaultImageID, "members"), &r, nil); err != nil {
+			t.Fatalf("Error listing the image members: %s", err)
+		}
+		result := make(map[string]string)
+		for _, m := range r.Members {
+			result[m.MemberID] = m.Status
+		}
+		return result
+	}
+
+	var job struct {
+		JobID string `json:"job_id"`
+	}
+	_, err = imsV1Client.Post(membersURL, map[string]interface{}{
+		"images":   []string{defaultImageID},
+		"projects": []string{"project-a", "project-b"},
+	}, &job, reqOpts)
+	if err != nil {
+		t.Fatalf("Error sharing the image: %s", err)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	if members := listMembers(); len(members) != 2 || members["project-a"] != "pending" {
+		t.Errorf("expected 2 pending members, but got %v", members)
+	}
+
+	_, err = imsV1Client.Put(membersURL, map[string]interface{}{
+		"images":     []string{defaultImageID},
+		"project_id": "project-a",
+		"status":     "accepted",
+	}, &job, reqOpts)
+	if err != nil {
+		t.Fatalf("Error accepting the image: %s", err)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	_, err = imsV1Client.DeleteWithBodyResp(membersURL, map[string]interface{}{
+		"images":   []string{defaultImageID},
+		"projects": []string{"project-b"},
+	}, &job, reqOpts)
+	if err != nil {
+		t.Fatalf("Error stopping sharing the image: %s", err)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	if members := listMembers(); len(members) != 1 || members["project-a"] != "accepted" {
+		t.Errorf("expected the accepted member project-a, but got %v", members)
+	}
+
+	// the copied image is returned in the entities of the job
+	_, err = imsV1Client.Post(imsV1Client.ServiceURL("cloudimages", defaultImageID, "copy"), map[string]interface{}{
+		"name":   "image-copy",
+		"cmk_id": "fake-cmk-id",
+	}, &job, reqOpts)
+	if err != nil {
+		t.Fatalf("Error copying the image: %s", err)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	var jobDetail struct {
+		Entities struct {
+			ImageID string `json:"image_id"`
+		} `json:"entities"`
+	}
+	if _, err := ecsClient.Get(ecsClient.ServiceURL("jobs", job.JobID), &jobDetail, nil); err != nil {
+		t.Fatalf("Error getting the job: %s", err)
+	}
+
+	imageID := jobDetail.Entities.ImageID
+	var image map[string]interface{}
+	if _, err := imsClient.Get(imsClient.ServiceURL("images", imageID), &image, nil); err != nil {
+		t.Fatalf("Error getting the copied image: %s", err)
+	}
+	if image["name"] != "image-copy" || image["status"] != "active" || image["__system__cmkid"] != "fake-cmk-id" {
+		t.Errorf("unexpected copied image: %v", image)
+	}
+
+	if _, err := imsClient.Delete(imsClient.ServiceURL("images", imageID), nil); err != nil {
+		t.Fatalf("Error deleting the copied image: %s", err)
+	}
+	_, err = imsClient.Get(imsClient.ServiceURL("images", imageID), &image, nil)
+	if !common.IsNotFound(err) {
+		t.Errorf("expected the copied image to be deleted, but got %v", err)
+	}
+}
+
+func TestServer_createImages(t *testing.T) {
+	_, c := newTestConfig(t)
+	ecsClient, err := c.ComputeV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsV11Client, err := c.ComputeV11Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evsV21Client, err := c.BlockStorageV21Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imsV1Client, err := c.ImageV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imsClient, err := c.ImageV2Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc, subnet := createTestSubnet(t, c)
+	job, err := cloudservers.Create(ecsV11Client, cloudservers.CreateOpts{
+		ImageRef:         defaultImageID,
+		FlavorRef:        "s6.small.1",
+		Name:             "ecs-test",
+		VpcId:            vpc.ID,
+		Nics:             []cloudservers.Nic{{SubnetId: subnet.ID}},
+		RootVolume:       cloudservers.RootVolume{VolumeType: "SSD"},
+		AvailabilityZone: "cn-north-4a",
+	}).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error creating the server: %s", err)
+	}
+	serverID := waitTestJob(t, ecsClient, job.JobID).Entities.SubJobs[0].Entities.ServerId
+	volumeJob, err := cloudvolumes.Create(evsV21Client, cloudvolumes.CreateOpts{
+		Volume: cloudvolumes.VolumeOpts{
+			AvailabilityZone: "cn-north-4a",
+			VolumeType:       "SAS",
+			Name:             "volume-test",
+			Size:             10,
+		},
+	}).Extract()
+	if err != nil {
+		t.Fatalf("Error creating the volume: %s", err)
+	}
+	volumeID := volumeJob.VolumeIDs[0]
+
+	var jobDetail struct {
+		Entities struct {
+			ImageID       string `json:"image_id"`
+			SubJobsResult []struct {
+				Entities struct {
+					ImageID string `json:"image_id"`
+				} `json:"entities"`
+			} `json:"sub_jobs_result"`
+		} `json:"entities"`
+	}
+	getImage := func(id string) map[string]interface{} {
+		var image map[string]interface{}
+		if _, err := imsClient.Get(imsClient.ServiceURL("images", id), &image, nil); err != nil {
+			t.Fatalf("Error getting the image %s: %s", id, err)
+		}
+		return image
+	}
+
+	// the system disk image is returned by image_id and the data disk images by sub_jobs_result
+	imageJob, err := cloudimages.CreateImageByServer(imsClient, cloudimages.CreateByServerOpts{
+		Name:       "image-test",
+		InstanceId: serverID,
+		DataImages: []cloudimages.DataImage{{Name: "image-test-data-1", VolumeId: volumeID}},
+	}).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error creating the image: %s", err)
+	}
+	waitTestJob(t, ecsClient, imageJob.JobID)
+	if _, err := ecsClient.Get(ecsClient.ServiceURL("jobs", imageJob.JobID), &jobDetail, nil); err != nil {
+		t.Fatalf("Error getting the job: %s", err)
+	}
+	if jobDetail.Entities.ImageID == "" || len(jobDetail.Entities.SubJobsResult) != 1 {
+		t.Fatalf("unexpected entities of the job: %#v", jobDetail.Entities)
+	}
+	if image := getImage(jobDetail.Entities.ImageID); image["status"] != "active" ||
+		image["__data_origin"] != "instance,"+serverID {
+		t.Errorf("unexpected system disk image: %v", image)
+	}
+	dataImage := getImage(jobDetail.Entities.SubJobsResult[0].Entities.ImageID)
+	if dataImage["name"] != "image-test-data-1" || dataImage["virtual_env_type"] != "DataImage" ||
+		dataImage["min_disk"] != float64(10) || dataImage["__data_origin"] != "instance,"+serverID {
+		t.Errorf("unexpected data disk image: %v", dataImage)
+	}
+
+	// the whole image requires the CBR vault
+	reqOpts := &golangsdk.RequestOpts{OkCodes: []int{200}}
+	wholeURL := imsV1Client.ServiceURL("cloudimages", "wholeimages", "action")
+	_, err = imsV1Client.Post(wholeURL, map[string]interface{}{"name": "whole-test", "instance_id": serverID},
+		&job, reqOpts)
+	if _, ok := err.(golangsdk.ErrDefault400); !ok {
+		t.Errorf("expected 400 when creating the whole image without vault, but got %#v", err)
+	}
+	_, err = imsV1Client.Post(wholeURL, map[string]interface{}{
+		"name":        "whole-test",
+		"instance_id": serverID,
+		"vault_id":    "fake-vault-id",
+	}, &job, reqOpts)
+	if err != nil {
+		t.Fatalf("Error creating the whole image: %s", err)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	if _, err := ecsClient.Get(ecsClient.ServiceURL("jobs", job.JobID), &jobDetail, nil); err != nil {
+		t.Fatalf("Error getting the job: %s", err)
+	}
+	if image := getImage(jobDetail.Entities.ImageID); image["__whole_image"] != "true" || image["status"] != "active" {
+		t.Errorf("unexpected whole image: %v", image)
+	}
+}
+
+func TestServer_volumeRetype(t *testing.T) {
+	_, c := newTestConfig(t)
+	ecsClient, err := c.ComputeV1Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecsV11Client, err := c.ComputeV11Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evsClient, err := c.BlockStorageV2Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evsV21Client, err := c.BlockStorageV21Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evsV5Client, err := c.BlockStorageV5Client(c.Region)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var volume struct {
+		Status      string `json:"status"`
+		VolumeType  string `json:"volume_type"`
+		Attachments []struct {
+			ServerID string `json:"server_id"`
+		} `json:"attachments"`
+		Iops *struct {
+			TotalVal int `json:"total_val"`
+		} `json:"iops"`
+		Throughput *struct {
+			TotalVal int `json:"total_val"`
+		} `json:"throughput"`
+	}
+	getVolume := func(id string) {
+		volume.Iops, volume.Throughput = nil, nil
+		var r struct {
+			Volume interface{} `json:"volume"`
+		}
+		r.Volume = &volume
+		if _, err := evsClient.Get(evsClient.ServiceURL("cloudvolumes", id), &r, nil); err != nil {
+			t.Fatalf("Error getting the volume %s: %s", id, err)
+		}
+	}
+	var job struct {
+		JobID     string   `json:"job_id"`
+		VolumeIDs []string `json:"volume_ids"`
+	}
+
+	// the IOPS is invalid for the volume type
+	createURL := evsV21Client.ServiceURL("cloudvolumes")
+	_, err = evsV21Client.Post(createURL, map[string]interface{}{
+		"volume": map[string]interface{}{
+			"availability_zone": "cn-north-4a",
+			"volume_type":       "SAS",
+			"size":              10,
+			"iops":              3000,
+		},
+	}, &job, &golangsdk.RequestOpts{OkCodes: []int{202}})
+	if _, ok := err.(golangsdk.ErrDefault400); !ok {
+		t.Errorf("expected 400 when creating the SAS volume with IOPS, but got %#v", err)
+	}
+	_, err = evsV21Client.Post(createURL, map[string]interface{}{
+		"volume": map[string]interface{}{
+			"availability_zone": "cn-north-4a",
+			"volume_type":       "SAS",
+			"name":              "volume-test",
+			"size":              10,
+		},
+	}, &job, &golangsdk.RequestOpts{OkCodes: []int{202}})
+	if err != nil {
+		t.Fatalf("Error creating the volume: %s", err)
+	}
+	volumeID := job.VolumeIDs[0]
+	waitTestJob(t, ecsClient, job.JobID)
+
+	vpc, subnet := createTestSubnet(t, c)
+	serverJob, err := cloudservers.Create(ecsV11Client, cloudservers.CreateOpts{
+		ImageRef:         defaultImageID,
+		FlavorRef:        "s6.small.1",
+		Name:             "ecs-test",
+		VpcId:            vpc.ID,
+		Nics:             []cloudservers.Nic{{SubnetId: subnet.ID}},
+		RootVolume:       cloudservers.RootVolume{VolumeType: "SSD"},
+		AvailabilityZone: "cn-north-4a",
+	}).ExtractJobResponse()
+	if err != nil {
+		t.Fatalf("Error creating the server: %s", err)
+	}
+	serverID := waitTestJob(t, ecsClient, serverJob.JobID).Entities.SubJobs[0].Entities.ServerId
+	attachJob, err := block_devices.Attach(ecsClient, block_devices.AttachOpts{ServerId: serverID, VolumeId: volumeID})
+	if err != nil {
+		t.Fatalf("Error attaching the volume: %s", err)
+	}
+	waitTestJob(t, ecsClient, attachJob.ID)
+
+	// the attached volume is retyped in place, the unspecified throughput is the default of the new type
+	retypeURL := evsClient.ServiceURL("volumes", volumeID, "retype")
+	_, err = evsClient.Post(retypeURL, map[string]interface{}{
+		"os-retype": map[string]interface{}{"new_type": "GPSSD2", "iops": 128001},
+	}, &job, &golangsdk.RequestOpts{OkCodes: []int{202}})
+	if _, ok := err.(golangsdk.ErrDefault400); !ok {
+		t.Errorf("expected 400 when retyping the volume with the IOPS out of range, but got %#v", err)
+	}
+	_, err = evsClient.Post(retypeURL, map[string]interface{}{
+		"os-retype": map[string]interface{}{"new_type": "GPSSD2", "iops": 5000},
+	}, &job, &golangsdk.RequestOpts{OkCodes: []int{202}})
+	if err != nil {
+		t.Fatalf("Error retyping the volume: %s", err)
+	}
+	getVolume(volumeID)
+	if volume.Status != "retyping" {
+		t.Errorf("expected the volume to be retyping, but got %s", volume.Status)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	getVolume(volumeID)
+	if volume.Status != "in-use" || volume.VolumeType != "GPSSD2" || len(volume.Attachments) != 1 ||
+		volume.Attachments[0].ServerID != serverID || volume.Iops == nil || volume.Iops.TotalVal != 5000 ||
+		volume.Throughput == nil || volume.Throughput.TotalVal != 125 {
+		t.Errorf("unexpected volume after retyping: %#v", volume)
+	}
+
+	// the unspecified IOPS is not changed by the QoS modification
+	qosURL := evsV5Client.ServiceURL("cloudvolumes", volumeID, "qos")
+	_, err = evsV5Client.Put(qosURL, map[string]interface{}{
+		"qos_modify": map[string]interface{}{"throughput": 500},
+	}, &job, &golangsdk.RequestOpts{OkCodes: []int{200}})
+	if err != nil {
+		t.Fatalf("Error modifying the QoS of the volume: %s", err)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	getVolume(volumeID)
+	if volume.Status != "in-use" || volume.Iops == nil || volume.Iops.TotalVal != 5000 ||
+		volume.Throughput == nil || volume.Throughput.TotalVal != 500 {
+		t.Errorf("unexpected volume after modifying the QoS: %#v", volume)
+	}
+
+	// the IOPS and throughput of the old type are removed after retyping to the type without provisioned QoS
+	_, err = evsClient.Post(retypeURL, map[string]interface{}{
+		"os-retype": map[string]interface{}{"new_type": "SSD"},
+	}, &job, &golangsdk.RequestOpts{OkCodes: []int{202}})
+	if err != nil {
+		t.Fatalf("Error retyping the volume: %s", err)
+	}
+	waitTestJob(t, ecsClient, job.JobID)
+	getVolume(volumeID)
+	if volume.VolumeType != "SSD" || volume.Iops != nil || volume.Throughput != nil {
+		t.Errorf("unexpected volume after retyping: %#v", volume)
+	}
+	_, err = evsV5Client.Put(qosURL, map[string]interface{}{
+		"qos_modify": map[string]interface{}{"iops": 5000},
+	}, &job, &golangsdk.RequestOpts{OkCodes: []int{200}})
+	if _, ok := err.(golangsdk.ErrDefault400); !ok {
+		t.Errorf("expected 400 when modifying the QoS of the SSD volume, but got %#v", err)
+	}
+}
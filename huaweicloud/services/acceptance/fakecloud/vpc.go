@@ -0,0 +1,368 @@
+package fakecloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+func (s *Server) registerVPCRoutes() {
+	s.handle("POST", withProject("/v1", "vpcs"), s.createVpc)
+	s.handle("GET", withProject("/v1", "vpcs"), s.listVpcs)
+	s.handle("GET", withProject("/v1", "vpcs/"+idPattern), s.getVpc)
+	s.handle("PUT", withProject("/v1", "vpcs/"+idPattern), s.updateVpc)
+	s.handle("DELETE", withProject("/v1", "vpcs/"+idPattern), s.deleteVpc)
+
+	s.handle("POST", withProject("/v1", "subnets"), s.createSubnet)
+	s.handle("GET", withProject("/v1", "subnets"), s.listSubnets)
+	s.handle("GET", withProject("/v1", "subnets/"+idPattern), s.getSubnet)
+	s.handle("PUT", withProject("/v1", "vpcs/"+idPattern+"/subnets/"+idPattern), s.updateSubnet)
+	s.handle("DELETE", withProject("/v1", "vpcs/"+idPattern+"/subnets/"+idPattern), s.deleteSubnet)
+
+	s.handle("POST", withProject("/v3", "vpc/security-groups"), s.createSecurityGroup)
+	s.handle("GET", withProject("/v3", "vpc/security-groups"), s.listSecurityGroups)
+	s.handle("GET", withProject("/v3", "vpc/security-groups/"+idPattern), s.getSecurityGroup)
+	s.handle("PUT", withProject("/v3", "vpc/security-groups/"+idPattern), s.updateSecurityGroup)
+	s.handle("DELETE", withProject("/v3", "vpc/security-groups/"+idPattern), s.deleteSecurityGroup)
+	s.handle("DELETE", withProject("/v3", "vpc/security-group-rules/"+idPattern), s.deleteSecurityGroupRule)
+
+	// the tags of VPC (v2.0), ECS (v1) and EVS (v2) resources, the project ID is optional in the path of v2.0 API
+	tagsPath := `/v[0-9.]+/(?:[^/]+/)?(vpcs|subnets|cloudservers|cloudvolumes)/` + idPattern + `/tags`
+	s.handle("GET", tagsPath, s.getTags)
+	s.handle("POST", tagsPath+"/action", s.updateTags)
+}
+
+func (s *Server) createVpc(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "vpc")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"name":                  body["name"],
+		"cidr":                  body["cidr"],
+		"description":           body["description"],
+		"enterprise_project_id": "0",
+		"routes":                []interface{}{},
+	}
+	if v, ok := body["enterprise_project_id"].(string); ok && v != "" {
+		data["enterprise_project_id"] = v
+	}
+
+	vpc := s.create("vpc", data, "CREATING", "OK")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc.data})
+}
+
+func (s *Server) listVpcs(w http.ResponseWriter, r *http.Request, _ []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"vpcs": filterObjects(s.list("vpc"), r, "id", "enterprise_project_id"),
+	})
+}
+
+func (s *Server) getVpc(w http.ResponseWriter, r *http.Request, params []string) {
+	vpc := s.get("vpc", params[0])
+	if vpc == nil {
+		writeNotFound(w, "VPC", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc.data})
+}
+
+func (s *Server) updateVpc(w http.ResponseWriter, r *http.Request, params []string) {
+	vpc := s.lookup("vpc", params[0])
+	if vpc == nil {
+		writeNotFound(w, "VPC", params[0])
+		return
+	}
+
+	body, err := readBody(r, "vpc")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	mergeInto(vpc.data, body)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"vpc": vpc.data})
+}
+
+// deleteVpc returns 409 if there are subnets in the VPC
+func (s *Server) deleteVpc(w http.ResponseWriter, r *http.Request, params []string) {
+	if s.lookup("vpc", params[0]) == nil {
+		writeNotFound(w, "VPC", params[0])
+		return
+	}
+
+	for _, subnet := range s.list("subnet") {
+		if subnet.data["vpc_id"] == params[0] {
+			writeError(w, http.StatusConflict, "VPC.0111",
+				fmt.Sprintf("the VPC %s is still used by subnet %s", params[0], subnet.data["id"]))
+			return
+		}
+	}
+
+	s.remove("vpc", params[0], "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createSubnet(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "subnet")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	vpcID, _ := body["vpc_id"].(string)
+	if s.lookup("vpc", vpcID) == nil {
+		writeError(w, http.StatusBadRequest, "VPC.0202", fmt.Sprintf("the VPC %s does not exist", vpcID))
+		return
+	}
+
+	data := map[string]interface{}{
+		"dhcp_enable":       true,
+		"ipv6_enable":       false,
+		"dnsList":           []interface{}{},
+		"availability_zone": "",
+	}
+	mergeInto(data, body)
+	data["id"] = s.newID()
+	data["neutron_subnet_id"] = s.newID()
+	data["neutron_network_id"] = data["id"]
+
+	subnet := s.create("subnet", data, "UNKNOWN", "ACTIVE")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"subnet": subnet.data})
+}
+
+func (s *Server) listSubnets(w http.ResponseWriter, r *http.Request, _ []string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"subnets": filterObjects(s.list("subnet"), r, "vpc_id"),
+	})
+}
+
+func (s *Server) getSubnet(w http.ResponseWriter, r *http.Request, params []string) {
+	subnet := s.get("subnet", params[0])
+	if subnet == nil {
+		writeNotFound(w, "subnet", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"subnet": subnet.data})
+}
+
+func (s *Server) updateSubnet(w http.ResponseWriter, r *http.Request, params []string) {
+	subnet := s.lookup("subnet", params[1])
+	if subnet == nil || subnet.data["vpc_id"] != params[0] {
+		writeNotFound(w, "subnet", params[1])
+		return
+	}
+
+	body, err := readBody(r, "subnet")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	mergeInto(subnet.data, body)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"subnet": map[string]interface{}{
+			"id":     params[1],
+			"status": subnet.data["status"],
+		},
+	})
+}
+
+// deleteSubnet returns 409 if there are ECS instances in the subnet
+func (s *Server) deleteSubnet(w http.ResponseWriter, r *http.Request, params []string) {
+	subnet := s.lookup("subnet", params[1])
+	if subnet == nil || subnet.data["vpc_id"] != params[0] {
+		writeNotFound(w, "subnet", params[1])
+		return
+	}
+
+	for _, server := range s.list("server") {
+		if server.refs["subnet_id"] == params[1] {
+			writeError(w, http.StatusConflict, "VPC.0606",
+				fmt.Sprintf("the subnet %s is still used by instance %s", params[1], server.data["id"]))
+			return
+		}
+	}
+
+	s.remove("subnet", params[1], "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createSecurityGroup(w http.ResponseWriter, r *http.Request, _ []string) {
+	body, err := readBody(r, "security_group")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+
+	data := map[string]interface{}{
+		"description":           "",
+		"enterprise_project_id": "0",
+		"project_id":            s.ProjectID,
+		"created_at":            nowString(),
+		"updated_at":            nowString(),
+	}
+	mergeInto(data, body)
+	secgroup := s.create("security_group", data, "", "")
+	delete(secgroup.data, "status")
+
+	// the default rules allow the egress traffic
+	id := secgroup.data["id"].(string)
+	rules := make([]interface{}, 0, 2)
+	for _, ethertype := range []string{"IPv4", "IPv6"} {
+		rule := s.create("security_group_rule", map[string]interface{}{
+			"security_group_id": id,
+			"direction":         "egress",
+			"ethertype":         ethertype,
+			"action":            "allow",
+			"priority":          "100",
+			"project_id":        s.ProjectID,
+		}, "", "")
+		delete(rule.data, "status")
+		rules = append(rules, rule.data)
+	}
+	secgroup.data["security_group_rules"] = rules
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"security_group": secgroup.data})
+}
+
+func (s *Server) listSecurityGroups(w http.ResponseWriter, r *http.Request, _ []string) {
+	secgroups := s.list("security_group")
+
+	// the marker is the ID of the last security group in the previous page
+	if marker := r.URL.Query().Get("marker"); marker != "" {
+		for i, secgroup := range secgroups {
+			if secgroup.data["id"] == marker {
+				secgroups = secgroups[i+1:]
+				break
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"security_groups": filterObjects(secgroups, r, "id", "name", "enterprise_project_id"),
+	})
+}
+
+func (s *Server) getSecurityGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	secgroup := s.get("security_group", params[0])
+	if secgroup == nil {
+		writeNotFound(w, "security group", params[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group": secgroup.data})
+}
+
+func (s *Server) updateSecurityGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	secgroup := s.lookup("security_group", params[0])
+	if secgroup == nil {
+		writeNotFound(w, "security group", params[0])
+		return
+	}
+
+	body, err := readBody(r, "security_group")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	mergeInto(secgroup.data, body)
+	secgroup.data["updated_at"] = nowString()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"security_group": secgroup.data})
+}
+
+func (s *Server) deleteSecurityGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	if s.lookup("security_group", params[0]) == nil {
+		writeNotFound(w, "security group", params[0])
+		return
+	}
+
+	for _, rule := range s.list("security_group_rule") {
+		if rule.data["security_group_id"] == params[0] {
+			s.remove("security_group_rule", rule.data["id"].(string), "")
+		}
+	}
+	s.remove("security_group", params[0], "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteSecurityGroupRule(w http.ResponseWriter, r *http.Request, params []string) {
+	rule := s.lookup("security_group_rule", params[0])
+	if rule == nil {
+		writeNotFound(w, "security group rule", params[0])
+		return
+	}
+	s.remove("security_group_rule", params[0], "")
+
+	// remove the rule from the security group
+	if secgroup := s.lookup("security_group", rule.data["security_group_id"].(string)); secgroup != nil {
+		rules, _ := secgroup.data["security_group_rules"].([]interface{})
+		remained := make([]interface{}, 0, len(rules))
+		for _, v := range rules {
+			if v.(map[string]interface{})["id"] != params[0] {
+				remained = append(remained, v)
+			}
+		}
+		secgroup.data["security_group_rules"] = remained
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tagsKinds maps the resource type in the path of tags API to the kind of the object
+var tagsKinds = map[string]string{
+	"vpcs":         "vpc",
+	"subnets":      "subnet",
+	"cloudservers": "server",
+	"cloudvolumes": "volume",
+}
+
+func (s *Server) getTags(w http.ResponseWriter, r *http.Request, params []string) {
+	obj := s.lookup(tagsKinds[params[0]], params[1])
+	if obj == nil {
+		writeNotFound(w, params[0], params[1])
+		return
+	}
+
+	tags := obj.tags
+	if tags == nil {
+		tags = []interface{}{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tags": tags})
+}
+
+// updateTags creates or deletes the tags with the action in the request body
+func (s *Server) updateTags(w http.ResponseWriter, r *http.Request, params []string) {
+	obj := s.lookup(tagsKinds[params[0]], params[1])
+	if obj == nil {
+		writeNotFound(w, params[0], params[1])
+		return
+	}
+
+	body, err := readBody(r, "")
+	if err != nil {
+		writeBadRequest(w, err)
+		return
+	}
+	tags, _ := body["tags"].([]interface{})
+
+	// the existing tags with the same key are replaced or deleted
+	keys := make(map[interface{}]bool)
+	for _, tag := range tags {
+		keys[tag.(map[string]interface{})["key"]] = true
+	}
+	remained := make([]interface{}, 0, len(obj.tags)+len(tags))
+	for _, tag := range obj.tags {
+		if !keys[tag.(map[string]interface{})["key"]] {
+			remained = append(remained, tag)
+		}
+	}
+
+	switch body["action"] {
+	case "create":
+		remained = append(remained, tags...)
+	case "delete":
+	default:
+		writeError(w, http.StatusBadRequest, "FAKE.0400", fmt.Sprintf("invalid tag action: %v", body["action"]))
+		return
+	}
+	obj.tags = remained
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -65,6 +65,12 @@ func TestAccFunctionGraphTrigger_basic(t *testing.T) {
 						"${huaweicloud_fgs_function.test.urn}"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acceptance.ImportStateIdWithAttributes(resourceName, "function_urn"),
+			},
 		},
 	})
 }
@@ -66,6 +66,12 @@ func TestAccIdentityAccessKey_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "description", "access key by terraform updated"),
 				),
 			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_file", "secret", "user_name"},
+			},
 		},
 	})
 }
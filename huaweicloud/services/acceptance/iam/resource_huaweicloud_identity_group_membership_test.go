@@ -46,6 +46,11 @@ func TestAccIdentityV3GroupMembership_basic(t *testing.T) {
 					testAccCheckIdentityV3GroupMembershipExists(resourceName, []string{userName2}),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
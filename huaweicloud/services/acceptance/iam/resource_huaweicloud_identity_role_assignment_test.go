@@ -48,6 +48,11 @@ func TestAccIdentityV3RoleAssignment_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "domain_id", acceptance.HW_DOMAIN_ID),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
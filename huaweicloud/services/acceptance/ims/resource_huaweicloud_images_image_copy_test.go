@@ -0,0 +1,130 @@
+package ims
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chnsz/golangsdk/openstack/ims/v2/cloudimages"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getImageCopyResourceFunc(conf *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	region := acceptance.HW_REGION_NAME
+	if v := state.Primary.Attributes["target_region"]; v != "" {
+		region = v
+	}
+	c, err := conf.ImageV2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating HuaweiCloud image client: %s", err)
+	}
+
+	allPages, err := cloudimages.List(c, &cloudimages.ListOpts{ID: state.Primary.ID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	images, err := cloudimages.ExtractImages(allPages)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) < 1 {
+		return nil, fmt.Errorf("the image %s does not exist", state.Primary.ID)
+	}
+	return &images[0], nil
+}
+
+func TestAccImsImageCopy_fakeCloud(t *testing.T) {
+	var image cloudimages.Image
+	srv := acceptance.NewFakeCloud(t)
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_images_image_copy.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&image,
+		getImageCopyResourceFunc,
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccImsImageCopy_basic("fake-image-id", rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "cmk_id", "fake-cmk-id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "active"),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccImsImageCopy_basic("fake-image-id", rName+"_update"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"_update"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccImsImageCopy_crossRegion(t *testing.T) {
+	var image cloudimages.Image
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_images_image_copy.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&image,
+		getImageCopyResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckImage(t)
+			acceptance.TestAccPreCheckReplication(t)
+			if acceptance.HW_IMS_AGENCY_NAME == "" {
+				t.Skip("HW_IMS_AGENCY_NAME must be set for the cross-region copy acceptance test")
+			}
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImsImageCopy_crossRegion(rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "target_region", acceptance.HW_DEST_REGION),
+					resource.TestCheckResourceAttr(resourceName, "status", "active"),
+				),
+			},
+		},
+	})
+}
+
+func testAccImsImageCopy_basic(imageID, name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_images_image_copy" "test" {
+  source_image_id = "%s"
+  name            = "%s"
+  cmk_id          = "fake-cmk-id"
+}
+`, imageID, name)
+}
+
+func testAccImsImageCopy_crossRegion(name string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_images_image_copy" "test" {
+  source_image_id = "%s"
+  name            = "%s"
+  target_region   = "%s"
+  agency_name     = "%s"
+}
+`, acceptance.HW_IMAGE_ID, name, acceptance.HW_DEST_REGION, acceptance.HW_IMS_AGENCY_NAME)
+}
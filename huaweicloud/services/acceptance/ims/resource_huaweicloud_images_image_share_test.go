@@ -0,0 +1,198 @@
+package ims
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+type imageMembers struct {
+	Members []struct {
+		MemberID string `json:"member_id"`
+		Status   string `json:"status"`
+	} `json:"members"`
+}
+
+func getImageShareResourceFunc(conf *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	c, err := conf.ImageV2Client(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating HuaweiCloud image client: %s", err)
+	}
+
+	var r imageMembers
+	if _, err := c.Get(c.ServiceURL("images", state.Primary.ID, "members"), &r, nil); err != nil {
+		return nil, err
+	}
+	if len(r.Members) == 0 {
+		return nil, fmt.Errorf("the image %s is not shared with any project", state.Primary.ID)
+	}
+	return &r, nil
+}
+
+func getImageShareAccepterResourceFunc(conf *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	c, err := conf.ImageV2Client(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating HuaweiCloud image client: %s", err)
+	}
+	projectID, err := conf.GetProjectID(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, err
+	}
+
+	var member struct {
+		Status string `json:"status"`
+	}
+	if _, err := c.Get(c.ServiceURL("images", state.Primary.ID, "members", projectID), &member, nil); err != nil {
+		return nil, err
+	}
+	if member.Status != "accepted" {
+		return nil, fmt.Errorf("the shared image %s is %s", state.Primary.ID, member.Status)
+	}
+	return &member, nil
+}
+
+func TestAccImsImageShare_basic(t *testing.T) {
+	var members imageMembers
+	resourceName := "huaweicloud_images_image_share.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&members,
+		getImageShareResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			acceptance.TestAccPreCheck(t)
+			acceptance.TestAccPreCheckImage(t)
+			acceptance.TestAccPreCheckReplication(t)
+		},
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImsImageShare_basic(acceptance.HW_IMAGE_ID, acceptance.HW_DEST_PROJECT_ID),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "source_image_id", acceptance.HW_IMAGE_ID),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.project_id", acceptance.HW_DEST_PROJECT_ID),
+					resource.TestCheckResourceAttr(resourceName, "members.0.status", "pending"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// the projects are added and removed in place, and the shared image is accepted by the project of the provider
+func TestAccImsImageShare_fakeCloud(t *testing.T) {
+	var members imageMembers
+	srv := acceptance.NewFakeCloud(t)
+	resourceName := "huaweicloud_images_image_share.test"
+	accepterName := "huaweicloud_images_image_share_accepter.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&members,
+		getImageShareResourceFunc,
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccImsImageShare_fakeCloud(srv.ProjectID, "fake-project-id"),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "target_project_ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckResourceAttr(accepterName, "status", "accepted"),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccImsImageShare_fakeCloud(srv.ProjectID),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "target_project_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "members.0.project_id", srv.ProjectID),
+					resource.TestCheckResourceAttr(resourceName, "members.0.status", "accepted"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccImsImageShareAccepter_fakeCloud(t *testing.T) {
+	var member struct {
+		Status string `json:"status"`
+	}
+	srv := acceptance.NewFakeCloud(t)
+	resourceName := "huaweicloud_images_image_share_accepter.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&member,
+		getImageShareAccepterResourceFunc,
+	)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccImsImageShare_fakeCloud(srv.ProjectID),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "image_id", "fake-image-id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "accepted"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccImsImageShare_basic(imageID, projectID string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_images_image_share" "test" {
+  source_image_id    = "%s"
+  target_project_ids = ["%s"]
+}
+`, imageID, projectID)
+}
+
+// the image is shared with the project of the provider itself in the fake cloud, so it can be accepted
+func testAccImsImageShare_fakeCloud(projectIDs ...string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_images_image_share" "test" {
+  source_image_id    = "fake-image-id"
+  target_project_ids = ["%s"]
+}
+
+resource "huaweicloud_images_image_share_accepter" "test" {
+  image_id = huaweicloud_images_image_share.test.source_image_id
+}
+`, strings.Join(projectIDs, `", "`))
+}
@@ -64,6 +64,12 @@ func TestAccLBV2Member_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("huaweicloud_lb_member.member_2", "weight", "15"),
 				),
 			},
+			{
+				ResourceName:      resourceName1,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: acceptance.ImportStateIdWithAttributes(resourceName1, "pool_id"),
+			},
 		},
 	})
 }
@@ -0,0 +1,82 @@
+package acceptance
+
+import (
+	"math/rand"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+)
+
+const (
+	// defaultCassettePath is relative to the directory of the test package
+	defaultCassettePath = "testdata/cassette.json"
+	// recorderRandSeed makes the random names of the resources same in the record and replay runs
+	recorderRandSeed = 20211001
+)
+
+var (
+	testRecorder     *config.HTTPRecorder
+	testRecorderErr  error
+	testRecorderOnce sync.Once
+)
+
+func init() {
+	// acctest seeds math/rand with the current time, override it before any random names are generated
+	if HW_ACCEPTANCE_MODE != "" {
+		rand.Seed(recorderRandSeed)
+	}
+}
+
+// preCheckHTTPRecorder starts the recorder of the test package with HW_ACCEPTANCE_MODE,
+// the recorded interactions are saved into the cassette after each test in record mode.
+func preCheckHTTPRecorder(t *testing.T) {
+	if HW_ACCEPTANCE_MODE == "" {
+		return
+	}
+
+	testRecorderOnce.Do(func() {
+		testRecorder, testRecorderErr = startHTTPRecorder()
+	})
+	if testRecorderErr != nil {
+		t.Fatalf("Error starting the HTTP recorder: %s", testRecorderErr)
+	}
+
+	if testRecorder.Mode() == config.RecordModeRecord {
+		t.Cleanup(func() {
+			if err := testRecorder.Save(); err != nil {
+				t.Errorf("Error saving the cassette: %s", err)
+			}
+		})
+	}
+}
+
+func startHTTPRecorder() (*config.HTTPRecorder, error) {
+	path := HW_ACCEPTANCE_CASSETTE
+	if path == "" {
+		path = defaultCassettePath
+	}
+
+	recorder, err := config.NewHTTPRecorder(HW_ACCEPTANCE_MODE, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the server serves the requests of huaweicloud-sdk-go-v3 clients until the test process exits
+	server := httptest.NewTLSServer(recorder)
+	recorder.SetLoopback(server.Listener.Addr().String())
+
+	if recorder.Mode() == config.RecordModeReplay {
+		// the requests are signed but never verified in replay mode
+		for _, env := range []string{"HW_ACCESS_KEY", "HW_SECRET_KEY"} {
+			if os.Getenv(env) == "" {
+				os.Setenv(env, "replay")
+			}
+		}
+	}
+
+	config.SetHTTPRecorder(recorder)
+	return recorder, nil
+}
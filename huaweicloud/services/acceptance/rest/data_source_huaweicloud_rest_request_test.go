@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func TestAccRestRequestDataSource_basic(t *testing.T) {
+	rName := acceptance.RandomAccResourceName()
+	dataSourceName := "data.huaweicloud_rest_request.test"
+
+	dc := acceptance.InitDataSourceCheck(dataSourceName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestRequestDataSource_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(dataSourceName, "status_code", "200"),
+					resource.TestCheckResourceAttr(dataSourceName, "result", fmt.Sprintf("%q", rName)),
+					resource.TestCheckResourceAttrSet(dataSourceName, "response_body"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRestRequestDataSource_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%s"
+  cidr = "192.168.0.0/16"
+}
+
+data "huaweicloud_rest_request" "test" {
+  service = "vpc"
+  path    = "v1/{project_id}/vpcs/${huaweicloud_vpc.test.id}"
+
+  result_path = "vpc.name"
+}
+`, rName)
+}
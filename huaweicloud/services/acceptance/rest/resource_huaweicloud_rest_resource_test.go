@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/services/acceptance"
+)
+
+func getVpcResourceFunc(c *config.Config, state *terraform.ResourceState) (interface{}, error) {
+	client, err := c.NetworkingV1Client(acceptance.HW_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HuaweiCloud VPC client: %s", err)
+	}
+	return vpcs.Get(client, state.Primary.ID).Extract()
+}
+
+func TestAccRestResource_basic(t *testing.T) {
+	var vpc vpcs.Vpc
+	rName := acceptance.RandomAccResourceName()
+	rNameUpdate := rName + "_update"
+	resourceName := "huaweicloud_rest_resource.test"
+
+	rc := acceptance.InitResourceCheck(
+		resourceName,
+		&vpc,
+		getVpcResourceFunc,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      rc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestResource_basic(rName, rName),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "service", "vpc"),
+					resource.TestCheckResourceAttr(resourceName, "result", fmt.Sprintf("%q", rName)),
+				),
+			},
+			{
+				Config: testAccRestResource_basic(rName, rNameUpdate),
+				Check: resource.ComposeTestCheckFunc(
+					rc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(resourceName, "result", fmt.Sprintf("%q", rNameUpdate)),
+				),
+			},
+		},
+	})
+}
+
+func testAccRestResource_basic(name, updateName string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_rest_resource" "test" {
+  service     = "vpc"
+  create_path = "v1/{project_id}/vpcs"
+  create_body = jsonencode({
+    vpc = {
+      name = "%s"
+      cidr = "192.168.0.0/16"
+    }
+  })
+  id_path = "vpc.id"
+
+  read_path        = "v1/{project_id}/vpcs/{id}"
+  read_result_path = "vpc.name"
+
+  update_path = "v1/{project_id}/vpcs/{id}"
+  update_body = jsonencode({
+    vpc = {
+      name = "%s"
+    }
+  })
+
+  status_polling {
+    status_path    = "vpc.status"
+    success_values = ["OK"]
+    interval       = 3
+  }
+}
+`, name, updateName)
+}
@@ -239,3 +239,64 @@ data "huaweicloud_vpcs" "test" {
 }
 `, rName1, rName1, rName2, rName2, rName1)
 }
+
+func TestAccVpcsDataSource_filter(t *testing.T) {
+	randName := acceptance.RandomAccResourceName()
+	randCidr := acceptance.RandomCidr()
+	dataSourceName := "data.huaweicloud_vpcs.test"
+
+	dc := acceptance.InitDataSourceCheck(dataSourceName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { acceptance.TestAccPreCheck(t) },
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      dc.CheckResourceDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVpcs_filter(randName, randCidr),
+				Check: resource.ComposeTestCheckFunc(
+					dc.CheckResourceExists(),
+					resource.TestCheckResourceAttr(dataSourceName, "vpcs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "vpcs.0.name", randName),
+					resource.TestCheckResourceAttr(dataSourceName, "vpcs.0.tags.owner", "terraform"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceVpcs_filter(rName, cidr string) string {
+	return fmt.Sprintf(`
+resource "huaweicloud_vpc" "test" {
+  name = "%[1]s"
+  cidr = "%[2]s"
+
+  tags = {
+    owner = "terraform"
+  }
+}
+
+data "huaweicloud_vpcs" "test" {
+  filter {
+    name     = "name"
+    values   = ["^%[1]s$"]
+    match_by = "regex"
+  }
+
+  filter {
+    name   = "tags.owner"
+    values = ["terraform"]
+  }
+
+  filter {
+    name   = "status"
+    values = ["ERROR"]
+    negate = true
+  }
+
+  depends_on = [
+    huaweicloud_vpc.test
+  ]
+}
+`, rName, cidr)
+}
@@ -57,6 +57,40 @@ func TestAccVpcV1_basic(t *testing.T) {
 	})
 }
 
+// TestAccVpcV1_fakeCloud runs the basic case against the in-process fake cloud, no credentials are required.
+func TestAccVpcV1_fakeCloud(t *testing.T) {
+	var vpc vpcs.Vpc
+
+	srv := acceptance.NewFakeCloud(t)
+	rName := acceptance.RandomAccResourceName()
+	resourceName := "huaweicloud_vpc.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: acceptance.TestAccProviderFactories,
+		CheckDestroy:      testAccCheckVpcV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: srv.ProviderConfig() + testAccVpcV1_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1Exists(resourceName, &vpc),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "status", "OK"),
+					resource.TestCheckResourceAttr(resourceName, "tags.foo", "bar"),
+				),
+			},
+			{
+				Config: srv.ProviderConfig() + testAccVpcV1_update(rName+"_updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcV1Exists(resourceName, &vpc),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"_updated"),
+					resource.TestCheckResourceAttr(resourceName, "description", "updated by acc test"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key", "value_updated"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVpcV1_WithEpsId(t *testing.T) {
 	var vpc vpcs.Vpc
 
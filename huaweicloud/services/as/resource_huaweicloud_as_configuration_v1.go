@@ -22,6 +22,10 @@ func ResourceASConfiguration() *schema.Resource {
 		Update: nil,
 		Delete: resourceASConfigurationDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceASConfigurationImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -165,6 +169,13 @@ func ResourceASConfiguration() *schema.Resource {
 							Optional: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
+						"charging_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"postPaid", "spot",
+							}, false),
+						},
 					},
 				},
 			},
@@ -172,6 +183,25 @@ func ResourceASConfiguration() *schema.Resource {
 	}
 }
 
+// configurationCreateOptsExt adds the market type to the instance_config of the creation request,
+// it's not supported by configurations.InstanceConfigOpts.
+type configurationCreateOptsExt struct {
+	configurations.CreateOptsBuilder
+	MarketType string
+}
+
+func (opts configurationCreateOptsExt) ToConfigurationCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOptsBuilder.ToConfigurationCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.MarketType != "" {
+		b["instance_config"].(map[string]interface{})["market_type"] = opts.MarketType
+	}
+	return b, nil
+}
+
 func getDisk(diskMeta []interface{}) ([]configurations.DiskOpts, error) {
 	var diskOptsList []configurations.DiskOpts
 
@@ -293,10 +323,17 @@ func resourceASConfigurationCreate(d *schema.ResourceData, meta interface{}) err
 	if err1 != nil {
 		return fmtp.Errorf("Error when getting instance_config info: %s", err1)
 	}
-	createOpts := configurations.CreateOpts{
+	var createOpts configurations.CreateOptsBuilder = configurations.CreateOpts{
 		Name:           d.Get("scaling_configuration_name").(string),
 		InstanceConfig: instanceConfig,
 	}
+	// the instances of the scaling group are created in the spot pricing
+	if configDataMap["charging_mode"].(string) == "spot" {
+		createOpts = configurationCreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			MarketType:        "spot",
+		}
+	}
 
 	logp.Printf("[DEBUG] Create AS configuration Options: %#v", createOpts)
 	asConfigId, err := configurations.Create(asClient, createOpts).Extract()
@@ -322,6 +359,8 @@ func resourceASConfigurationRead(d *schema.ResourceData, meta interface{}) error
 	}
 
 	logp.Printf("[DEBUG] Retrieved ASConfiguration %q: %+v", d.Id(), asConfig)
+	d.Set("region", config.GetRegion(d))
+	d.Set("scaling_configuration_name", asConfig.Name)
 
 	return nil
 }
@@ -424,3 +463,90 @@ func resourceASConfigurationValidateName(v interface{}, k string) (ws []string,
 	}
 	return
 }
+
+func resourceASConfigurationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	asClient, err := config.AutoscalingV1Client(config.GetRegion(d))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud autoscaling client: %s", err)
+	}
+
+	r := configurations.Get(asClient, d.Id())
+	asConfig, err := r.Extract()
+	if err != nil {
+		return nil, fmtp.Errorf("Error retrieving AS configuration %s: %s", d.Id(), err)
+	}
+	logp.Printf("[DEBUG] Retrieved AS configuration %s during the import", d.Id())
+
+	// the market_type is missing from configurations.InstanceConfig
+	var marketConfig struct {
+		Configuration struct {
+			InstanceConfig struct {
+				MarketType string `json:"market_type"`
+			} `json:"instance_config"`
+		} `json:"scaling_configuration"`
+	}
+	if err := r.ExtractInto(&marketConfig); err != nil {
+		return nil, fmtp.Errorf("Error retrieving the market type of AS configuration %s: %s", d.Id(), err)
+	}
+
+	instanceConfig := flattenInstanceConfig(asConfig.InstanceConfig)
+	if marketConfig.Configuration.InstanceConfig.MarketType == "spot" {
+		instanceConfig[0]["charging_mode"] = "spot"
+	}
+
+	// instance_config is only set during the import, the user_data and kms_id are missing from the API response
+	if err := d.Set("instance_config", instanceConfig); err != nil {
+		return nil, fmtp.Errorf("Error setting instance_config of AS configuration %s: %s", d.Id(), err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func flattenInstanceConfig(instanceConfig configurations.InstanceConfig) []map[string]interface{} {
+	disks := make([]map[string]interface{}, len(instanceConfig.Disk))
+	for i, disk := range instanceConfig.Disk {
+		disks[i] = map[string]interface{}{
+			"size":        disk.Size,
+			"volume_type": disk.VolumeType,
+			"disk_type":   disk.DiskType,
+		}
+	}
+
+	personalities := make([]map[string]interface{}, len(instanceConfig.Personality))
+	for i, personality := range instanceConfig.Personality {
+		personalities[i] = map[string]interface{}{
+			"path":    personality.Path,
+			"content": personality.Content,
+		}
+	}
+
+	result := map[string]interface{}{
+		"instance_id": instanceConfig.InstanceID,
+		"flavor":      instanceConfig.FlavorRef,
+		"image":       instanceConfig.ImageRef,
+		"key_name":    instanceConfig.SSHKey,
+		"disk":        disks,
+		"personality": personalities,
+		"metadata":    instanceConfig.Metadata,
+	}
+
+	if eip := instanceConfig.PublicIp.Eip; eip.Type != "" {
+		result["public_ip"] = []map[string]interface{}{
+			{
+				"eip": []map[string]interface{}{
+					{
+						"ip_type": eip.Type,
+						"bandwidth": []map[string]interface{}{
+							{
+								"size":          eip.Bandwidth.Size,
+								"share_type":    eip.Bandwidth.ShareType,
+								"charging_mode": eip.Bandwidth.ChargingMode,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return []map[string]interface{}{result}
+}
@@ -21,6 +21,10 @@ func ResourceASPolicy() *schema.Resource {
 		Update: resourceASPolicyUpdate,
 		Delete: resourceASPolicyDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -225,6 +229,7 @@ func resourceASPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	logp.Printf("[DEBUG] Retrieved ASPolicy %q: %+v", d.Id(), asPolicy)
+	d.Set("scaling_group_id", asPolicy.ID)
 	d.Set("scaling_policy_name", asPolicy.Name)
 	d.Set("scaling_policy_type", asPolicy.Type)
 	d.Set("alarm_id", asPolicy.AlarmID)
@@ -339,7 +339,7 @@ func resourceBmsInstanceCreate(ctx context.Context, d *schema.ResourceData, meta
 		createOpts.RootVolume = &volRequest
 	}
 
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		createOpts.ServerTags = taglist
@@ -206,7 +206,7 @@ func getCbrPolicyOfSpecificVault(client *golangsdk.ServiceClient, vaultId string
 }
 
 func setCbrAllVaultParameters(client *golangsdk.ServiceClient, d *schema.ResourceData,
-	vaultList []interface{}) error {
+	vaultList []interface{}, tagsConfig *utils.TagsConfig) error {
 	result := make([]map[string]interface{}, len(vaultList))
 	ids := make([]string, len(vaultList))
 	for i, val := range vaultList {
@@ -225,7 +225,7 @@ func setCbrAllVaultParameters(client *golangsdk.ServiceClient, d *schema.Resourc
 			"spec_code":             vault.Billing.SpecCode,
 			"storage":               vault.Billing.StorageUnit,
 			"auto_expand_enabled":   vault.AutoExpand,
-			"tags":                  utils.TagsToMap(vault.Tags),
+			"tags":                  utils.TagsToMap(vault.Tags, tagsConfig),
 			"resources":             makeCbrVaultResources(vault.Billing.ObjectType, vault.Resources),
 		}
 
@@ -267,7 +267,7 @@ func dataSourceCbrVaultsV3Read(_ context.Context, d *schema.ResourceData, meta i
 	}
 
 	// Set the ID and other parameters.
-	err = setCbrAllVaultParameters(client, d, vaultList)
+	err = setCbrAllVaultParameters(client, d, vaultList, config.TagsConfig)
 	if err != nil {
 		return fmtp.DiagErrorf("Error setting vaults parameter: %s", err)
 	}
@@ -302,7 +302,7 @@ func resourceCBRVaultV3Create(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	if err := utils.UpdateResourceTags(client, d, "vault", d.Id()); err != nil {
+	if err := utils.UpdateResourceTags(client, d, "vault", d.Id(), config.TagsConfig); err != nil {
 		return fmtp.Errorf("Error setting tags of CBR vault: %s", err)
 	}
 
@@ -413,7 +413,7 @@ func resourceCBRVaultV3Read(d *schema.ResourceData, meta interface{}) error {
 		d.Set("size", resp.Billing.Size),
 		d.Set("auto_expand", resp.AutoExpand),
 		d.Set("enterprise_project_id", resp.EnterpriseProjectID),
-		d.Set("tags", utils.TagsToMap(resp.Tags)),
+		d.Set("tags", utils.FlattenResourceTags(d, resp.Tags, config.TagsConfig)),
 		setCbrResources(d, resp.Billing.ObjectType, resp.Resources),
 		setCbrPolicyId(d, client),
 		// Computed
@@ -526,7 +526,7 @@ func resourceCBRVaultV3Update(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if d.HasChange("tags") {
-		if err = utils.UpdateResourceTags(client, d, "vault", d.Id()); err != nil {
+		if err = utils.UpdateResourceTags(client, d, "vault", d.Id(), config.TagsConfig); err != nil {
 			return fmtp.Errorf("Failed to update tags: %s", err)
 		}
 	}
@@ -225,7 +225,7 @@ func dataSourceCceNodesRead(_ context.Context, d *schema.ResourceData, meta inte
 		serverId := v.Status.ServerID
 
 		if resourceTags, err := tags.Get(computeClient, "cloudservers", serverId).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 			node["tags"] = tagmap
 		} else {
 			logp.Printf("[WARN] Error fetching tags of CCE Node (%s): %s", serverId, err)
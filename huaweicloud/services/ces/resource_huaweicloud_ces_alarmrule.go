@@ -214,7 +214,7 @@ func ResourceAlarmRule() *schema.Resource {
 			"insufficientdata_actions": {
 				Type:       schema.TypeList,
 				Optional:   true,
-				Deprecated: "insufficientdata_actions is deprecated",
+				Deprecated: "insufficientdata_actions is deprecated and has no replacement",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
@@ -278,7 +278,8 @@ func buildClusterCreateParameters(d *schema.ResourceData, config *config.Config)
 				VolumeType: d.Get("node_config.0.volume.0.volume_type").(string),
 			},
 		},
-		Tags:                utils.ExpandResourceTags(d.Get("tags").(map[string]interface{})),
+		Tags: utils.ExpandResourceTags(utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}),
+			config.TagsConfig)),
 		EnterpriseProjectId: config.GetEnterpriseProjectID(d),
 	}
 
@@ -331,7 +332,7 @@ func resourceCssClusterRead(ctx context.Context, d *schema.ResourceData, meta in
 		return fmtp.DiagErrorf("Query cluster detail failed,cluster_id=%s,err=%s", d.Id(), createErr)
 	}
 
-	if err := setCssClusterProperties(d, cssV1Client, clusterDetail); err != nil {
+	if err := setCssClusterProperties(d, cssV1Client, clusterDetail, config.TagsConfig); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -339,7 +340,7 @@ func resourceCssClusterRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func setCssClusterProperties(d *schema.ResourceData, client *golangsdk.ServiceClient,
-	clusterDetail *cluster.ClusterDetailResponse) error {
+	clusterDetail *cluster.ClusterDetailResponse, tagsConfig *utils.TagsConfig) error {
 	mErr := multierror.Append(
 		d.Set("created", clusterDetail.Created),
 		d.Set("endpoint", clusterDetail.Endpoint),
@@ -352,7 +353,7 @@ func setCssClusterProperties(d *schema.ResourceData, client *golangsdk.ServiceCl
 		setClusterNodes(d, clusterDetail.Instances),
 		setClusterSecurity(d, clusterDetail),
 		setClusterBackupStrategy(d, client, clusterDetail),
-		utils.SetResourceTagsToState(d, client, "css-cluster"),
+		utils.SetResourceTagsToState(d, client, "css-cluster", tagsConfig),
 	)
 
 	if err := mErr.ErrorOrNil(); err != nil {
@@ -509,7 +510,7 @@ func resourceCssClusterUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(cssV1Client, d, "css-cluster", clusterId)
+		tagErr := utils.UpdateResourceTags(cssV1Client, d, "css-cluster", clusterId, config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of CSS cluster:%s, err:%s", d.Id(), tagErr)
 		}
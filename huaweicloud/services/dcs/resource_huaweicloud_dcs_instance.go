@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/plancheck"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
@@ -49,6 +50,8 @@ func ResourceDcsInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+
+		CustomizeDiff: plancheck.CustomizeDiff(validateDcsInstancePlan),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -229,7 +232,6 @@ func ResourceDcsInstance() *schema.Resource {
 			"enterprise_project_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"charging_mode": common.SchemeChargingMode(nil),
@@ -292,27 +294,27 @@ func ResourceDcsInstance() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "Deprecated, this is a non-public attribute.",
+				Deprecated: "Deprecated, please use `enterprise_project_id` instead.",
 			},
 			"internal_version": {
 				Type:       schema.TypeString,
 				Computed:   true,
-				Deprecated: "Deprecated, please us `engine_version` instead.",
+				Deprecated: "Deprecated, please use `engine_version` instead.",
 			},
 			"ip": {
 				Type:       schema.TypeString,
 				Computed:   true,
-				Deprecated: "Deprecated, please us `private_ip` instead.",
+				Deprecated: "Deprecated, please use `private_ip` instead.",
 			},
 			"user_id": {
 				Type:       schema.TypeString,
 				Computed:   true,
-				Deprecated: "Deprecated",
+				Deprecated: "Deprecated, it has no replacement.",
 			},
 			"user_name": {
 				Type:       schema.TypeString,
 				Computed:   true,
-				Deprecated: "Deprecated",
+				Deprecated: "Deprecated, it has no replacement.",
 			},
 			"save_days": {
 				Type:       schema.TypeInt,
@@ -720,7 +722,7 @@ func resourceDcsInstancesRead(ctx context.Context, d *schema.ResourceData, meta
 
 	// set tags
 	if resourceTags, err := tags.Get(client, "instances", d.Id()).Extract(); err == nil {
-		tagMap := utils.TagsToMap(resourceTags.Tags)
+		tagMap := utils.FlattenResourceTags(d, resourceTags.Tags, conf.TagsConfig)
 		if err := d.Set("tags", tagMap); err != nil {
 			return fmtp.DiagErrorf("[DEBUG] Error saving tag to state for DCS instance (%s): %s", d.Id(), err)
 		}
@@ -821,6 +823,18 @@ func resourceDcsInstancesUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 	}
 
+	// update enterprise project
+	if d.HasChange("enterprise_project_id") {
+		migrateOpts := common.MigrateResourceOpts{
+			ResourceId:   d.Id(),
+			ResourceType: "dcs",
+			ProjectId:    client.ProjectID,
+		}
+		if err := common.MigrateEnterpriseProject(ctx, d, config, migrateOpts); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDcsInstancesRead(ctx, d, meta)
 }
 
@@ -984,3 +998,22 @@ func getAvailableZoneCodeByID(client *golangsdk.ServiceClient, azIds []interface
 
 	return azCodes, nil
 }
+
+// validateDcsInstancePlan validates the flavor and availability zones during the plan,
+// it's only called when enable_plan_validation is true.
+func validateDcsInstancePlan(_ context.Context, d *schema.ResourceDiff, conf *config.Config) error {
+	if !plancheck.ShouldValidate(d, "flavor", "capacity", "availability_zones") || !d.NewValueKnown("capacity") {
+		return nil
+	}
+
+	region := plancheck.GetRegion(d, conf)
+	engine := plancheck.GetKnownString(d, "engine")
+	flavor := plancheck.GetKnownString(d, "flavor")
+	azs := plancheck.GetKnownStringList(d, "availability_zones")
+
+	err := plancheck.CheckDcsFlavor(conf, region, engine, d.Get("capacity").(float64), flavor, azs)
+	if err != nil {
+		return fmtp.Errorf("Error validating flavor: %s", err)
+	}
+	return nil
+}
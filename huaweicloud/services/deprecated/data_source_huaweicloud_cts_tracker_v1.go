@@ -11,7 +11,7 @@ import (
 func DataSourceCTSTrackerV1() *schema.Resource {
 	return &schema.Resource{
 		Read:               dataSourceCTSTrackerV1Read,
-		DeprecationMessage: "CTS tracker has been deprecated.",
+		DeprecationMessage: "CTS tracker has been deprecated and has no replacement.",
 
 		Schema: map[string]*schema.Schema{
 			"region": {
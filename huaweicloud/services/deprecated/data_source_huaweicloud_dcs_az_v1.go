@@ -14,7 +14,7 @@ func DataSourceDcsAZV1() *schema.Resource {
 		Read: dataSourceDcsAZV1Read,
 		DeprecationMessage: "this is deprecated. " +
 			"This data source is used for the \"available_zones\" of the \"huaweicloud_dcs_instance\" resource. " +
-			"Now `available_zones` has been deprecated and this data source is no longer used.",
+			"Now `available_zones` has been deprecated, please use huaweicloud_availability_zones data source instead.",
 
 		Schema: map[string]*schema.Schema{
 			"region": {
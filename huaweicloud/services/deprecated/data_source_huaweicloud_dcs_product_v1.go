@@ -12,9 +12,9 @@ import (
 func DataSourceDcsProductV1() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceDcsProductV1Read,
-		DeprecationMessage: "this is deprecated." +
+		DeprecationMessage: "this is deprecated. " +
 			"This data source is used for the \"product_id\" of the \"huaweicloud_dcs_instance\" resource. " +
-			"Now \"product_id\" has been deprecated and this data source is no longer used.",
+			"Now \"product_id\" has been deprecated, please use huaweicloud_dcs_flavors data source instead.",
 
 		Schema: map[string]*schema.Schema{
 			"region": {
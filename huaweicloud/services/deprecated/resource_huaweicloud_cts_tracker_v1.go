@@ -18,7 +18,7 @@ func ResourceCTSTrackerV1() *schema.Resource {
 		Read:               resourceCTSTrackerRead,
 		Update:             resourceCTSTrackerUpdate,
 		Delete:             resourceCTSTrackerDelete,
-		DeprecationMessage: "CTS tracker has been deprecated.",
+		DeprecationMessage: "CTS tracker has been deprecated and has no replacement.",
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -21,7 +21,7 @@ func ResourceDmsGroups() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		DeprecationMessage: "Deprecated, Distributed Message Service (Shared Edition) has withdrawn, " +
-			"please use DMS for Kafka instead.",
+			"please use huaweicloud_dms_kafka_instance and huaweicloud_dms_kafka_topic instead.",
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -228,7 +228,7 @@ func resourceDmsInstancesV1Create(d *schema.ResourceData, meta interface{}) erro
 	d.SetId(v.InstanceID)
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		dmsV2Client, err := config.DmsV2Client(config.GetRegion(d))
 		if err != nil {
@@ -292,7 +292,7 @@ func resourceDmsInstancesV1Read(d *schema.ResourceData, meta interface{}) error
 
 	engine := d.Get("engine").(string)
 	if resourceTags, err := tags.Get(dmsV2Client, engine, d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmtp.Errorf("Error saving tags to state for dms instance (%s): %s", d.Id(), err)
 		}
@@ -347,7 +347,7 @@ func resourceDmsInstancesV1Update(d *schema.ResourceData, meta interface{}) erro
 		}
 		// update tags
 		engine := d.Get("engine").(string)
-		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id())
+		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of dms instance:%s, err:%s", d.Id(), tagErr)
 		}
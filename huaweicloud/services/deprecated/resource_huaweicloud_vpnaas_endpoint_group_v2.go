@@ -19,7 +19,7 @@ func ResourceVpnEndpointGroupV2() *schema.Resource {
 		Read:               resourceVpnEndpointGroupV2Read,
 		Update:             resourceVpnEndpointGroupV2Update,
 		Delete:             resourceVpnEndpointGroupV2Delete,
-		DeprecationMessage: "VPN has been deprecated.",
+		DeprecationMessage: "VPN has been deprecated and has no replacement.",
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -50,7 +50,7 @@ func ResourceVpnEndpointGroupV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"type": {
 				Type:     schema.TypeString,
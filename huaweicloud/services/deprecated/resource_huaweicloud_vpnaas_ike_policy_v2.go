@@ -19,7 +19,7 @@ func ResourceVpnIKEPolicyV2() *schema.Resource {
 		Read:               resourceVpnIKEPolicyV2Read,
 		Update:             resourceVpnIKEPolicyV2Update,
 		Delete:             resourceVpnIKEPolicyV2Delete,
-		DeprecationMessage: "VPN has been deprecated.",
+		DeprecationMessage: "VPN has been deprecated and has no replacement.",
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -92,7 +92,7 @@ func ResourceVpnIKEPolicyV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"value_specs": {
 				Type:     schema.TypeMap,
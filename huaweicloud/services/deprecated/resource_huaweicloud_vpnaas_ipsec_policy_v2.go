@@ -19,7 +19,7 @@ func ResourceVpnIPSecPolicyV2() *schema.Resource {
 		Read:               resourceVpnIPSecPolicyV2Read,
 		Update:             resourceVpnIPSecPolicyV2Update,
 		Delete:             resourceVpnIPSecPolicyV2Delete,
-		DeprecationMessage: "VPN has been deprecated.",
+		DeprecationMessage: "VPN has been deprecated and has no replacement.",
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -74,7 +74,7 @@ func ResourceVpnIPSecPolicyV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"lifetime": {
 				Type:     schema.TypeSet,
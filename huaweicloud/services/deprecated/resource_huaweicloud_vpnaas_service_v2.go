@@ -19,7 +19,7 @@ func ResourceVpnServiceV2() *schema.Resource {
 		Read:               resourceVpnServiceV2Read,
 		Update:             resourceVpnServiceV2Update,
 		Delete:             resourceVpnServiceV2Delete,
-		DeprecationMessage: "VPN has been deprecated.",
+		DeprecationMessage: "VPN has been deprecated and has no replacement.",
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -55,7 +55,7 @@ func ResourceVpnServiceV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"subnet_id": {
 				Type:     schema.TypeString,
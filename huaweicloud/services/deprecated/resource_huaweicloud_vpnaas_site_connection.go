@@ -21,7 +21,7 @@ func ResourceVpnSiteConnectionV2() *schema.Resource {
 		Read:               resourceVpnSiteConnectionV2Read,
 		Update:             resourceVpnSiteConnectionV2Update,
 		Delete:             resourceVpnSiteConnectionV2Delete,
-		DeprecationMessage: "VPN has been deprecated.",
+		DeprecationMessage: "VPN has been deprecated and has no replacement.",
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -106,7 +106,7 @@ func ResourceVpnSiteConnectionV2() *schema.Resource {
 				Optional:   true,
 				ForceNew:   true,
 				Computed:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 			"peer_cidrs": {
 				Type:     schema.TypeList,
@@ -216,7 +216,7 @@ func resourceVpnSiteConnectionV2Create(d *schema.ResourceData, meta interface{})
 	d.SetId(conn.ID)
 
 	// create tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(networkingClient, "ipsec-site-connections", d.Id(), taglist).ExtractErr(); tagErr != nil {
@@ -280,7 +280,7 @@ func resourceVpnSiteConnectionV2Read(d *schema.ResourceData, meta interface{}) e
 		return fmtp.Errorf("Error fetching VPN site connection tags: %s", err)
 	}
 
-	tagmap := utils.TagsToMap(resourceTags.Tags)
+	tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 	if err := d.Set("tags", tagmap); err != nil {
 		return fmtp.Errorf("Error saving tags for VPN site connection %s: %s", d.Id(), err)
 	}
@@ -398,7 +398,7 @@ func resourceVpnSiteConnectionV2Update(d *schema.ResourceData, meta interface{})
 	}
 
 	// update tags
-	tagErr := utils.UpdateResourceTags(networkingClient, d, "ipsec-site-connections", d.Id())
+	tagErr := utils.UpdateResourceTags(networkingClient, d, "ipsec-site-connections", d.Id(), config.TagsConfig)
 	if tagErr != nil {
 		return fmtp.Errorf("Error updating tags of VPN site connection %s: %s", d.Id(), tagErr)
 	}
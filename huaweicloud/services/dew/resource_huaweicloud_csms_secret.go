@@ -114,8 +114,7 @@ func resourceCsmsSecretCreate(ctx context.Context, d *schema.ResourceData, meta
 	d.SetId(id)
 
 	// Save tags
-	if t, ok := d.GetOk("tags"); ok {
-		tMaps := t.(map[string]interface{})
+	if tMaps := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig); len(tMaps) > 0 {
 		tagMaps := utils.ExpandResourceTags(tMaps)
 		err = tags.Create(client, serviceType, rst.ID, tagMaps).ExtractErr()
 		if err != nil {
@@ -171,7 +170,7 @@ func resourceCsmsSecretRead(_ context.Context, d *schema.ResourceData, meta inte
 
 	// Query secret tags
 	if resourceTags, err := tags.Get(client, serviceType, id).Extract(); err == nil {
-		tagMap := utils.TagsToMap(resourceTags.Tags)
+		tagMap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		mErr = multierror.Append(
 			mErr,
 			d.Set("tags", tagMap),
@@ -263,7 +262,7 @@ func resourceCsmsSecretUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	// Update tags
 	if d.HasChange("tags") {
-		err = utils.UpdateResourceTags(client, d, serviceType, id)
+		err = utils.UpdateResourceTags(client, d, serviceType, id, config.TagsConfig)
 		if err != nil {
 			e := fmtp.Errorf("failed to update CSMS secret tags: %s", err)
 			mErr = multierror.Append(mErr, e)
@@ -189,7 +189,8 @@ func resourceDisStreamCreate(ctx context.Context, d *schema.ResourceData, meta i
 		DataType:          d.Get("data_type").(string),
 		DataSchema:        d.Get("data_schema").(string),
 		CompressionFormat: d.Get("compression_format").(string),
-		Tags:              utils.ExpandResourceTags(d.Get("tags").(map[string]interface{})),
+		Tags: utils.ExpandResourceTags(utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}),
+			config.TagsConfig)),
 	}
 
 	if v, ok := d.GetOk("csv_delimiter"); ok {
@@ -251,7 +252,7 @@ func resourceDisStreamRead(ctx context.Context, d *schema.ResourceData, meta int
 		d.Set("data_type", detail.DataType),
 		d.Set("retention_period", detail.RetentionPeriod),
 		d.Set("stream_type", detail.StreamType),
-		d.Set("tags", utils.TagsToMap(detail.Tags)),
+		d.Set("tags", utils.FlattenResourceTags(d, detail.Tags, config.TagsConfig)),
 		d.Set("created", detail.CreateTime),
 		d.Set("readable_partition_count", detail.ReadablePartitionCount),
 		d.Set("writable_partition_count", detail.WritablePartitionCount),
@@ -320,7 +321,7 @@ func resourceDisStreamUpdate(ctx context.Context, d *schema.ResourceData, meta i
 
 	if d.HasChange("tags") {
 		streamId := d.Get("stream_id").(string)
-		tagErr := utils.UpdateResourceTags(client, d, "stream", streamId)
+		tagErr := utils.UpdateResourceTags(client, d, "stream", streamId, config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of DIS stream:%s,streamId=%s, err:%s", name, streamId, tagErr)
 		}
@@ -226,7 +226,8 @@ func resourceFlinkJarJobCreate(ctx context.Context, d *schema.ResourceData, meta
 		ResumeCheckpoint:     utils.Bool(d.Get("resume_checkpoint").(bool)),
 		ResumeMaxNum:         golangsdk.IntToPointer(d.Get("resume_max_num").(int)),
 		CheckpointPath:       d.Get("checkpoint_path").(string),
-		Tags:                 utils.ExpandResourceTags(d.Get("tags").(map[string]interface{})),
+		Tags: utils.ExpandResourceTags(utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}),
+			config.TagsConfig)),
 	}
 
 	if runtimConfig, ok := d.GetOk("runtime_config"); ok {
@@ -325,7 +326,7 @@ func resourceFlinkJarJobRead(_ context.Context, d *schema.ResourceData, meta int
 		d.Set("resume_checkpoint", detail.JobConfig.ResumeCheckpoint),
 		d.Set("resume_max_num", detail.JobConfig.ResumeMaxNum),
 		d.Set("checkpoint_path", detail.JobConfig.CheckpointPath),
-		d.Set("runtime_config", utils.TagsToMap(parseConfig(detail.JobConfig.RuntimeConfig))),
+		d.Set("runtime_config", parseConfig(detail.JobConfig.RuntimeConfig)),
 		d.Set("status", detail.Status),
 	)
 	if setSdErr := mErr.ErrorOrNil(); setSdErr != nil {
@@ -237,7 +237,8 @@ func resourceFlinkSqlJobCreate(ctx context.Context, d *schema.ResourceData, meta
 		TmSlotNum:            golangsdk.IntToPointer(d.Get("tm_slot_num").(int)),
 		ResumeCheckpoint:     utils.Bool(d.Get("resume_checkpoint").(bool)),
 		ResumeMaxNum:         golangsdk.IntToPointer(d.Get("resume_max_num").(int)),
-		Tags:                 utils.ExpandResourceTags(d.Get("tags").(map[string]interface{})),
+		Tags: utils.ExpandResourceTags(utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}),
+			config.TagsConfig)),
 	}
 
 	if mode := d.Get("checkpoint_mode").(string); mode == flinkjob.CheckpointModeAtLeastOnce {
@@ -335,7 +336,7 @@ func resourceFlinkSqlJobRead(ctx context.Context, d *schema.ResourceData, meta i
 		d.Set("tm_slot_num", detail.JobConfig.TmSlotNum),
 		d.Set("resume_checkpoint", detail.JobConfig.ResumeCheckpoint),
 		d.Set("resume_max_num", detail.JobConfig.ResumeMaxNum),
-		d.Set("runtime_config", utils.TagsToMap(parseConfig(detail.JobConfig.RuntimeConfig))),
+		d.Set("runtime_config", parseConfig(detail.JobConfig.RuntimeConfig)),
 		d.Set("status", detail.Status),
 	)
 	if setSdErr := mErr.ErrorOrNil(); setSdErr != nil {
@@ -591,8 +592,13 @@ func updateFlinkSqlJobWithStop(ctx context.Context, client *golangsdk.ServiceCli
 	return nil
 }
 
-func parseConfig(configStr string) []tags.ResourceTag {
-	var rst []tags.ResourceTag
-	json.Unmarshal([]byte(configStr), &rst)
+func parseConfig(configStr string) map[string]string {
+	var configs []tags.ResourceTag
+	json.Unmarshal([]byte(configStr), &configs)
+
+	rst := make(map[string]string)
+	for _, v := range configs {
+		rst[v.Key] = v.Value
+	}
 	return rst
 }
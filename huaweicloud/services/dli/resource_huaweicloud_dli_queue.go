@@ -131,14 +131,14 @@ func ResourceDliQueue() *schema.Resource {
 				Type:       schema.TypeString,
 				Optional:   true,
 				ForceNew:   true,
-				Deprecated: "management_subnet_cidr is Deprecated",
+				Deprecated: "management_subnet_cidr is deprecated and has no replacement",
 			},
 
 			"subnet_cidr": {
 				Type:       schema.TypeString,
 				Optional:   true,
 				ForceNew:   true,
-				Deprecated: "subnet_cidr is Deprecated",
+				Deprecated: "subnet_cidr is deprecated and has no replacement",
 			},
 		},
 
@@ -160,7 +160,8 @@ func resourceSqlJobCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		Sql:       d.Get("sql").(string),
 		Currentdb: d.Get("database_name").(string),
 		QueueName: d.Get("queue_name").(string),
-		Tags:      utils.ExpandResourceTags(d.Get("tags").(map[string]interface{})),
+		Tags: utils.ExpandResourceTags(utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}),
+			config.TagsConfig)),
 	}
 
 	if _, ok := d.GetOk("conf"); ok {
@@ -218,7 +219,7 @@ func resourceSqlJobRead(_ context.Context, d *schema.ResourceData, meta interfac
 		d.Set("start_time", utils.FormatTimeStampRFC3339(int64(dt.StartTime))),
 		d.Set("duration", dt.Duration),
 		d.Set("status", dt.Status),
-		d.Set("tags", utils.TagsToMap(dt.Tags)),
+		d.Set("tags", utils.FlattenResourceTags(d, dt.Tags, config.TagsConfig)),
 	)
 	if setSdErr := mErr.ErrorOrNil(); setSdErr != nil {
 		return fmtp.DiagErrorf("Error setting vault fields: %s", setSdErr)
@@ -100,7 +100,7 @@ func DataSourceDmsProduct() *schema.Resource {
 				Type:       schema.TypeString,
 				Optional:   true,
 				Computed:   true,
-				Deprecated: "io_type has deprecated, please use storage_spec_code",
+				Deprecated: "io_type has deprecated, please use storage_spec_code instead",
 			},
 		},
 	}
@@ -233,7 +233,7 @@ func ResourceDmsKafkaInstance() *schema.Resource {
 				Computed: true,
 				ForceNew: true,
 				Deprecated: "The bandwidth has been deprecated. " +
-					"If you need to change the bandwidth, please update the product_id.",
+					"If you need to change the bandwidth, please update the product_id instead.",
 			},
 		},
 	}
@@ -369,7 +369,7 @@ func resourceDmsKafkaInstanceCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		createOpts.Tags = taglist
@@ -480,7 +480,7 @@ func resourceDmsKafkaInstanceRead(_ context.Context, d *schema.ResourceData, met
 	// set tags
 	engine := "kafka"
 	if resourceTags, err := tags.Get(dmsV2Client, engine, d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		if err := d.Set("tags", tagmap); err != nil {
 			e := fmtp.Errorf("error saving tags to state for DMS kafka instance (%s): %s", d.Id(), err)
 			mErr = multierror.Append(mErr, e)
@@ -538,7 +538,7 @@ func resourceDmsKafkaInstanceUpdate(ctx context.Context, d *schema.ResourceData,
 	if d.HasChange("tags") {
 		// update tags
 		engine := "kafka"
-		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id())
+		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			e := fmtp.Errorf("error updating tags of DMS kafka instance:%s, err:%s", d.Id(), tagErr)
 			mErr = multierror.Append(mErr, e)
@@ -252,7 +252,7 @@ func resourceDmsRabbitmqInstanceCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		createOpts.Tags = taglist
@@ -349,7 +349,7 @@ func resourceDmsRabbitmqInstanceRead(_ context.Context, d *schema.ResourceData,
 	// set tags
 	engine := "rabbitmq"
 	if resourceTags, err := tags.Get(dmsV2Client, engine, d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		err = d.Set("tags", tagmap)
 		if err != nil {
 			mErr = multierror.Append(mErr, err)
@@ -411,7 +411,7 @@ func resourceDmsRabbitmqInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 	if d.HasChange("tags") {
 		// update tags
 		engine := "rabbitmq"
-		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id())
+		tagErr := utils.UpdateResourceTags(dmsV2Client, d, engine, d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			e := fmtp.Errorf("error updating tags of DMS rabbitmq instance:%s, err:%s", d.Id(), tagErr)
 			mErr = multierror.Append(mErr, e)
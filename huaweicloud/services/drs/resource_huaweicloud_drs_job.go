@@ -315,7 +315,7 @@ func resourceDrsJobCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.Errorf("Error creating DRS v3 client, error=%s", err)
 	}
 
-	opts, err := buildCreateParamter(d, client.ProjectID, config.GetEnterpriseProjectID(d))
+	opts, err := buildCreateParamter(d, client.ProjectID, config.GetEnterpriseProjectID(d), config.TagsConfig)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -589,7 +589,8 @@ func waitingforJobStatus(ctx context.Context, client *golangsdk.ServiceClient, i
 	return nil
 }
 
-func buildCreateParamter(d *schema.ResourceData, projectId, enterpriseProjectID string) (*jobs.BatchCreateJobReq, error) {
+func buildCreateParamter(d *schema.ResourceData, projectId, enterpriseProjectID string,
+	tagsConfig *utils.TagsConfig) (*jobs.BatchCreateJobReq, error) {
 	jobDirection := d.Get("direction").(string)
 
 	sourceDb, err := buildDbConfigParamter(d, "source_db", projectId)
@@ -637,8 +638,9 @@ func buildCreateParamter(d *schema.ResourceData, projectId, enterpriseProjectID
 		SourceEndpoint:   *sourceDb,
 		TargetEndpoint:   *targetDb,
 		SubnetId:         subnetId,
-		Tags:             utils.ExpandResourceTags(d.Get("tags").(map[string]interface{})),
-		SysTags:          utils.BuildSysTags(enterpriseProjectID),
+		Tags: utils.ExpandResourceTags(utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}),
+			tagsConfig)),
+		SysTags: utils.BuildSysTags(enterpriseProjectID),
 	}
 
 	return &jobs.BatchCreateJobReq{Jobs: []jobs.CreateJobReq{job}}, nil
@@ -239,7 +239,8 @@ func resourceDwsClusterCreate(ctx context.Context, d *schema.ResourceData, meta
 		SecurityGroupID:     d.Get("security_group_id").(string),
 		VpcID:               d.Get("vpc_id").(string),
 		EnterpriseProjectId: config.GetEnterpriseProjectID(d),
-		Tags:                utils.ExpandResourceTags(d.Get("tags").(map[string]interface{})),
+		Tags: utils.ExpandResourceTags(utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}),
+			config.TagsConfig)),
 	}
 
 	if obj, ok := d.GetOk("number_of_cn"); ok {
@@ -295,7 +296,7 @@ func resourceDwsClusterRead(ctx context.Context, d *schema.ResourceData, meta in
 		d.Set("port", clusterDetail.Port),
 		setPublicIpToState(d, clusterDetail.PublicIp),
 		d.Set("enterprise_project_id", clusterDetail.EnterpriseProjectId),
-		d.Set("tags", utils.TagsToMap(clusterDetail.Tags)),
+		d.Set("tags", utils.FlattenResourceTags(d, clusterDetail.Tags, config.TagsConfig)),
 		d.Set("created", clusterDetail.Created),
 		setEndpointsToState(d, clusterDetail.Endpoints),
 		setPublicEndpointsToState(d, clusterDetail.PublicEndpoints),
@@ -169,7 +169,7 @@ func dataSourceVpcEipsRead(_ context.Context, d *schema.ResourceData, meta inter
 		var tagRst map[string]string
 
 		if resourceTags, err := tags.Get(clientV2, "publicips", item.ID).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 
 			if !utils.HasMapContains(tagmap, tagFilter) {
 				continue
@@ -1,6 +1,7 @@
 package eip
 
 import (
+	"context"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -123,7 +124,6 @@ func ResourceVpcEIPV1() *schema.Resource {
 			"enterprise_project_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"address": {
@@ -148,10 +148,11 @@ func ResourceVpcEIPV1() *schema.Resource {
 			},
 
 			// charge info: charging_mode, period_unit, period, auto_renew
-			"charging_mode": common.SchemeChargingMode(nil),
-			"period_unit":   common.SchemaPeriodUnit([]string{"publicip.0.ip_address"}),
-			"period":        common.SchemaPeriod([]string{"publicip.0.ip_address"}),
-			"auto_renew":    common.SchemaAutoRenew([]string{"publicip.0.ip_address"}),
+			"charging_mode": common.SchemaChargingModeUpdatable(nil),
+			"period_unit":   common.SchemaPeriodUnitUpdatable([]string{"publicip.0.ip_address"}),
+			"period":        common.SchemaPeriodUpdatable([]string{"publicip.0.ip_address"}),
+			"auto_renew":    common.SchemaAutoRenewUpdatable([]string{"publicip.0.ip_address"}),
+			"expire_time":   common.SchemaExpireTime(),
 		},
 	}
 }
@@ -281,7 +282,7 @@ func resourceVpcEIPV1Create(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// create tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(networkingV2Client, "publicips", eIP.ID, taglist).ExtractErr(); tagErr != nil {
@@ -341,6 +342,7 @@ func resourceVpcEIPV1Read(d *schema.ResourceData, meta interface{}) error {
 		d.Set("status", NormalizeEIPStatus(eIP.Status)),
 		d.Set("publicip", publicIP),
 		d.Set("bandwidth", bW),
+		common.SetPrePaidExpireTime(d, config, d.Id()),
 	)
 
 	if mErr.ErrorOrNil() != nil {
@@ -350,7 +352,7 @@ func resourceVpcEIPV1Read(d *schema.ResourceData, meta interface{}) error {
 	// save tags
 	if vpcV2Client, err := config.NetworkingV2Client(region); err == nil {
 		if resourceTags, err := tags.Get(vpcV2Client, "publicips", d.Id()).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 			if err := d.Set("tags", tagmap); err != nil {
 				return fmtp.Errorf("Error saving tags for EIP (%s): %s", d.Id(), err)
 			}
@@ -441,15 +443,61 @@ func resourceVpcEIPV1Update(d *schema.ResourceData, meta interface{}) error {
 			return fmtp.Errorf("Error creating Huaweicloud vpc client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(vpcV2Client, d, "publicips", d.Id())
+		tagErr := utils.UpdateResourceTags(vpcV2Client, d, "publicips", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of VPC %s: %s", d.Id(), tagErr)
 		}
 	}
 
+	if d.HasChanges("charging_mode", "period_unit", "period", "auto_renew") {
+		vpcV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
+		if err != nil {
+			return fmtp.Errorf("Error creating Huaweicloud vpc client: %s", err)
+		}
+
+		changeToPrePaid := func(opts common.ChangeToPrePaidOpts) (string, error) {
+			return changeEIPToPrePaid(vpcV2Client, d.Id(), opts)
+		}
+		if err := common.UpdateChargeInfo(context.TODO(), d, config, d.Id(), changeToPrePaid); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		migrateOpts := common.MigrateResourceOpts{
+			ResourceId:   d.Id(),
+			ResourceType: "eip",
+			ProjectId:    networkingClient.ProjectID,
+		}
+		if err := common.MigrateEnterpriseProject(context.TODO(), d, config, migrateOpts); err != nil {
+			return err
+		}
+	}
+
 	return resourceVpcEIPV1Read(d, meta)
 }
 
+// changeEIPToPrePaid converts the postPaid EIP and its dedicated bandwidth to prePaid, and returns the order ID.
+func changeEIPToPrePaid(client *golangsdk.ServiceClient, id string, opts common.ChangeToPrePaidOpts) (string, error) {
+	changeOpts := map[string]interface{}{
+		"publicip_ids": []string{id},
+		"extendParam": map[string]interface{}{
+			"period_type":   opts.PeriodUnit,
+			"period_num":    opts.Period,
+			"is_auto_renew": opts.AutoRenew,
+			"is_auto_pay":   true,
+		},
+	}
+	logp.Printf("[DEBUG] Change EIP (%s) to prePaid options: %#v", id, changeOpts)
+
+	var r struct {
+		OrderID string `json:"order_id"`
+	}
+	_, err := client.Post(client.ServiceURL(client.ProjectID, "publicips", "change-to-period"), changeOpts, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200}})
+	return r.OrderID, err
+}
+
 func resourceVpcEIPV1Delete(d *schema.ResourceData, meta interface{}) error {
 	config := meta.(*config.Config)
 	networkingClient, err := config.NetworkingV1Client(config.GetRegion(d))
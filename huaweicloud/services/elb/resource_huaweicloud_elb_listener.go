@@ -27,6 +27,10 @@ func ResourceListenerV3() *schema.Resource {
 		UpdateContext: resourceListenerV3Update,
 		DeleteContext: resourceListenerV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -233,7 +237,7 @@ func resourceListenerV3Create(ctx context.Context, d *schema.ResourceData, meta
 	d.SetId(listener.ID)
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		elbV2Client, err := config.ElbV2Client(config.GetRegion(d))
 		if err != nil {
@@ -283,6 +287,9 @@ func resourceListenerV3Read(_ context.Context, d *schema.ResourceData, meta inte
 	d.Set("request_timeout", listener.ClientTimeout)
 	d.Set("response_timeout", listener.MemberTimeout)
 	d.Set("region", config.GetRegion(d))
+	if len(listener.Loadbalancers) > 0 {
+		d.Set("loadbalancer_id", listener.Loadbalancers[0].ID)
+	}
 
 	if listener.IpGroup != (listeners.IpGroup{}) {
 		d.Set("access_policy", listener.IpGroup.Type)
@@ -294,7 +301,7 @@ func resourceListenerV3Read(_ context.Context, d *schema.ResourceData, meta inte
 
 	// fetch tags
 	if resourceTags, err := tags.Get(elbV2Client, "listeners", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		d.Set("tags", tagmap)
 	} else {
 		logp.Printf("[WARN] fetching tags of elb listener failed: %s", err)
@@ -406,7 +413,7 @@ func resourceListenerV3Update(ctx context.Context, d *schema.ResourceData, meta
 		if err != nil {
 			return fmtp.DiagErrorf("Error creating HuaweiCloud elb 2.0 client: %s", err)
 		}
-		tagErr := utils.UpdateResourceTags(elbV2Client, d, "listeners", d.Id())
+		tagErr := utils.UpdateResourceTags(elbV2Client, d, "listeners", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of elb listener:%s, err:%s", d.Id(), tagErr)
 		}
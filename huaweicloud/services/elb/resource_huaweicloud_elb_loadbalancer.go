@@ -29,6 +29,10 @@ func ResourceLoadBalancerV3() *schema.Resource {
 		UpdateContext: resourceLoadBalancerV3Update,
 		DeleteContext: resourceLoadBalancerV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -297,7 +301,7 @@ func resourceLoadBalancerV3Create(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(loadBalancerID)
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		elbV2Client, err := config.ElbV2Client(config.GetRegion(d))
 		if err != nil {
@@ -358,7 +362,7 @@ func resourceLoadBalancerV3Read(_ context.Context, d *schema.ResourceData, meta
 
 	// fetch tags
 	if resourceTags, err := tags.Get(elbV2Client, "loadbalancers", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		d.Set("tags", tagmap)
 	} else {
 		logp.Printf("[WARN] fetching tags of elb loadbalancer failed: %s", err)
@@ -445,7 +449,7 @@ func resourceLoadBalancerV3Update(ctx context.Context, d *schema.ResourceData, m
 		if err != nil {
 			return fmtp.DiagErrorf("Error creating HuaweiCloud elb 2.0 client: %s", err)
 		}
-		tagErr := utils.UpdateResourceTags(elbV2Client, d, "loadbalancers", d.Id())
+		tagErr := utils.UpdateResourceTags(elbV2Client, d, "loadbalancers", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of load balancer:%s, err:%s", d.Id(), tagErr)
 		}
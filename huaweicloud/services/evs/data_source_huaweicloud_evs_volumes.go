@@ -7,6 +7,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
@@ -48,6 +49,10 @@ func DataSourceEvsVolumesV2() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"filter": common.FilterSchema([]string{
+				"id", "name", "availability_zone", "bootable", "description", "enterprise_project_id",
+				"service_type", "shareable", "size", "status", "wwn", "tags",
+			}),
 			"volumes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -232,6 +237,13 @@ func dataSourceEvsVolumesV2Read(_ context.Context, d *schema.ResourceData, meta
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	if rules := common.ExpandFilterRules(d, nil); len(rules) > 0 {
+		vMap, ids, err = filterVolumeListByRules(vMap, rules)
+		if err != nil {
+			return fmtp.DiagErrorf("filter EVS volumes failed: %s", err)
+		}
+	}
 	d.SetId(hashcode.Strings(ids))
 	if err = d.Set("volumes", vMap); err != nil {
 		return fmtp.DiagErrorf("Error saving the detailed information of the EVS disks to state: %s", err)
@@ -248,3 +260,19 @@ func filterVolumeListByTags(volumes []cloudvolumes.Volume, filter map[string]int
 	}
 	return result
 }
+
+func filterVolumeListByRules(volumes []map[string]interface{}, rules []utils.FilterRule) ([]map[string]interface{},
+	[]string, error) {
+	filtered, err := utils.FilterSliceWithRules(volumes, rules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]map[string]interface{}, len(filtered))
+	ids := make([]string, len(filtered))
+	for i, volume := range filtered {
+		result[i] = volume.(map[string]interface{})
+		ids[i] = result[i]["id"].(string)
+	}
+	return result, ids, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/ecs/v1/jobs"
 	"github.com/chnsz/golangsdk/openstack/evs/v2/cloudvolumes"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/waiter"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
@@ -33,12 +34,14 @@ func ResourceEvsVolume() *schema.Resource {
 		DeleteContext: resourceEvsVolumeDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceEvsVolumeImportState,
 		},
 
+		CustomizeDiff: resourceEvsVolumeCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
-			Update: schema.DefaultTimeout(3 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(3 * time.Minute),
 		},
 
@@ -57,11 +60,23 @@ func ResourceEvsVolume() *schema.Resource {
 			"volume_type": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					"GPSSD", "SSD", "ESSD", "SAS",
+					"GPSSD", "SSD", "ESSD", "SAS", "GPSSD2", "ESSD2",
 				}, true),
 			},
+			// iops and throughput are the provisioned performance of GPSSD2 and ESSD2 volumes
+			"iops": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"throughput": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"device_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -109,15 +124,15 @@ func ResourceEvsVolume() *schema.Resource {
 				ForceNew: true,
 				Default:  false,
 			},
-			"charging_mode": common.SchemeChargingMode(nil),
-			"period_unit":   common.SchemaPeriodUnit(nil),
-			"period":        common.SchemaPeriod(nil),
-			"auto_renew":    common.SchemaAutoRenew(nil),
+			"charging_mode": common.SchemaChargingModeUpdatable(nil),
+			"period_unit":   common.SchemaPeriodUnitUpdatable(nil),
+			"period":        common.SchemaPeriodUpdatable(nil),
+			"auto_renew":    common.SchemaAutoRenewUpdatable(nil),
+			"expire_time":   common.SchemaExpireTime(),
 			"tags":          common.TagsSchema(),
 			"enterprise_project_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				Computed: true,
 			},
 			"attachment": {
@@ -185,7 +200,7 @@ func buildEvsVolumeCreateOpts(d *schema.ResourceData, config *config.Config) clo
 		ImageID:             d.Get("image_id").(string),
 		Multiattach:         d.Get("multiattach").(bool),
 		EnterpriseProjectID: common.GetEnterpriseProjectID(d, config),
-		Tags:                resourceContainerTags(d),
+		Tags:                resourceContainerTags(d, config.TagsConfig),
 	}
 	m := map[string]string{
 		"create_for_volume_id": "true",
@@ -208,6 +223,119 @@ func buildEvsVolumeCreateOpts(d *schema.ResourceData, config *config.Config) clo
 	return result
 }
 
+// volumeQoSRanges are the valid ranges of the provisioned IOPS and throughput (MiB/s) of the volume types,
+// the throughput of ESSD2 volumes is not configurable.
+var volumeQoSRanges = map[string]map[string][2]int{
+	"iops": {
+		"GPSSD2": {3000, 128000},
+		"ESSD2":  {100, 256000},
+	},
+	"throughput": {
+		"GPSSD2": {125, 1000},
+	},
+}
+
+// volumeCreateOptsExt adds the provisioned IOPS and throughput, which are not supported by the SDK,
+// to the request body of creating a volume.
+type volumeCreateOptsExt struct {
+	cloudvolumes.CreateOptsBuilder
+	Iops       int
+	Throughput int
+}
+
+func (opts volumeCreateOptsExt) ToVolumeCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOptsBuilder.ToVolumeCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	volume := b["volume"].(map[string]interface{})
+	if opts.Iops > 0 {
+		volume["iops"] = opts.Iops
+	}
+	if opts.Throughput > 0 {
+		volume["throughput"] = opts.Throughput
+	}
+	return b, nil
+}
+
+// volumeQoS is the provisioned IOPS and throughput in the volume detail, which are not parsed by the SDK
+type volumeQoS struct {
+	Iops struct {
+		TotalVal int `json:"total_val"`
+	} `json:"iops"`
+	Throughput struct {
+		TotalVal int `json:"total_val"`
+	} `json:"throughput"`
+}
+
+// volumeRetypeOpts is the request body of changing the volume type
+type volumeRetypeOpts struct {
+	Retype   volumeRetypeTypeOpts  `json:"os-retype"`
+	BssParam *volumeRetypeBssParam `json:"bssParam,omitempty"`
+}
+
+type volumeRetypeTypeOpts struct {
+	NewType    string `json:"new_type"`
+	Iops       int    `json:"iops,omitempty"`
+	Throughput int    `json:"throughput,omitempty"`
+}
+
+type volumeRetypeBssParam struct {
+	IsAutoPay string `json:"isAutoPay"`
+}
+
+// volumeQoSModifyOpts is the request body of changing the provisioned IOPS and throughput of the volume
+type volumeQoSModifyOpts struct {
+	QoSModify volumeQoSOpts `json:"qos_modify"`
+}
+
+type volumeQoSOpts struct {
+	Iops       int `json:"iops,omitempty"`
+	Throughput int `json:"throughput,omitempty"`
+}
+
+type volumeJobResponse struct {
+	JobID   string `json:"job_id"`
+	OrderID string `json:"order_id"`
+}
+
+// resourceEvsVolumeCustomizeDiff validates the provisioned IOPS and throughput against the volume type, they are
+// recomputed if the volume type is changed without specifying them.
+func resourceEvsVolumeCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !d.NewValueKnown("volume_type") {
+		return nil
+	}
+
+	volumeType := strings.ToUpper(d.Get("volume_type").(string))
+	for _, key := range []string{"iops", "throughput"} {
+		v := rawConfig.GetAttr(key)
+		if v.IsNull() {
+			if d.Id() != "" && d.HasChange("volume_type") {
+				if err := d.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !v.IsKnown() {
+			continue
+		}
+
+		valueRange, ok := volumeQoSRanges[key][volumeType]
+		if !ok {
+			return fmtp.Errorf("%s can not be specified when volume_type is %s", key, volumeType)
+		}
+		value, _ := v.AsBigFloat().Int64()
+		if value < int64(valueRange[0]) || value > int64(valueRange[1]) {
+			return fmtp.Errorf("the %s of %s volume must be between %d and %d, but got %d",
+				key, volumeType, valueRange[0], valueRange[1], value)
+		}
+	}
+	return nil
+}
+
 func resourceEvsVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*config.Config)
 	// The v2 client is used to obtain the volume detail.
@@ -221,11 +349,15 @@ func resourceEvsVolumeCreate(ctx context.Context, d *schema.ResourceData, meta i
 		return fmtp.DiagErrorf("Error creating HuaweiCloud block storage v2.1 client: %s", err)
 	}
 
-	opt := buildEvsVolumeCreateOpts(d, config)
+	opt := volumeCreateOptsExt{
+		CreateOptsBuilder: buildEvsVolumeCreateOpts(d, config),
+		Iops:              d.Get("iops").(int),
+		Throughput:        d.Get("throughput").(int),
+	}
 	logp.Printf("[DEBUG] Create Options: %#v", opt)
 	job, err := cloudvolumes.Create(evsV21Client, opt).Extract()
 	if err != nil {
-		return fmtp.DiagErrorf("Error creating HuaweiCloud EVS volume: %s", err)
+		return common.DiagAPIError(err, "Error creating HuaweiCloud EVS volume")
 	}
 	if len(job.VolumeIDs) < 1 {
 		return fmtp.DiagErrorf("The volume ID was not included in the response to the request to create the volume.")
@@ -241,15 +373,17 @@ func resourceEvsVolumeCreate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	logp.Printf("[DEBUG] Waiting for the EVS volume to become available, the volume ID is %s.", d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"creating"},
-		Target:     []string{"available"},
-		Refresh:    cloudVolumeRefreshFunc(evsV2Client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      3 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-	_, err = stateConf.WaitForStateContext(ctx)
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("EVS volume (%s) to become available", d.Id()),
+		Pending:      []string{"creating"},
+		Target:       []string{"available"},
+		Failed:       []string{"error"},
+		Refresh:      cloudVolumeRefreshFunc(evsV2Client, d.Id()),
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		Delay:        3 * time.Second,
+		PollInterval: 5 * time.Second,
+	}
+	_, err = stateConf.WaitForState(ctx)
 	if err != nil {
 		return fmtp.DiagErrorf("Error waiting for the creation of EVS volume (%s) to complete: %s", d.Id(), err)
 	}
@@ -297,10 +431,15 @@ func resourceEvsVolumeRead(_ context.Context, d *schema.ResourceData, meta inter
 		return fmtp.DiagErrorf("Error creating HuaweiCloud block storage v2 client: %s", err)
 	}
 
-	resp, err := cloudvolumes.Get(evsV2Client, d.Id()).Extract()
+	result := cloudvolumes.Get(evsV2Client, d.Id())
+	resp, err := result.Extract()
 	if err != nil {
 		return common.CheckDeletedDiag(d, err, "EVS volume")
 	}
+	var qos volumeQoS
+	if err := result.ExtractInto(&qos); err != nil {
+		return fmtp.DiagErrorf("Error parsing the IOPS and throughput of EVS volume (%s): %s", d.Id(), err)
+	}
 
 	logp.Printf("[DEBUG] Retrieved volume %s: %+v", d.Id(), resp)
 	mErr := multierror.Append(
@@ -310,12 +449,15 @@ func resourceEvsVolumeRead(_ context.Context, d *schema.ResourceData, meta inter
 		d.Set("availability_zone", resp.AvailabilityZone),
 		d.Set("snapshot_id", resp.SnapshotID),
 		d.Set("volume_type", resp.VolumeType),
+		d.Set("iops", qos.Iops.TotalVal),
+		d.Set("throughput", qos.Throughput.TotalVal),
 		d.Set("enterprise_project_id", resp.EnterpriseProjectID),
 		d.Set("region", config.GetRegion(d)),
 		d.Set("wwn", resp.WWN),
 		d.Set("multiattach", resp.Multiattach),
-		d.Set("tags", resp.Tags),
+		d.Set("tags", utils.FlattenTagsMap(d, resp.Tags, config.TagsConfig)),
 		setEvsVolumeChargingInfo(d, resp),
+		common.SetPrePaidExpireTime(d, config, d.Id()),
 		setEvsVolumeDeviceType(d, resp),
 		setEvsVolumeImageId(d, resp),
 		setEvsVolumeAttachment(d, resp),
@@ -342,17 +484,33 @@ func resourceEvsVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 		_, err = cloudvolumes.Update(evsV2Client, d.Id(), updateOpts).Extract()
 		if err != nil {
-			return fmtp.DiagErrorf("Error updating HuaweiCloud volume: %s", err)
+			return common.DiagAPIError(err, "Error updating EVS volume (%s)", d.Id())
 		}
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(evsV2Client, d, "cloudvolumes", d.Id())
+		tagErr := utils.UpdateResourceTags(evsV2Client, d, "cloudvolumes", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of HuaweiCloud volume:%s, err:%s", d.Id(), tagErr)
 		}
 	}
 
+	// the standalone volumes can not be converted to prePaid, they are converted together with the instances
+	if err := common.UpdateChargeInfo(ctx, d, config, d.Id(), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("enterprise_project_id") {
+		migrateOpts := common.MigrateResourceOpts{
+			ResourceId:   d.Id(),
+			ResourceType: "disk",
+			ProjectId:    evsV2Client.ProjectID,
+		}
+		if err := common.MigrateEnterpriseProject(ctx, d, config, migrateOpts); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("size") {
 		evsV21Client, err := config.BlockStorageV21Client(config.GetRegion(d))
 		if err != nil {
@@ -373,7 +531,7 @@ func resourceEvsVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta i
 
 		resp, err := cloudvolumes.ExtendSize(evsV21Client, d.Id(), extendOpts).Extract()
 		if err != nil {
-			return fmtp.DiagErrorf("Error extending EVS volume (%s) size: %s", d.Id(), err)
+			return common.DiagAPIError(err, "Error extending EVS volume (%s) size", d.Id())
 		}
 
 		if strings.EqualFold(d.Get("charging_mode").(string), "prePaid") {
@@ -384,27 +542,140 @@ func resourceEvsVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			}
 		}
 
-		stateConf := &resource.StateChangeConf{
-			Pending:    []string{"extending"},
-			Target:     []string{"available", "in-use"},
-			Refresh:    cloudVolumeRefreshFunc(evsV2Client, d.Id()),
-			Timeout:    d.Timeout(schema.TimeoutUpdate),
-			Delay:      10 * time.Second,
-			MinTimeout: 3 * time.Second,
+		stateConf := &waiter.Conf{
+			Description:  fmt.Sprintf("EVS volume (%s) to be extended", d.Id()),
+			Pending:      []string{"extending"},
+			Target:       []string{"available", "in-use"},
+			Failed:       []string{"error", "error_extending"},
+			Refresh:      cloudVolumeRefreshFunc(evsV2Client, d.Id()),
+			Timeout:      d.Timeout(schema.TimeoutUpdate),
+			Delay:        10 * time.Second,
+			PollInterval: 3 * time.Second,
 		}
 
-		_, err = stateConf.WaitForStateContext(ctx)
+		_, err = stateConf.WaitForState(ctx)
 		if err != nil {
 			return fmtp.DiagErrorf("Error waiting for EVS volume (%s) to become ready: %s", d.Id(), err)
 		}
 	}
 
+	// the IOPS and throughput are changed together with the volume type
+	if d.HasChange("volume_type") {
+		if err := retypeEvsVolume(ctx, d, config); err != nil {
+			return common.DiagAPIError(err, "Error changing the type of EVS volume (%s)", d.Id())
+		}
+	} else if d.HasChanges("iops", "throughput") {
+		if err := updateEvsVolumeQoS(ctx, d, config); err != nil {
+			return common.DiagAPIError(err, "Error changing the IOPS and throughput of EVS volume (%s)", d.Id())
+		}
+	}
+
 	return resourceEvsVolumeRead(ctx, d, meta)
 }
 
-func resourceContainerTags(d *schema.ResourceData) map[string]string {
+// retypeEvsVolume changes the type of the volume online, the volume can be attached to an instance.
+// The error of the retype request is returned as it is.
+func retypeEvsVolume(ctx context.Context, d *schema.ResourceData, config *config.Config) error {
+	region := config.GetRegion(d)
+	evsV2Client, err := config.BlockStorageV2Client(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud block storage v2 client: %s", err)
+	}
+
+	// the IOPS and throughput which are not supported by the new type are not sent
+	newType := d.Get("volume_type").(string)
+	retypeOpts := volumeRetypeOpts{
+		Retype: volumeRetypeTypeOpts{
+			NewType: newType,
+		},
+	}
+	if _, ok := volumeQoSRanges["iops"][strings.ToUpper(newType)]; ok {
+		retypeOpts.Retype.Iops = d.Get("iops").(int)
+	}
+	if _, ok := volumeQoSRanges["throughput"][strings.ToUpper(newType)]; ok {
+		retypeOpts.Retype.Throughput = d.Get("throughput").(int)
+	}
+	// If charging mode is PrePaid, the order of the price difference is automatically paid.
+	isPrePaid := strings.EqualFold(d.Get("charging_mode").(string), "prePaid")
+	if isPrePaid {
+		retypeOpts.BssParam = &volumeRetypeBssParam{IsAutoPay: "true"}
+	}
+
+	logp.Printf("[DEBUG] Retype Options: %#v", retypeOpts)
+	var r volumeJobResponse
+	_, err = evsV2Client.Post(evsV2Client.ServiceURL("volumes", d.Id(), "retype"), retypeOpts, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200, 202}})
+	if err != nil {
+		return err
+	}
+
+	if isPrePaid && r.OrderID != "" {
+		if err := common.WaitOrderComplete(ctx, d, config, r.OrderID); err != nil {
+			return fmtp.Errorf("The order (%s) is not completed while changing the type of EVS volume (%s): %#v",
+				r.OrderID, d.Id(), err)
+		}
+	}
+	return waitForEvsVolumeJob(ctx, d, config, r.JobID, "retyping")
+}
+
+// updateEvsVolumeQoS changes the provisioned IOPS and throughput of the volume
+func updateEvsVolumeQoS(ctx context.Context, d *schema.ResourceData, config *config.Config) error {
+	evsV5Client, err := config.BlockStorageV5Client(config.GetRegion(d))
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud block storage v5 client: %s", err)
+	}
+
+	qosOpts := volumeQoSModifyOpts{
+		QoSModify: volumeQoSOpts{
+			Iops:       d.Get("iops").(int),
+			Throughput: d.Get("throughput").(int),
+		},
+	}
+	logp.Printf("[DEBUG] QoS Options: %#v", qosOpts)
+	var r volumeJobResponse
+	_, err = evsV5Client.Put(evsV5Client.ServiceURL("cloudvolumes", d.Id(), "qos"), qosOpts, &r,
+		&golangsdk.RequestOpts{OkCodes: []int{200, 202}})
+	if err != nil {
+		return err
+	}
+	return waitForEvsVolumeJob(ctx, d, config, r.JobID, "updating")
+}
+
+// waitForEvsVolumeJob waits for the EVS job to succeed and the volume to become available or in-use again
+func waitForEvsVolumeJob(ctx context.Context, d *schema.ResourceData, config *config.Config, jobID,
+	pendingStatus string) error {
+	region := config.GetRegion(d)
+	evsV1Client, err := config.BlockStorageV1Client(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud block storage v1 client: %s", err)
+	}
+	evsV2Client, err := config.BlockStorageV2Client(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud block storage v2 client: %s", err)
+	}
+
+	if _, err := waiter.WaitForJob(ctx, evsV1Client, jobID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return fmtp.Errorf("Error waiting for the job (%s) of EVS volume (%s) to complete: %s", jobID, d.Id(), err)
+	}
+
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("EVS volume (%s) to become ready", d.Id()),
+		Pending:      []string{pendingStatus},
+		Target:       []string{"available", "in-use"},
+		Failed:       []string{"error"},
+		Refresh:      cloudVolumeRefreshFunc(evsV2Client, d.Id()),
+		Timeout:      d.Timeout(schema.TimeoutUpdate),
+		PollInterval: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(ctx); err != nil {
+		return fmtp.Errorf("Error waiting for EVS volume (%s) to become ready: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceContainerTags(d *schema.ResourceData, tagsConfig *utils.TagsConfig) map[string]string {
 	m := make(map[string]string)
-	for key, val := range d.Get("tags").(map[string]interface{}) {
+	for key, val := range utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), tagsConfig) {
 		m[key] = val.(string)
 	}
 	return m
@@ -439,15 +710,18 @@ func resourceEvsVolumeDelete(ctx context.Context, d *schema.ResourceData, meta i
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			stateConf := &resource.StateChangeConf{
-				Pending:    []string{"RUNNING"},
-				Target:     []string{"SUCCESS", "NOTFOUND"},
-				Refresh:    AttachmentJobRefreshFunc(computeClient, job.ID),
-				Timeout:    10 * time.Minute,
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
+			stateConf := &waiter.Conf{
+				Description:  fmt.Sprintf("EVS volume (%s) to be detached from %s", d.Id(), attachment.ServerID),
+				Pending:      []string{"INIT", "RUNNING"},
+				Target:       []string{"SUCCESS", "NOTFOUND"},
+				Failed:       []string{"FAIL"},
+				Refresh:      AttachmentJobRefreshFunc(computeClient, job.ID),
+				FailReason:   attachmentJobFailReason,
+				Timeout:      10 * time.Minute,
+				Delay:        10 * time.Second,
+				PollInterval: 3 * time.Second,
 			}
-			if _, err = stateConf.WaitForState(); err != nil {
+			if _, err = stateConf.WaitForState(ctx); err != nil {
 				return diag.FromErr(err)
 			}
 		}
@@ -475,16 +749,18 @@ func resourceEvsVolumeDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Wait for the volume to delete before moving on.
 	logp.Printf("[DEBUG] Waiting for the EVS volume (%s) to delete", d.Id())
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"deleting", "downloading", "available"},
-		Target:     []string{"deleted"},
-		Refresh:    cloudVolumeRefreshFunc(evsV2Client, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
-
-	_, err = stateConf.WaitForState()
+	stateConf := &waiter.Conf{
+		Description:  fmt.Sprintf("EVS volume (%s) to be deleted", d.Id()),
+		Pending:      []string{"deleting", "downloading", "available"},
+		Target:       []string{"deleted"},
+		Failed:       []string{"error_deleting"},
+		Refresh:      cloudVolumeRefreshFunc(evsV2Client, d.Id()),
+		Timeout:      d.Timeout(schema.TimeoutDelete),
+		Delay:        10 * time.Second,
+		PollInterval: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState(ctx)
 	if err != nil {
 		return fmtp.DiagErrorf("Error waiting for the EVS volume (%s) to delete: %s", d.Id(), err)
 	}
@@ -493,6 +769,13 @@ func resourceEvsVolumeDelete(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+func resourceEvsVolumeImportState(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	// set the default value to avoid the changes in the plan after importing
+	d.Set("cascade", false)
+	return []*schema.ResourceData{d}, nil
+}
+
 func AttachmentJobRefreshFunc(c *golangsdk.ServiceClient, jobId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		resp, err := jobs.Get(c, jobId)
@@ -507,6 +790,13 @@ func AttachmentJobRefreshFunc(c *golangsdk.ServiceClient, jobId string) resource
 	}
 }
 
+func attachmentJobFailReason(result interface{}) string {
+	if job, ok := result.(*jobs.Job); ok {
+		return fmt.Sprintf("[%s] %s", job.ErrorCode, job.FailReason)
+	}
+	return ""
+}
+
 func cloudVolumeRefreshFunc(c *golangsdk.ServiceClient, volumeId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		response, err := cloudvolumes.Get(c, volumeId).Extract()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/chnsz/golangsdk"
@@ -51,6 +52,10 @@ func ResourceFunctionGraphTrigger() *schema.Resource {
 		Update: resourceFunctionGraphTriggerUpdate,
 		Delete: resourceFunctionGraphTriggerDelete,
 
+		Importer: &schema.ResourceImporter{
+			State: resourceFunctionGraphTriggerImportState,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Update: schema.DefaultTimeout(2 * time.Minute),
 		},
@@ -830,3 +835,14 @@ func resourceFunctionGraphTriggerDelete(d *schema.ResourceData, meta interface{}
 	d.SetId("")
 	return nil
 }
+
+func resourceFunctionGraphTriggerImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmtp.Errorf("Invalid format specified for FunctionGraph trigger. Format must be " +
+			"<function_urn>/<trigger_id>")
+	}
+
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, d.Set("function_urn", parts[0])
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/identity/v3/users"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
@@ -22,6 +21,19 @@ func ResourceIdentityGroupMembershipV3() *schema.Resource {
 		UpdateContext: resourceIdentityGroupMembershipV3Update,
 		DeleteContext: resourceIdentityGroupMembershipV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIdentityGroupMembershipV3Import,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceIdentityGroupMembershipV3SchemaV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceIdentityGroupMembershipV3StateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Schema: map[string]*schema.Schema{
 			"group": {
 				Type:     schema.TypeString,
@@ -38,6 +50,37 @@ func ResourceIdentityGroupMembershipV3() *schema.Resource {
 	}
 }
 
+func resourceIdentityGroupMembershipV3SchemaV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceIdentityGroupMembershipV3StateUpgradeV0 replaces the random ID of version 0 with the group ID,
+// the memberships are read by the group attribute, so the users of the state are kept as they are.
+func resourceIdentityGroupMembershipV3StateUpgradeV0(_ context.Context, rawState map[string]interface{},
+	_ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	if group, ok := rawState["group"].(string); ok && group != "" {
+		rawState["id"] = group
+	}
+	return rawState, nil
+}
+
 func resourceIdentityGroupMembershipV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*config.Config)
 	identityClient, err := config.IdentityV3Client(config.GetRegion(d))
@@ -52,8 +95,8 @@ func resourceIdentityGroupMembershipV3Create(ctx context.Context, d *schema.Reso
 		return fmtp.DiagErrorf("Error adding users to identity group: %s", err)
 	}
 
-	//lintignore:R015
-	d.SetId(resource.UniqueId())
+	// the group ID is used as the resource ID, so the membership can be imported by the group ID
+	d.SetId(group)
 
 	return resourceIdentityGroupMembershipV3Read(ctx, d, meta)
 }
@@ -165,3 +208,31 @@ func removeUsersFromGroup(identityClient *golangsdk.ServiceClient, group string,
 	}
 	return nil
 }
+
+// resourceIdentityGroupMembershipV3Import imports all the users of the group, the import ID is the group ID.
+func resourceIdentityGroupMembershipV3Import(_ context.Context, d *schema.ResourceData,
+	meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	identityClient, err := config.IdentityV3Client(config.GetRegion(d))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud identity client: %s", err)
+	}
+
+	allPages, err := users.ListInGroup(identityClient, d.Id(), users.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, fmtp.Errorf("Unable to query the users of group (%s): %s", d.Id(), err)
+	}
+	allUsers, err := users.ExtractUsers(allPages)
+	if err != nil {
+		return nil, fmtp.Errorf("Unable to retrieve users: %s", err)
+	}
+
+	userIDs := make([]string, len(allUsers))
+	for i, u := range allUsers {
+		userIDs[i] = u.ID
+	}
+	d.Set("group", d.Id())
+	d.Set("users", userIDs)
+
+	return []*schema.ResourceData{d}, nil
+}
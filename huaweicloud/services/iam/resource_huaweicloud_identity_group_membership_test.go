@@ -0,0 +1,28 @@
+package iam
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceIdentityGroupMembershipV3StateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":    "terraform-20220301080000000000000001",
+		"group": "89cd04f168b84af6be287f71730fdb4b",
+		"users": []interface{}{"user-1", "user-2"},
+	}
+	expected := map[string]interface{}{
+		"id":    "89cd04f168b84af6be287f71730fdb4b",
+		"group": "89cd04f168b84af6be287f71730fdb4b",
+		"users": []interface{}{"user-1", "user-2"},
+	}
+
+	actual, err := resourceIdentityGroupMembershipV3StateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("error upgrading the state: %s", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	}
+}
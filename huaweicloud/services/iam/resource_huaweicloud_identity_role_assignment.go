@@ -22,6 +22,10 @@ func ResourceIdentityRoleAssignmentV3() *schema.Resource {
 		ReadContext:   resourceIdentityRoleAssignmentV3Read,
 		DeleteContext: resourceIdentityRoleAssignmentV3Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIdentityRoleAssignmentV3Import,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"role_id": {
 				Type:     schema.TypeString,
@@ -166,3 +170,12 @@ func ExtractRoleAssignmentID(roleAssignmentID string) (string, string, string, s
 	split := strings.Split(roleAssignmentID, "/")
 	return split[0], split[1], split[2], split[3]
 }
+
+func resourceIdentityRoleAssignmentV3Import(_ context.Context, d *schema.ResourceData,
+	_ interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 4 {
+		return nil, fmtp.Errorf("Invalid format specified for role assignment. " +
+			"Format must be <domain_id>/<project_id>/<group_id>/<role_id>, and one of domain_id and project_id is empty")
+	}
+	return []*schema.ResourceData{d}, nil
+}
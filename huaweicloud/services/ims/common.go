@@ -0,0 +1,93 @@
+package ims
+
+import (
+	"context"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/chnsz/golangsdk/openstack/ims/v2/cloudimages"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common/waiter"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+)
+
+// imageMember is the project which the image is shared with
+type imageMember struct {
+	MemberID  string `json:"member_id"`
+	ImageID   string `json:"image_id"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// imageMembersOpts is the request body of the APIs which share the images, or accept and reject the shared images
+type imageMembersOpts struct {
+	Images    []string `json:"images"`
+	Projects  []string `json:"projects,omitempty"`
+	ProjectID string   `json:"project_id,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	VaultID   string   `json:"vault_id,omitempty"`
+}
+
+type imageJobResponse struct {
+	JobID string `json:"job_id"`
+}
+
+// WaitForImageJob waits for the IMS job to succeed, the client is the IMS v1 client.
+// The jobs of IMS are queried by the path "v1/{project_id}/jobs/{job_id}".
+func WaitForImageJob(ctx context.Context, client *golangsdk.ServiceClient, jobID string,
+	timeout time.Duration) (*waiter.Job, error) {
+	jobClient := *client
+	jobClient.ResourceBase = client.ResourceBase + client.ProjectID + "/"
+	return waiter.WaitForJob(ctx, &jobClient, jobID, timeout)
+}
+
+// getImage returns the image by ID, nil is returned if the image does not exist.
+func getImage(client *golangsdk.ServiceClient, id string) (*cloudimages.Image, error) {
+	allPages, err := cloudimages.List(client, &cloudimages.ListOpts{ID: id, Limit: 1}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	images, err := cloudimages.ExtractImages(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(images) < 1 || images[0].ID != id {
+		return nil, nil
+	}
+	return &images[0], nil
+}
+
+// listImageMembers returns the projects which the image is shared with, the client is the IMS v2 client.
+func listImageMembers(client *golangsdk.ServiceClient, imageID string) ([]imageMember, error) {
+	var r struct {
+		Members []imageMember `json:"members"`
+	}
+	_, err := client.Get(client.ServiceURL("images", imageID, "members"), &r, nil)
+	return r.Members, err
+}
+
+// updateImageMembers shares the images with the projects by the method POST, stops sharing by DELETE, or accepts
+// and rejects the shared images by PUT. The client is the IMS v1 client and the job ID is returned.
+func updateImageMembers(client *golangsdk.ServiceClient, method string, opts imageMembersOpts) (string, error) {
+	var r imageJobResponse
+	url := client.ServiceURL("cloudimages", "members")
+	reqOpts := &golangsdk.RequestOpts{OkCodes: []int{200}}
+
+	var err error
+	switch method {
+	case "POST":
+		_, err = client.Post(url, opts, &r, reqOpts)
+	case "PUT":
+		_, err = client.Put(url, opts, &r, reqOpts)
+	case "DELETE":
+		_, err = client.DeleteWithBodyResp(url, opts, &r, reqOpts)
+	default:
+		return "", fmtp.Errorf("unsupported method %s to update the image members", method)
+	}
+	if err != nil {
+		return "", err
+	}
+	return r.JobID, nil
+}
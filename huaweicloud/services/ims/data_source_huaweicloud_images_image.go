@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
 
@@ -26,6 +27,23 @@ var imageValidVisibilities = []string{
 	"public", "private", "community", "shared",
 }
 
+// imageFilterFields maps the names of filter block to the fields of cloudimages.Image
+var imageFilterFields = map[string]string{
+	"name":                  "Name",
+	"owner":                 "Owner",
+	"visibility":            "Visibility",
+	"os":                    "Platform",
+	"os_version":            "OsVersion",
+	"image_type":            "VirtualEnvType",
+	"container_format":      "ContainerFormat",
+	"disk_format":           "DiskFormat",
+	"min_disk_gb":           "MinDisk",
+	"min_ram_mb":            "MinRam",
+	"size_bytes":            "ImageSize",
+	"protected":             "Protected",
+	"enterprise_project_id": "EnterpriseProjectID",
+}
+
 func DataSourceImagesImageV2() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceImagesImageV2Read,
@@ -110,17 +128,21 @@ func DataSourceImagesImageV2() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": common.FilterSchema([]string{
+				"name", "owner", "visibility", "os", "os_version", "image_type", "container_format", "disk_format",
+				"min_disk_gb", "min_ram_mb", "size_bytes", "protected", "enterprise_project_id",
+			}),
 
 			// Deprecated values
 			"size_min": {
 				Type:       schema.TypeInt,
 				Optional:   true,
-				Deprecated: "size_min is deprecated",
+				Deprecated: "size_min is deprecated and has no replacement",
 			},
 			"size_max": {
 				Type:       schema.TypeInt,
 				Optional:   true,
-				Deprecated: "size_max is deprecated",
+				Deprecated: "size_max is deprecated and has no replacement",
 			},
 
 			// Computed values
@@ -249,6 +271,19 @@ func dataSourceImagesImageV2Read(ctx context.Context, d *schema.ResourceData, me
 		filteredImages = allImages[:]
 	}
 
+	// filter images by the filter blocks
+	if rules := common.ExpandFilterRules(d, imageFilterFields); len(rules) > 0 {
+		matchedImages, err := utils.FilterSliceWithRules(filteredImages, rules)
+		if err != nil {
+			return fmtp.DiagErrorf("filter images failed: %s", err)
+		}
+
+		filteredImages = make([]cloudimages.Image, len(matchedImages))
+		for i, image := range matchedImages {
+			filteredImages[i] = image.(cloudimages.Image)
+		}
+	}
+
 	if len(filteredImages) < 1 {
 		return fmtp.DiagErrorf("Your query returned no results. " +
 			"Please change your search criteria and try again.")
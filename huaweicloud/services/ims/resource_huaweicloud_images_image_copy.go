@@ -0,0 +1,278 @@
+package ims
+
+import (
+	"context"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	imageservice_v2 "github.com/chnsz/golangsdk/openstack/imageservice/v2/images"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+func ResourceImsImageCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceImsImageCopyCreate,
+		ReadContext:   resourceImsImageCopyRead,
+		UpdateContext: resourceImsImageCopyUpdate,
+		DeleteContext: resourceImsImageCopyDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(3 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			// region is the region of the source image
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"source_image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			// cmk_id and enterprise_project_id are valid for copying the image in the same region
+			"cmk_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"target_region"},
+			},
+			"enterprise_project_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"target_region"},
+			},
+			// target_region, agency_name and vault_id are valid for copying the image to another region
+			"target_region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"agency_name"},
+			},
+			"agency_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"target_region"},
+			},
+			"vault_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"target_region"},
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"image_size": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"disk_format": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// imageCopyOpts is the request body of copying the image in the same region or to another region
+type imageCopyOpts struct {
+	Name                string `json:"name"`
+	Description         string `json:"description,omitempty"`
+	CmkID               string `json:"cmk_id,omitempty"`
+	EnterpriseProjectID string `json:"enterprise_project_id,omitempty"`
+	Region              string `json:"region,omitempty"`
+	ProjectName         string `json:"project_name,omitempty"`
+	AgencyName          string `json:"agency_name,omitempty"`
+	VaultID             string `json:"vault_id,omitempty"`
+}
+
+// getImageCopyRegion returns the region of the copied image
+func getImageCopyRegion(d *schema.ResourceData, conf *config.Config) string {
+	if v, ok := d.GetOk("target_region"); ok {
+		return v.(string)
+	}
+	return conf.GetRegion(d)
+}
+
+// copyImage copies the image in the same region, or to the target region, the ID of the IMS job is returned.
+func copyImage(client *golangsdk.ServiceClient, imageID string, opts imageCopyOpts) (string, error) {
+	action := "copy"
+	if opts.Region != "" {
+		action = "cross_region_copy"
+	}
+
+	var r imageJobResponse
+	_, err := client.Post(client.ServiceURL("cloudimages", imageID, action), opts, &r, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return "", err
+	}
+	return r.JobID, nil
+}
+
+func resourceImsImageCopyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	imsClient, err := conf.ImageV1Client(conf.GetRegion(d))
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating HuaweiCloud image v1 client: %s", err)
+	}
+
+	sourceImageID := d.Get("source_image_id").(string)
+	opts := imageCopyOpts{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+	if targetRegion, ok := d.GetOk("target_region"); ok {
+		// the copied image belongs to the default project of the target region
+		opts.Region = targetRegion.(string)
+		opts.ProjectName = targetRegion.(string)
+		opts.AgencyName = d.Get("agency_name").(string)
+		opts.VaultID = d.Get("vault_id").(string)
+	} else {
+		opts.CmkID = d.Get("cmk_id").(string)
+		opts.EnterpriseProjectID = common.GetEnterpriseProjectID(d, conf)
+	}
+
+	logp.Printf("[DEBUG] Copy image %s options: %#v", sourceImageID, opts)
+	jobID, err := copyImage(imsClient, sourceImageID, opts)
+	if err != nil {
+		return common.DiagAPIError(err, "Error copying image %s", sourceImageID)
+	}
+
+	job, err := WaitForImageJob(ctx, imsClient, jobID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return common.DiagAPIError(err, "Error waiting for the image %s to be copied", sourceImageID)
+	}
+	if job.Entities.ImageID == "" {
+		return fmtp.DiagErrorf("Error copying image %s: the new image ID is not found in job %s",
+			sourceImageID, jobID)
+	}
+
+	d.SetId(job.Entities.ImageID)
+	return resourceImsImageCopyRead(ctx, d, meta)
+}
+
+func resourceImsImageCopyRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	imsClient, err := conf.ImageV2Client(getImageCopyRegion(d, conf))
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating HuaweiCloud image client: %s", err)
+	}
+
+	img, err := getImage(imsClient, d.Id())
+	if err != nil {
+		return common.DiagAPIError(err, "Error retrieving the copied image %s", d.Id())
+	}
+	if img == nil {
+		logp.Printf("[WARN] the copied image %s does not exist, remove it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	logp.Printf("[DEBUG] Retrieved the copied image %s: %#v", d.Id(), img)
+
+	mErr := multierror.Append(
+		d.Set("region", conf.GetRegion(d)),
+		d.Set("name", img.Name),
+		d.Set("description", img.Description),
+		d.Set("status", img.Status),
+		d.Set("image_size", img.ImageSize),
+		d.Set("disk_format", img.DiskFormat),
+	)
+	// the enterprise project of the image in the target region is not managed by the resource
+	if _, ok := d.GetOk("target_region"); !ok {
+		mErr = multierror.Append(mErr, d.Set("enterprise_project_id", img.EnterpriseProjectID))
+	}
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmtp.DiagErrorf("Error setting the fields of the copied image: %s", err)
+	}
+	return nil
+}
+
+func resourceImsImageCopyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	imsClient, err := conf.ImageV2Client(getImageCopyRegion(d, conf))
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating HuaweiCloud image client: %s", err)
+	}
+
+	if d.HasChange("name") {
+		updateOpts := imageservice_v2.UpdateOpts{
+			imageservice_v2.ReplaceImageName{NewName: d.Get("name").(string)},
+		}
+		logp.Printf("[DEBUG] Update Options: %#v", updateOpts)
+		if _, err := imageservice_v2.Update(imsClient, d.Id(), updateOpts).Extract(); err != nil {
+			return common.DiagAPIError(err, "Error updating the name of image %s", d.Id())
+		}
+	}
+
+	return resourceImsImageCopyRead(ctx, d, meta)
+}
+
+func resourceImsImageCopyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	imsClient, err := conf.ImageV2Client(getImageCopyRegion(d, conf))
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating HuaweiCloud image client: %s", err)
+	}
+
+	logp.Printf("[DEBUG] Deleting the copied image %s", d.Id())
+	if err := imageservice_v2.Delete(imsClient, d.Id()).ExtractErr(); err != nil {
+		return common.CheckDeletedDiag(d, err, "Error deleting the copied image")
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE"},
+		Target:     []string{"DELETED"},
+		Refresh:    imageDeleteRefreshFunc(imsClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmtp.DiagErrorf("Error waiting for the copied image %s to be deleted: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func imageDeleteRefreshFunc(client *golangsdk.ServiceClient, imageID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		r, err := imageservice_v2.Get(client, imageID).Extract()
+		if err != nil {
+			if common.IsNotFound(err) {
+				logp.Printf("[INFO] Successfully deleted image %s", imageID)
+				return imageID, "DELETED", nil
+			}
+			return nil, "", err
+		}
+		return r, "ACTIVE", nil
+	}
+}
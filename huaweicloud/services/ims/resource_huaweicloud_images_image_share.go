@@ -0,0 +1,177 @@
+package ims
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+func ResourceImsImageShare() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceImsImageShareCreate,
+		ReadContext:   resourceImsImageShareRead,
+		UpdateContext: resourceImsImageShareUpdate,
+		DeleteContext: resourceImsImageShareDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"source_image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"target_project_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// shareImage shares the image with the projects, or stops sharing if the method is DELETE
+func shareImage(ctx context.Context, d *schema.ResourceData, conf *config.Config, method string, projects []string,
+	timeout time.Duration) error {
+	imsClient, err := conf.ImageV1Client(conf.GetRegion(d))
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud image v1 client: %s", err)
+	}
+
+	opts := imageMembersOpts{
+		Images:   []string{d.Get("source_image_id").(string)},
+		Projects: projects,
+	}
+	logp.Printf("[DEBUG] %s image members options: %#v", method, opts)
+	jobID, err := updateImageMembers(imsClient, method, opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = WaitForImageJob(ctx, imsClient, jobID, timeout)
+	return err
+}
+
+func resourceImsImageShareCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	imageID := d.Get("source_image_id").(string)
+	projects := utils.ExpandToStringListBySet(d.Get("target_project_ids").(*schema.Set))
+
+	if err := shareImage(ctx, d, conf, "POST", projects, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return common.DiagAPIError(err, "Error sharing image %s", imageID)
+	}
+
+	d.SetId(imageID)
+	return resourceImsImageShareRead(ctx, d, meta)
+}
+
+func resourceImsImageShareRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	imsClient, err := conf.ImageV2Client(conf.GetRegion(d))
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating HuaweiCloud image client: %s", err)
+	}
+
+	members, err := listImageMembers(imsClient, d.Id())
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "Error retrieving the members of image")
+	}
+	if len(members) == 0 {
+		logp.Printf("[WARN] the image %s is not shared with any project, remove it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	projectIDs := make([]string, len(members))
+	memberList := make([]map[string]interface{}, len(members))
+	for i, member := range members {
+		projectIDs[i] = member.MemberID
+		memberList[i] = map[string]interface{}{
+			"project_id": member.MemberID,
+			"status":     member.Status,
+		}
+	}
+
+	mErr := multierror.Append(
+		d.Set("region", conf.GetRegion(d)),
+		d.Set("source_image_id", d.Id()),
+		d.Set("target_project_ids", projectIDs),
+		d.Set("members", memberList),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmtp.DiagErrorf("Error setting the fields of image share: %s", err)
+	}
+	return nil
+}
+
+func resourceImsImageShareUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	oldRaw, newRaw := d.GetChange("target_project_ids")
+	oldSet, newSet := oldRaw.(*schema.Set), newRaw.(*schema.Set)
+	if removed := utils.ExpandToStringListBySet(oldSet.Difference(newSet)); len(removed) > 0 {
+		if err := shareImage(ctx, d, conf, "DELETE", removed, timeout); err != nil {
+			return common.DiagAPIError(err, "Error stopping sharing image %s", d.Id())
+		}
+	}
+	if added := utils.ExpandToStringListBySet(newSet.Difference(oldSet)); len(added) > 0 {
+		if err := shareImage(ctx, d, conf, "POST", added, timeout); err != nil {
+			return common.DiagAPIError(err, "Error sharing image %s", d.Id())
+		}
+	}
+
+	return resourceImsImageShareRead(ctx, d, meta)
+}
+
+func resourceImsImageShareDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	projects := utils.ExpandToStringListBySet(d.Get("target_project_ids").(*schema.Set))
+
+	if err := shareImage(ctx, d, conf, "DELETE", projects, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return common.CheckDeletedDiag(d, err, "Error stopping sharing image")
+	}
+
+	d.SetId("")
+	return nil
+}
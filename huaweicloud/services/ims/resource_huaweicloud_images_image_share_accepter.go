@@ -0,0 +1,146 @@
+package ims
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+func ResourceImsImageShareAccepter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceImsImageShareAccepterCreate,
+		ReadContext:   resourceImsImageShareAccepterRead,
+		DeleteContext: resourceImsImageShareAccepterDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// vault_id is required to accept a full-ECS image which is created from a CBR backup
+			"vault_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// acceptImage accepts or rejects the image shared with the project of the region
+func acceptImage(ctx context.Context, d *schema.ResourceData, conf *config.Config, status string,
+	timeout time.Duration) error {
+	region := conf.GetRegion(d)
+	imsClient, err := conf.ImageV1Client(region)
+	if err != nil {
+		return fmtp.Errorf("Error creating HuaweiCloud image v1 client: %s", err)
+	}
+	projectID, err := conf.GetProjectID(region)
+	if err != nil {
+		return err
+	}
+
+	opts := imageMembersOpts{
+		Images:    []string{d.Get("image_id").(string)},
+		ProjectID: projectID,
+		Status:    status,
+		VaultID:   d.Get("vault_id").(string),
+	}
+	logp.Printf("[DEBUG] Update the status of image member options: %#v", opts)
+	jobID, err := updateImageMembers(imsClient, "PUT", opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = WaitForImageJob(ctx, imsClient, jobID, timeout)
+	return err
+}
+
+func resourceImsImageShareAccepterCreate(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	imageID := d.Get("image_id").(string)
+
+	if err := acceptImage(ctx, d, conf, "accepted", d.Timeout(schema.TimeoutCreate)); err != nil {
+		return common.DiagAPIError(err, "Error accepting the shared image %s", imageID)
+	}
+
+	d.SetId(imageID)
+	return resourceImsImageShareAccepterRead(ctx, d, meta)
+}
+
+func resourceImsImageShareAccepterRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	region := conf.GetRegion(d)
+	imsClient, err := conf.ImageV2Client(region)
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating HuaweiCloud image client: %s", err)
+	}
+	projectID, err := conf.GetProjectID(region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var member imageMember
+	_, err = imsClient.Get(imsClient.ServiceURL("images", d.Id(), "members", projectID), &member, nil)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "Error retrieving the shared image")
+	}
+	// the image is rejected or not handled by the project
+	if member.Status != "accepted" {
+		logp.Printf("[WARN] the shared image %s is %s, remove it from the state", d.Id(), member.Status)
+		d.SetId("")
+		return nil
+	}
+
+	mErr := multierror.Append(
+		d.Set("region", region),
+		d.Set("image_id", d.Id()),
+		d.Set("status", member.Status),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmtp.DiagErrorf("Error setting the fields of the shared image: %s", err)
+	}
+	return nil
+}
+
+func resourceImsImageShareAccepterDelete(ctx context.Context, d *schema.ResourceData,
+	meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+
+	if err := acceptImage(ctx, d, conf, "rejected", d.Timeout(schema.TimeoutDelete)); err != nil {
+		// the image has been deleted or is no longer shared with the project
+		return common.CheckDeletedDiag(d, err, "Error rejecting the shared image")
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -123,7 +123,7 @@ func dataSourceELBV2LoadbalancerRead(d *schema.ResourceData, meta interface{}) e
 	if err != nil {
 		logp.Printf("[WARN] Error fetching tags of elb load balancer %s: %s", d.Id(), err)
 	}
-	tagmap := utils.TagsToMap(resourceTags.Tags)
+	tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 	d.Set("tags", tagmap)
 
 	return nil
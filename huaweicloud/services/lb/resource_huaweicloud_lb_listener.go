@@ -26,6 +26,10 @@ func ResourceListenerV2() *schema.Resource {
 		UpdateContext: resourceListenerV2Update,
 		DeleteContext: resourceListenerV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceListenerV2Import,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -60,7 +64,7 @@ func ResourceListenerV2() *schema.Resource {
 				Optional:   true,
 				Computed:   true,
 				ForceNew:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 
 			"loadbalancer_id": {
@@ -183,7 +187,7 @@ func resourceListenerV2Create(ctx context.Context, d *schema.ResourceData, meta
 	d.SetId(listener.ID)
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(lbv2Client, "listeners", listener.ID, taglist).ExtractErr(); tagErr != nil {
@@ -231,7 +235,7 @@ func resourceListenerV2Read(_ context.Context, d *schema.ResourceData, meta inte
 
 	// fetch tags
 	if resourceTags, err := tags.Get(lbv2Client, "listeners", d.Id()).Extract(); err == nil {
-		tagmap := utils.TagsToMap(resourceTags.Tags)
+		tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 		mErr = multierror.Append(mErr, d.Set("tags", tagmap))
 	} else {
 		logp.Printf("[WARN] fetching tags of elb listener failed: %s", err)
@@ -324,7 +328,7 @@ func resourceListenerV2Update(ctx context.Context, d *schema.ResourceData, meta
 
 	// update tags
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(lbv2Client, d, "listeners", d.Id())
+		tagErr := utils.UpdateResourceTags(lbv2Client, d, "listeners", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of elb listener:%s, err:%s", d.Id(), tagErr)
 		}
@@ -377,3 +381,23 @@ func resourceListenerV2Delete(ctx context.Context, d *schema.ResourceData, meta
 
 	return nil
 }
+
+func resourceListenerV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	lbClient, err := config.LoadBalancerClient(config.GetRegion(d))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud elb client: %s", err)
+	}
+
+	listener, err := listeners.Get(lbClient, d.Id()).Extract()
+	if err != nil {
+		return nil, common.CheckDeleted(d, err, "listener")
+	}
+
+	logp.Printf("[DEBUG] Retrieved listener %s during the import: %#v", d.Id(), listener)
+	if len(listener.Loadbalancers) > 0 {
+		d.Set("loadbalancer_id", listener.Loadbalancers[0].ID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -2,6 +2,7 @@ package lb
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -23,6 +24,10 @@ func ResourceMemberV2() *schema.Resource {
 		UpdateContext: resourceMemberV2Update,
 		DeleteContext: resourceMemberV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMemberV2Import,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -47,7 +52,7 @@ func ResourceMemberV2() *schema.Resource {
 				Optional:   true,
 				Computed:   true,
 				ForceNew:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 
 			"address": {
@@ -258,3 +263,15 @@ func resourceMemberV2Delete(ctx context.Context, d *schema.ResourceData, meta in
 
 	return nil
 }
+
+func resourceMemberV2Import(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return nil, fmtp.Errorf("Invalid format specified for member. Format must be <pool_id>/<member_id>")
+	}
+
+	d.SetId(parts[1])
+	d.Set("pool_id", parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
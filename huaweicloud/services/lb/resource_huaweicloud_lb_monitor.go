@@ -23,6 +23,10 @@ func ResourceMonitorV2() *schema.Resource {
 		UpdateContext: resourceMonitorV2Update,
 		DeleteContext: resourceMonitorV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMonitorV2Import,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -103,7 +107,7 @@ func ResourceMonitorV2() *schema.Resource {
 				Optional:   true,
 				Computed:   true,
 				ForceNew:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 		},
 	}
@@ -294,3 +298,23 @@ func resourceMonitorV2Delete(ctx context.Context, d *schema.ResourceData, meta i
 
 	return nil
 }
+
+func resourceMonitorV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	lbClient, err := config.LoadBalancerClient(config.GetRegion(d))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud elb client: %s", err)
+	}
+
+	monitor, err := monitors.Get(lbClient, d.Id()).Extract()
+	if err != nil {
+		return nil, common.CheckDeleted(d, err, "monitor")
+	}
+
+	logp.Printf("[DEBUG] Retrieved monitor %s during the import: %#v", d.Id(), monitor)
+	if len(monitor.Pools) > 0 {
+		d.Set("pool_id", monitor.Pools[0].ID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
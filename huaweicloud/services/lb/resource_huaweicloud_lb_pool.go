@@ -24,6 +24,10 @@ func ResourcePoolV2() *schema.Resource {
 		UpdateContext: resourcePoolV2Update,
 		DeleteContext: resourcePoolV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePoolV2Import,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -43,7 +47,7 @@ func ResourcePoolV2() *schema.Resource {
 				Optional:   true,
 				Computed:   true,
 				ForceNew:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 
 			"name": {
@@ -344,3 +348,26 @@ func resourcePoolV2Delete(ctx context.Context, d *schema.ResourceData, meta inte
 
 	return nil
 }
+
+func resourcePoolV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*config.Config)
+	lbClient, err := config.LoadBalancerClient(config.GetRegion(d))
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud elb client: %s", err)
+	}
+
+	pool, err := pools.Get(lbClient, d.Id()).Extract()
+	if err != nil {
+		return nil, common.CheckDeleted(d, err, "pool")
+	}
+
+	logp.Printf("[DEBUG] Retrieved pool %s during the import: %#v", d.Id(), pool)
+	// the pool is associated with the listener or the loadbalancer, and the listener is preferred
+	if len(pool.Listeners) > 0 {
+		d.Set("listener_id", pool.Listeners[0].ID)
+	} else if len(pool.Loadbalancers) > 0 {
+		d.Set("loadbalancer_id", pool.Loadbalancers[0].ID)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
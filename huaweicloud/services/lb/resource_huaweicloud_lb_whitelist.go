@@ -22,6 +22,10 @@ func ResourceWhitelistV2() *schema.Resource {
 		UpdateContext: resourceWhitelistV2Update,
 		DeleteContext: resourceWhitelistV2Delete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Update: schema.DefaultTimeout(10 * time.Minute),
@@ -40,7 +44,7 @@ func ResourceWhitelistV2() *schema.Resource {
 				Optional:   true,
 				Computed:   true,
 				ForceNew:   true,
-				Deprecated: "tenant_id is deprecated",
+				Deprecated: "tenant_id is deprecated and has no replacement",
 			},
 
 			"listener_id": {
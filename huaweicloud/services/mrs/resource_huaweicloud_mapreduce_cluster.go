@@ -500,7 +500,7 @@ func addTagsToMrsCluster(d *schema.ResourceData, config *config.Config) error {
 		return fmtp.Errorf("Error creating Huaweicloud MRS V1 client: %s", err)
 	}
 
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		taglist := utils.ExpandResourceTags(tagRaw)
 		if tagErr := tags.Create(client, "clusters", d.Id(), taglist).ExtractErr(); tagErr != nil {
@@ -773,12 +773,12 @@ func parseHostIps(groupName string, isCustomNode bool, clustHostMap map[string][
 	return rt
 }
 
-func setClsuterTags(d *schema.ResourceData, client *golangsdk.ServiceClient) error {
+func setClsuterTags(d *schema.ResourceData, client *golangsdk.ServiceClient, tagsConfig *utils.TagsConfig) error {
 	resourceTags, err := tags.Get(client, "clusters", d.Id()).Extract()
 	if err != nil {
 		return fmtp.Errorf("Error Fetching tags of MapReduce cluster form server: %s", err)
 	}
-	tagmap := utils.TagsToMap(resourceTags.Tags)
+	tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, tagsConfig)
 	return d.Set("tags", tagmap)
 }
 
@@ -832,7 +832,7 @@ func resourceMRSClusterV2Read(d *schema.ResourceData, meta interface{}) error {
 		setMrsClsuterChargingTimestamp(d, resp),
 		setMrsClsuterCreateTimestamp(d, resp),
 		setMrsClusterNodeGroups(d, client, resp),
-		setClsuterTags(d, client),
+		setClsuterTags(d, client, config.TagsConfig),
 	)
 	if err := mErr.ErrorOrNil(); err != nil {
 		return fmtp.Errorf("Error setting vault fields: %s", err)
@@ -1016,7 +1016,7 @@ func resourceMRSClusterV2Update(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if d.HasChange("tags") {
-		tagErr := utils.UpdateResourceTags(client, d, "clusters", d.Id())
+		tagErr := utils.UpdateResourceTags(client, d, "clusters", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.Errorf("Error updating tags of MRS cluster:%s, err:%s", d.Id(), tagErr)
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
@@ -55,6 +56,9 @@ func DataSourceRdsFlavor() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": common.FilterSchema([]string{
+				"id", "name", "vcpus", "memory", "instance_mode", "availability_zones", "db_versions",
+			}),
 			"flavors": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -146,7 +150,6 @@ func dataSourceRdsFlavorRead(_ context.Context, d *schema.ResourceData, meta int
 	}
 
 	var resultFlavors []interface{}
-	var ids []string
 
 	for _, item := range filterFlavors {
 		flavor := item.(flavors.Flavors)
@@ -170,9 +173,18 @@ func dataSourceRdsFlavorRead(_ context.Context, d *schema.ResourceData, meta int
 		if len(azList) > 0 && len(versionList) > 0 {
 			resultFlavors = append(resultFlavors, flattenRdsFlavor(flavor, azList, versionList))
 		}
+	}
 
-		ids = append(ids, flavor.ID)
+	if rules := common.ExpandFilterRules(d, nil); len(rules) > 0 {
+		resultFlavors, err = utils.FilterSliceWithRules(resultFlavors, rules)
+		if err != nil {
+			return fmtp.DiagErrorf("filter RDS flavors failed: %s", err)
+		}
+	}
 
+	ids := make([]string, len(resultFlavors))
+	for i, flavor := range resultFlavors {
+		ids[i] = flavor.(map[string]interface{})["id"].(string)
 	}
 
 	logp.Printf("[DEBUG]RDS flavors api return:%d, after filter: %d, %v", len(flavorsResp.Flavorslist), len(resultFlavors), resultFlavors)
@@ -289,7 +289,7 @@ func dataSourceRdsInstancesRead(ctx context.Context, d *schema.ResourceData, met
 			"flavor":                instanceInAll.FlavorRef,
 			"time_zone":             instanceInAll.TimeZone,
 			"enterprise_project_id": instanceInAll.EnterpriseProjectId,
-			"tags":                  utils.TagsToMap(instanceInAll.Tags),
+			"tags":                  utils.TagsToMap(instanceInAll.Tags, config.TagsConfig),
 		}
 
 		instanceID := instanceInAll.Id
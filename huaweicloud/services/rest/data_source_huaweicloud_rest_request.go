@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+)
+
+func DataSourceRestRequest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRestRequestRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"service": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GET",
+				ValidateFunc: validation.StringInSlice([]string{"GET", "POST"}, false),
+			},
+			"query": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"headers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"ok_codes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"result_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status_code": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"response_body": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceRestRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conf := meta.(*config.Config)
+	region := conf.GetRegion(d)
+	client, err := newRestClient(conf, d.Get("service").(string), region, d.Get("headers").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	method := d.Get("method").(string)
+	requestURL := client.buildURL(d.Get("path").(string), d.Get("query").(map[string]interface{}), nil)
+	resp, err := client.doRequest(method, requestURL, d.Get("body").(string), expandOkCodes(d.Get("ok_codes").([]interface{})))
+	if err != nil {
+		return fmtp.DiagErrorf("Error sending request %s %s: %s", method, requestURL, err)
+	}
+
+	var result string
+	if resp.Body != nil {
+		result, err = searchJSON(d.Get("result_path").(string), resp.Body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(hashcode.Strings([]string{method, requestURL, d.Get("body").(string)}))
+	mErr := multierror.Append(nil,
+		d.Set("region", region),
+		d.Set("status_code", resp.StatusCode),
+		d.Set("response_body", resp.RawBody),
+		d.Set("result", result),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmtp.DiagErrorf("Error setting REST request fields: %s", err)
+	}
+	return nil
+}
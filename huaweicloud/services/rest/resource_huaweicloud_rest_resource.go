@@ -0,0 +1,355 @@
+package rest
+
+import (
+	"context"
+	"time"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+func ResourceRestResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRestResourceCreate,
+		ReadContext:   resourceRestResourceRead,
+		UpdateContext: resourceRestResourceUpdate,
+		DeleteContext: resourceRestResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"service": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"headers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"create_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"create_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "POST",
+				ValidateFunc: validation.StringInSlice([]string{"POST", "PUT"}, false),
+			},
+			"create_body": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+			},
+			"id_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"read_path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"read_result_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"update_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"update_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "PUT",
+				ValidateFunc: validation.StringInSlice([]string{"POST", "PUT", "PATCH"}, false),
+			},
+			"update_body": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"update_path"},
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+			},
+			"delete_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"delete_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DELETE",
+				ValidateFunc: validation.StringInSlice([]string{"DELETE", "POST", "PUT"}, false),
+			},
+			"delete_body": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"status_polling": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status_path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"success_values": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"failure_values": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"job_id_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"result": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	equal, _ := utils.CompareJsonTemplateAreEquivalent(old, new)
+	return equal
+}
+
+func buildResourceRestClient(d *schema.ResourceData, meta interface{}) (*restClient, error) {
+	conf := meta.(*config.Config)
+	return newRestClient(conf, d.Get("service").(string), conf.GetRegion(d), d.Get("headers").(map[string]interface{}))
+}
+
+func resourceRestResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := buildResourceRestClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	method := d.Get("create_method").(string)
+	requestURL := client.buildURL(d.Get("create_path").(string), nil, nil)
+	resp, err := client.doRequest(method, requestURL, d.Get("create_body").(string), nil)
+	if err != nil {
+		return fmtp.DiagErrorf("Error creating REST resource by %s %s: %s", method, requestURL, err)
+	}
+	if resp.Body == nil {
+		return fmtp.DiagErrorf("Error creating REST resource: the response body is not JSON: %s", resp.RawBody)
+	}
+
+	id, err := searchString(d.Get("id_path").(string), resp.Body)
+	if err != nil {
+		return fmtp.DiagErrorf("Error extracting the ID of REST resource: %s", err)
+	}
+	d.SetId(id)
+
+	if err := waitForRestResourceStatus(ctx, d, client, resp.Body, d.Timeout(schema.TimeoutCreate), false); err != nil {
+		return fmtp.DiagErrorf("Error waiting for the creation of REST resource (%s) to complete: %s", id, err)
+	}
+
+	return resourceRestResourceRead(ctx, d, meta)
+}
+
+func resourceRestResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := buildResourceRestClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	requestURL := client.buildURL(d.Get("read_path").(string), nil, map[string]string{"id": d.Id()})
+	resp, err := client.doRequest("GET", requestURL, "", nil)
+	if err != nil {
+		return common.CheckDeletedDiag(d, err, "Error retrieving REST resource")
+	}
+
+	var result string
+	if resp.Body != nil {
+		result, err = searchJSON(d.Get("read_result_path").(string), resp.Body)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	mErr := multierror.Append(nil,
+		d.Set("region", meta.(*config.Config).GetRegion(d)),
+		d.Set("result", result),
+	)
+	if err := mErr.ErrorOrNil(); err != nil {
+		return fmtp.DiagErrorf("Error setting REST resource fields: %s", err)
+	}
+	return nil
+}
+
+func resourceRestResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	updatePath := d.Get("update_path").(string)
+	if updatePath != "" && d.HasChanges("update_path", "update_method", "update_body") {
+		client, err := buildResourceRestClient(d, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		method := d.Get("update_method").(string)
+		requestURL := client.buildURL(updatePath, nil, map[string]string{"id": d.Id()})
+		resp, err := client.doRequest(method, requestURL, d.Get("update_body").(string), nil)
+		if err != nil {
+			return fmtp.DiagErrorf("Error updating REST resource (%s): %s", d.Id(), err)
+		}
+
+		err = waitForRestResourceStatus(ctx, d, client, resp.Body, d.Timeout(schema.TimeoutUpdate), false)
+		if err != nil {
+			return fmtp.DiagErrorf("Error waiting for the update of REST resource (%s) to complete: %s", d.Id(), err)
+		}
+	}
+
+	return resourceRestResourceRead(ctx, d, meta)
+}
+
+func resourceRestResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := buildResourceRestClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deletePath := d.Get("delete_path").(string)
+	if deletePath == "" {
+		deletePath = d.Get("read_path").(string)
+	}
+	requestURL := client.buildURL(deletePath, nil, map[string]string{"id": d.Id()})
+	resp, err := client.doRequest(d.Get("delete_method").(string), requestURL, d.Get("delete_body").(string), nil)
+	if err != nil {
+		if _, ok := err.(golangsdk.ErrDefault404); ok {
+			d.SetId("")
+			return nil
+		}
+		return fmtp.DiagErrorf("Error deleting REST resource (%s): %s", d.Id(), err)
+	}
+
+	if err := waitForRestResourceStatus(ctx, d, client, resp.Body, d.Timeout(schema.TimeoutDelete), true); err != nil {
+		return fmtp.DiagErrorf("Error waiting for the deletion of REST resource (%s) to complete: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// waitForRestResourceStatus polls the status of the resource or job until it's in success_values,
+// the job ID is extracted from the response body of the operation if job_id_path is specified.
+// When deleting, the resource is also deleted if the status path responds 404.
+func waitForRestResourceStatus(ctx context.Context, d *schema.ResourceData, client *restClient, opBody interface{},
+	timeout time.Duration, isDelete bool) error {
+	pollingRaw := d.Get("status_polling").([]interface{})
+	if len(pollingRaw) == 0 || pollingRaw[0] == nil {
+		return nil
+	}
+	polling := pollingRaw[0].(map[string]interface{})
+
+	vars := map[string]string{"id": d.Id()}
+	if jobIDPath := polling["job_id_path"].(string); jobIDPath != "" {
+		if opBody == nil {
+			return fmtp.Errorf("unable to extract the job ID: the response body is empty or not JSON")
+		}
+		jobID, err := searchString(jobIDPath, opBody)
+		if err != nil {
+			return err
+		}
+		vars["job_id"] = jobID
+	}
+
+	statusPath := polling["path"].(string)
+	if statusPath == "" {
+		statusPath = d.Get("read_path").(string)
+	}
+	requestURL := client.buildURL(statusPath, nil, vars)
+	interval := time.Duration(polling["interval"].(int)) * time.Second
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING"},
+		Target:  []string{"COMPLETED"},
+		Refresh: restStatusRefreshFunc(client, requestURL, polling["status_path"].(string),
+			utils.ExpandToStringList(polling["success_values"].([]interface{})),
+			utils.ExpandToStringList(polling["failure_values"].([]interface{})), isDelete),
+		Timeout:      timeout,
+		Delay:        interval,
+		PollInterval: interval,
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
+
+func restStatusRefreshFunc(client *restClient, requestURL, statusPath string, successValues, failureValues []string,
+	isDelete bool) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.doRequest("GET", requestURL, "", nil)
+		if err != nil {
+			if _, ok := err.(golangsdk.ErrDefault404); ok && isDelete {
+				return "", "COMPLETED", nil
+			}
+			return nil, "", err
+		}
+		if resp.Body == nil {
+			return nil, "", fmtp.Errorf("the response body of %s is not JSON: %s", requestURL, resp.RawBody)
+		}
+
+		status, err := searchString(statusPath, resp.Body)
+		if err != nil {
+			// the status may be absent before the operation starts
+			logp.Printf("[DEBUG] unable to get the status from %s: %s", requestURL, err)
+			return resp.Body, "PENDING", nil
+		}
+
+		logp.Printf("[DEBUG] the status of %s is %s", requestURL, status)
+		if utils.StrSliceContains(successValues, status) {
+			return resp.Body, "COMPLETED", nil
+		}
+		if utils.StrSliceContains(failureValues, status) {
+			return resp.Body, "", fmtp.Errorf("unexpected status %s", status)
+		}
+		return resp.Body, "PENDING", nil
+	}
+}
@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chnsz/golangsdk"
+	"github.com/jmespath/go-jmespath"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/fmtp"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils/logp"
+)
+
+// defaultOkCodes are the status codes which are interpreted as success if ok_codes is not specified
+var defaultOkCodes = []int{200, 201, 202, 203, 204}
+
+// restClient sends the signed requests with the service client which is resolved from the service catalog,
+// so the requests share the authentication, endpoints, retries and logging of the provider.
+type restClient struct {
+	client *golangsdk.ServiceClient
+	// vars stores the values of placeholders in the path, e.g. {project_id}
+	vars    map[string]string
+	headers map[string]string
+}
+
+// restResponse is the status code and the parsed JSON body of a response
+type restResponse struct {
+	StatusCode int
+	RawBody    string
+	// Body is nil if the response body is empty or is not JSON
+	Body interface{}
+}
+
+func newRestClient(conf *config.Config, service, region string, headers map[string]interface{}) (*restClient, error) {
+	client, err := conf.NewServiceClient(service, region)
+	if err != nil {
+		return nil, fmtp.Errorf("Error creating HuaweiCloud %s client: %s", service, err)
+	}
+
+	rc := restClient{
+		client: client,
+		vars: map[string]string{
+			"project_id": client.ProjectID,
+			"region":     region,
+			"domain_id":  conf.DomainID,
+		},
+		headers: make(map[string]string),
+	}
+	for k, v := range headers {
+		rc.headers[k] = v.(string)
+	}
+	return &rc, nil
+}
+
+// buildURL replaces the placeholders in the path and appends the query parameters,
+// the path is relative to the service endpoint unless it's an absolute URL.
+func (rc *restClient) buildURL(path string, query map[string]interface{}, extraVars map[string]string) string {
+	for k, v := range extraVars {
+		path = strings.ReplaceAll(path, "{"+k+"}", v)
+	}
+	for k, v := range rc.vars {
+		path = strings.ReplaceAll(path, "{"+k+"}", v)
+	}
+
+	requestURL := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		requestURL = rc.client.Endpoint + strings.TrimPrefix(path, "/")
+	}
+
+	if len(query) == 0 {
+		return requestURL
+	}
+
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := url.Values{}
+	for _, k := range keys {
+		params.Add(k, query[k].(string))
+	}
+	if strings.Contains(requestURL, "?") {
+		return requestURL + "&" + params.Encode()
+	}
+	return requestURL + "?" + params.Encode()
+}
+
+// doRequest sends the request with the JSON body and returns the parsed response,
+// an error will be returned if the status code is not in okCodes.
+func (rc *restClient) doRequest(method, requestURL, body string, okCodes []int) (*restResponse, error) {
+	if len(okCodes) == 0 {
+		okCodes = defaultOkCodes
+	}
+
+	opts := golangsdk.RequestOpts{
+		OkCodes:          okCodes,
+		MoreHeaders:      make(map[string]string),
+		KeepResponseBody: true,
+	}
+	for k, v := range rc.headers {
+		opts.MoreHeaders[k] = v
+	}
+	if body != "" {
+		var jsonBody interface{}
+		if err := json.Unmarshal([]byte(body), &jsonBody); err != nil {
+			return nil, fmtp.Errorf("Error parsing the request body: %s", err)
+		}
+		opts.JSONBody = jsonBody
+	}
+
+	logp.Printf("[DEBUG] Sending REST request: %s %s", method, requestURL)
+	resp, err := rc.client.Request(method, requestURL, &opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	rawBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmtp.Errorf("Error reading the response body: %s", err)
+	}
+
+	result := restResponse{
+		StatusCode: resp.StatusCode,
+		RawBody:    string(rawBody),
+	}
+	if len(rawBody) > 0 {
+		var parsed interface{}
+		if err := json.Unmarshal(rawBody, &parsed); err == nil {
+			result.Body = parsed
+		}
+	}
+	return &result, nil
+}
+
+// searchJSON returns the JSON encoded result of the JMESPath expression,
+// the whole body will be returned if the expression is empty.
+func searchJSON(expression string, body interface{}) (string, error) {
+	result := body
+	if expression != "" {
+		var err error
+		result, err = jmespath.Search(expression, body)
+		if err != nil {
+			return "", fmtp.Errorf("Error searching %q in the response body: %s", expression, err)
+		}
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// searchString returns the result of the JMESPath expression as a string,
+// an error will be returned if the result is not a string or number.
+func searchString(expression string, body interface{}) (string, error) {
+	result, err := jmespath.Search(expression, body)
+	if err != nil {
+		return "", fmtp.Errorf("Error searching %q in the response body: %s", expression, err)
+	}
+
+	switch v := result.(type) {
+	case string:
+		if v != "" {
+			return v, nil
+		}
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmtp.Errorf("the result of %q is not a valid string: %v", expression, result)
+}
+
+func expandOkCodes(raw []interface{}) []int {
+	codes := make([]int, len(raw))
+	for i, v := range raw {
+		codes[i] = v.(int)
+	}
+	return codes
+}
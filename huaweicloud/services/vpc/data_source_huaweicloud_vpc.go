@@ -55,7 +55,7 @@ func DataSourceVpcV1() *schema.Resource {
 			"routes": {
 				Type:       schema.TypeList,
 				Computed:   true,
-				Deprecated: "use huaweicloud_vpc_route_table data source to get all routes",
+				Deprecated: "use huaweicloud_vpc_route_table data source instead to get all routes",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"destination": {
@@ -128,7 +128,7 @@ func dataSourceVpcV1Read(_ context.Context, d *schema.ResourceData, meta interfa
 	// save VirtualPrivateCloudV2 tags
 	if vpcV2Client, err := config.NetworkingV2Client(config.GetRegion(d)); err == nil {
 		if resourceTags, err := tags.Get(vpcV2Client, "vpcs", d.Id()).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 			if err := d.Set("tags", tagmap); err != nil {
 				return fmtp.DiagErrorf("Error saving tags to state for VPC (%s): %s", d.Id(), err)
 			}
@@ -205,7 +205,7 @@ func dataSourceVpcSubnetsRead(_ context.Context, d *schema.ResourceData, meta in
 		}
 
 		if resourceTags, err := tags.Get(clientV2, "subnets", item.ID).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 
 			if !utils.HasMapContains(tagmap, tagFilter) {
 				continue
@@ -8,6 +8,7 @@ import (
 	"github.com/chnsz/golangsdk/openstack/networking/v1/vpcs"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/common"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/config"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/helper/hashcode"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
@@ -50,6 +51,9 @@ func DataSourceVpcs() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"filter": common.FilterSchema([]string{
+				"id", "name", "cidr", "enterprise_project_id", "status", "description", "tags",
+			}),
 			"vpcs": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -119,9 +123,8 @@ func dataSourceVpcsRead(_ context.Context, d *schema.ResourceData, meta interfac
 
 	logp.Printf("[DEBUG] Retrieved Vpc using given filter: %+v", vpcList)
 
-	var vpcs []map[string]interface{}
+	var vpcs []interface{}
 	tagFilter := d.Get("tags").(map[string]interface{})
-	var ids []string
 	for _, vpcResource := range vpcList {
 		vpc := map[string]interface{}{
 			"id":                    vpcResource.ID,
@@ -133,7 +136,7 @@ func dataSourceVpcsRead(_ context.Context, d *schema.ResourceData, meta interfac
 		}
 
 		if resourceTags, err := tags.Get(vpcV2Client, "vpcs", vpcResource.ID).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.TagsToMap(resourceTags.Tags, config.TagsConfig)
 
 			if !utils.HasMapContains(tagmap, tagFilter) {
 				continue
@@ -149,7 +152,18 @@ func dataSourceVpcsRead(_ context.Context, d *schema.ResourceData, meta interfac
 		}
 
 		vpcs = append(vpcs, vpc)
-		ids = append(ids, vpcResource.ID)
+	}
+
+	if rules := common.ExpandFilterRules(d, nil); len(rules) > 0 {
+		vpcs, err = utils.FilterSliceWithRules(vpcs, rules)
+		if err != nil {
+			return fmtp.DiagErrorf("filter VPCs failed: %s", err)
+		}
+	}
+
+	ids := make([]string, len(vpcs))
+	for i, vpc := range vpcs {
+		ids[i] = vpc.(map[string]interface{})["id"].(string)
 	}
 	logp.Printf("[DEBUG]Vpc List after filter, count=%d :%+v", len(vpcs), vpcs)
 
@@ -67,7 +67,7 @@ func ResourceVirtualPrivateCloudV1() *schema.Resource {
 			"routes": {
 				Type:       schema.TypeList,
 				Computed:   true,
-				Deprecated: "use huaweicloud_vpc_route_table data source to get all routes",
+				Deprecated: "use huaweicloud_vpc_route_table data source instead to get all routes",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"destination": {
@@ -129,7 +129,7 @@ func resourceVirtualPrivateCloudCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		vpcV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
 		if err != nil {
@@ -182,7 +182,7 @@ func resourceVirtualPrivateCloudRead(_ context.Context, d *schema.ResourceData,
 	// save VirtualPrivateCloudV2 tags
 	if vpcV2Client, err := config.NetworkingV2Client(config.GetRegion(d)); err == nil {
 		if resourceTags, err := tags.Get(vpcV2Client, "vpcs", d.Id()).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 			if err := d.Set("tags", tagmap); err != nil {
 				return fmtp.DiagErrorf("Error saving tags to state for VPC (%s): %s", d.Id(), err)
 			}
@@ -226,7 +226,7 @@ func resourceVirtualPrivateCloudUpdate(ctx context.Context, d *schema.ResourceDa
 			return fmtp.DiagErrorf("Error creating Huaweicloud VPC client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(vpcV2Client, d, "vpcs", d.Id())
+		tagErr := utils.UpdateResourceTags(vpcV2Client, d, "vpcs", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of VPC %s: %s", d.Id(), tagErr)
 		}
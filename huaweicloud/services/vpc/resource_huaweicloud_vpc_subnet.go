@@ -203,7 +203,7 @@ func resourceVpcSubnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	//set tags
-	tagRaw := d.Get("tags").(map[string]interface{})
+	tagRaw := utils.MergeDefaultTags(d.Get("tags").(map[string]interface{}), config.TagsConfig)
 	if len(tagRaw) > 0 {
 		vpcSubnetV2Client, err := config.NetworkingV2Client(config.GetRegion(d))
 		if err != nil {
@@ -258,7 +258,7 @@ func resourceVpcSubnetRead(_ context.Context, d *schema.ResourceData, meta inter
 	// save VpcSubnet tags
 	if vpcSubnetV2Client, err := config.NetworkingV2Client(config.GetRegion(d)); err == nil {
 		if resourceTags, err := tags.Get(vpcSubnetV2Client, "subnets", d.Id()).Extract(); err == nil {
-			tagmap := utils.TagsToMap(resourceTags.Tags)
+			tagmap := utils.FlattenResourceTags(d, resourceTags.Tags, config.TagsConfig)
 			mErr = multierror.Append(mErr, d.Set("tags", tagmap))
 		} else {
 			logp.Printf("[WARN] Error fetching tags of Subnet (%s): %s", d.Id(), err)
@@ -322,7 +322,7 @@ func resourceVpcSubnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			return fmtp.DiagErrorf("Error creating Huaweicloud VpcSubnet client: %s", err)
 		}
 
-		tagErr := utils.UpdateResourceTags(vpcSubnetV2Client, d, "subnets", d.Id())
+		tagErr := utils.UpdateResourceTags(vpcSubnetV2Client, d, "subnets", d.Id(), config.TagsConfig)
 		if tagErr != nil {
 			return fmtp.DiagErrorf("Error updating tags of VPC subnet %s: %s", d.Id(), tagErr)
 		}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -75,23 +77,237 @@ func filterSliceWithFieldRaw(all interface{}, filter map[string]interface{}, ign
 	return result, nil
 }
 
+// The match rules of FilterRule, the numeric rules compare the values as float numbers.
+const (
+	FilterMatchByExact = "exact"
+	FilterMatchByRegex = "regex"
+	FilterMatchByLt    = "lt"
+	FilterMatchByLe    = "le"
+	FilterMatchByEq    = "eq"
+	FilterMatchByGe    = "ge"
+	FilterMatchByGt    = "gt"
+)
+
+// FilterMatchRules is the list of the match rules supported by FilterRule.
+var FilterMatchRules = []string{
+	FilterMatchByExact, FilterMatchByRegex, FilterMatchByLt, FilterMatchByLe,
+	FilterMatchByEq, FilterMatchByGe, FilterMatchByGt,
+}
+
+// FilterRule is a rule used by FilterSliceWithRules.
+// An object matches the rule if any of the Values matches the Field, the result is reversed when Negate is true.
+type FilterRule struct {
+	// Field is the struct field or the map key of the objects, using dot(.) to split the nested values,
+	// e.g. "SubBlock.SubField" or "tags.foo". The rest of the field after "tags." is regarded as a tag key.
+	Field string
+	// Values is the set of the values to match, a slice field matches if any of its elements matches.
+	Values []string
+	// MatchBy is one of FilterMatchRules, defaults to FilterMatchByExact.
+	MatchBy string
+	Negate  bool
+}
+
+// FilterSliceWithRules can filter the slice all through a list of rules, the objects in the slice can be
+// structs or maps. An object is returned only if it matches all of the rules.
+// A missing map key, e.g. a tag which is not set, matches none of the values.
+func FilterSliceWithRules(all interface{}, rules []FilterRule) ([]interface{}, error) {
+	allValue := reflect.ValueOf(all)
+	if allValue.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("options type is not a slice")
+	}
+
+	matchers := make([]func(interface{}) bool, len(rules))
+	for i, rule := range rules {
+		matcher, err := buildFilterMatcher(rule)
+		if err != nil {
+			return nil, err
+		}
+		matchers[i] = matcher
+	}
+
+	result := make([]interface{}, 0, allValue.Len())
+	for i := 0; i < allValue.Len(); i++ {
+		refValue := allValue.Index(i)
+		if refValue.Kind() == reflect.Ptr || refValue.Kind() == reflect.Interface {
+			refValue = refValue.Elem()
+		}
+		if refValue.Kind() != reflect.Struct && refValue.Kind() != reflect.Map {
+			return nil, fmt.Errorf("object in slice is neither a struct nor a map")
+		}
+
+		matched := true
+		for j, rule := range rules {
+			actual, err := getStructField(refValue, rule.Field)
+			if err != nil {
+				return nil, fmt.Errorf("get slice field %s failed: %s", rule.Field, err)
+			}
+
+			if matchers[j](actual) == rule.Negate {
+				log.Printf("[DEBUG] can not match slice[%d] field %s: expect %s %v (negate: %t), but got %v",
+					i, rule.Field, rule.MatchBy, rule.Values, rule.Negate, actual)
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			result = append(result, refValue.Interface())
+		}
+	}
+	return result, nil
+}
+
+// buildFilterMatcher returns a function that reports whether a field value matches any value of the rule
+func buildFilterMatcher(rule FilterRule) (func(interface{}) bool, error) {
+	var match func(actual interface{}, index int) bool
+
+	switch rule.MatchBy {
+	case "", FilterMatchByExact:
+		match = func(actual interface{}, index int) bool {
+			return fmt.Sprint(actual) == rule.Values[index]
+		}
+	case FilterMatchByRegex:
+		regexps := make([]*regexp.Regexp, len(rule.Values))
+		for i, val := range rule.Values {
+			r, err := regexp.Compile(val)
+			if err != nil {
+				return nil, fmt.Errorf("the regular expression %q of field %s is invalid: %s", val, rule.Field, err)
+			}
+			regexps[i] = r
+		}
+		match = func(actual interface{}, index int) bool {
+			return regexps[index].MatchString(fmt.Sprint(actual))
+		}
+	case FilterMatchByLt, FilterMatchByLe, FilterMatchByEq, FilterMatchByGe, FilterMatchByGt:
+		numbers := make([]float64, len(rule.Values))
+		for i, val := range rule.Values {
+			num, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, fmt.Errorf("the value %q of field %s is not a number", val, rule.Field)
+			}
+			numbers[i] = num
+		}
+		match = func(actual interface{}, index int) bool {
+			num, ok := parseFilterNumber(actual)
+			if !ok {
+				return false
+			}
+			return matchNumberRule(rule.MatchBy, compareNumber(num, numbers[index]))
+		}
+	default:
+		return nil, fmt.Errorf("the match rule %s of field %s is not supported", rule.MatchBy, rule.Field)
+	}
+
+	matchValue := func(actual interface{}) bool {
+		for i := range rule.Values {
+			if match(actual, i) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(actual interface{}) bool {
+		if actual == nil {
+			return false
+		}
+
+		actualValue := reflect.ValueOf(actual)
+		if actualValue.Kind() != reflect.Slice && actualValue.Kind() != reflect.Array {
+			return matchValue(actual)
+		}
+		for i := 0; i < actualValue.Len(); i++ {
+			if matchValue(actualValue.Index(i).Interface()) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// parseFilterNumber converts the integer, float and numeric string values to float64
+func parseFilterNumber(v interface{}) (float64, bool) {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	case reflect.String:
+		num, err := strconv.ParseFloat(strings.TrimSpace(value.String()), 64)
+		return num, err == nil
+	}
+	return 0, false
+}
+
+func compareNumber(actual, expect float64) int {
+	if actual < expect {
+		return matchRuleByNumLt
+	}
+	if actual > expect {
+		return matchRuleByNumGt
+	}
+	return matchRuleByNumEq
+}
+
+func matchNumberRule(matchBy string, result int) bool {
+	switch matchBy {
+	case FilterMatchByLt:
+		return result == matchRuleByNumLt
+	case FilterMatchByLe:
+		return result != matchRuleByNumGt
+	case FilterMatchByEq:
+		return result == matchRuleByNumEq
+	case FilterMatchByGe:
+		return result != matchRuleByNumLt
+	case FilterMatchByGt:
+		return result == matchRuleByNumGt
+	}
+	return false
+}
+
 func getStructField(v reflect.Value, field string) (interface{}, error) {
 	var subField interface{}
 	var err error
 	structValue := v
 
 	parts := strings.Split(field, ".")
+	// the tag keys may contain dots, e.g. "tags.kubernetes.io/cluster", so only the first dot is used as a separator
+	if strings.EqualFold(parts[0], "tags") {
+		parts = strings.SplitN(field, ".", 2)
+	}
 	for _, key := range parts {
 		subField, err = getStructFieldRaw(structValue, key)
 		if err != nil {
 			return nil, err
 		}
+		if subField == nil {
+			return nil, nil
+		}
 		structValue = reflect.ValueOf(subField)
 	}
 	return subField, nil
 }
 
 func getStructFieldRaw(v reflect.Value, field string) (interface{}, error) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Map {
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("reflect: the key of map is not a string")
+		}
+		value := v.MapIndex(reflect.ValueOf(field).Convert(v.Type().Key()))
+		if value.IsValid() {
+			return value.Interface(), nil
+		}
+		// the missing key is regarded as an unset value rather than an error
+		return nil, nil
+	}
+
 	if v.Kind() == reflect.Struct {
 		value := reflect.Indirect(v).FieldByName(field)
 		if value.IsValid() {
@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/chnsz/golangsdk"
 	"github.com/chnsz/golangsdk/openstack/common/tags"
@@ -11,13 +12,62 @@ import (
 
 const SysTagKeyEnterpriseProjectId = "_sys_enterprise_project_id"
 
+// TagsConfig is the provider-level tags configuration, default tags will be merged into the tags of every
+// taggable resource and the ignored tags will be filtered out of the state.
+type TagsConfig struct {
+	DefaultTags       map[string]string
+	IgnoreKeys        []string
+	IgnoreKeyPrefixes []string
+}
+
+// builtinIgnoreTagKeys is a list of system tags which are added by the cloud service,
+// ignore them to keep the tags consistent with what the user set.
+var builtinIgnoreTagKeys = []string{"CCE-Dynamic-Provisioning-Node"}
+
+// IsIgnoredTagKey returns true if the tag key is ignored by the provider-level ignore_tags configuration
+// or is a built-in system tag key.
+func IsIgnoredTagKey(key string, cfg *TagsConfig) bool {
+	if StrSliceContains(builtinIgnoreTagKeys, key) {
+		return true
+	}
+	if cfg == nil {
+		return false
+	}
+
+	if StrSliceContains(cfg.IgnoreKeys, key) {
+		return true
+	}
+	for _, prefix := range cfg.IgnoreKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeDefaultTags merges the provider-level default tags into the resource tags,
+// the tag defined in the resource has a higher priority than the default one.
+func MergeDefaultTags(tagmap map[string]interface{}, cfg *TagsConfig) map[string]interface{} {
+	result := make(map[string]interface{}, len(tagmap))
+	if cfg != nil {
+		for k, v := range cfg.DefaultTags {
+			result[k] = v
+		}
+	}
+	for k, v := range tagmap {
+		result[k] = v
+	}
+	return result
+}
+
 // UpdateResourceTags is a helper to update the tags for a resource.
 // It expects the tags field to be named "tags"
-func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string) error {
+func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, resourceType, id string,
+	cfg *TagsConfig) error {
 	if d.HasChange("tags") {
 		oRaw, nRaw := d.GetChange("tags")
-		oMap := oRaw.(map[string]interface{})
-		nMap := nRaw.(map[string]interface{})
+		oMap := MergeDefaultTags(oRaw.(map[string]interface{}), cfg)
+		nMap := MergeDefaultTags(nRaw.(map[string]interface{}), cfg)
 
 		// remove old tags
 		if len(oMap) > 0 {
@@ -42,10 +92,11 @@ func UpdateResourceTags(conn *golangsdk.ServiceClient, d *schema.ResourceData, r
 }
 
 //This is a help to query tags of resource, then set to state. The schema argument name must be: tags
-func SetResourceTagsToState(d *schema.ResourceData, client *golangsdk.ServiceClient, resourceType string) error {
+func SetResourceTagsToState(d *schema.ResourceData, client *golangsdk.ServiceClient, resourceType string,
+	cfg *TagsConfig) error {
 	// set tags
 	if resourceTags, err := tags.Get(client, resourceType, d.Id()).Extract(); err == nil {
-		tagmap := TagsToMap(resourceTags.Tags)
+		tagmap := FlattenResourceTags(d, resourceTags.Tags, cfg)
 		if err := d.Set("tags", tagmap); err != nil {
 			return fmt.Errorf("error saving tags to state for CSS cluster (%s): %s", d.Id(), err)
 		}
@@ -55,16 +106,50 @@ func SetResourceTagsToState(d *schema.ResourceData, client *golangsdk.ServiceCli
 	return nil
 }
 
-// TagsToMap returns the list of tags into a map.
-func TagsToMap(tags []tags.ResourceTag) map[string]string {
+// FlattenResourceTags returns the tags of the resource which are managed by the "tags" argument.
+// The ignored tags and the default tags which are not overridden will not be returned,
+// unless they are specified in the "tags" argument.
+func FlattenResourceTags(d *schema.ResourceData, resourceTags []tags.ResourceTag, cfg *TagsConfig) map[string]string {
+	tagmap := make(map[string]string, len(resourceTags))
+	for _, tag := range resourceTags {
+		tagmap[tag.Key] = tag.Value
+	}
+	return FlattenTagsMap(d, tagmap, cfg)
+}
+
+// FlattenTagsMap is the same as FlattenResourceTags, except that the tags of the resource are a map.
+func FlattenTagsMap(d *schema.ResourceData, tagmap map[string]string, cfg *TagsConfig) map[string]string {
+	rawTags, _ := d.Get("tags").(map[string]interface{})
+	result := make(map[string]string)
+	for k, v := range tagmap {
+		if _, ok := rawTags[k]; ok {
+			result[k] = v
+			continue
+		}
+		if IsIgnoredTagKey(k, cfg) {
+			continue
+		}
+		if cfg != nil {
+			if defaultValue, ok := cfg.DefaultTags[k]; ok && defaultValue == v {
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// TagsToMap returns the list of tags into a map, the ignored tags are not returned.
+func TagsToMap(tags []tags.ResourceTag, cfg *TagsConfig) map[string]string {
 	result := make(map[string]string)
 	for _, val := range tags {
+		// ignore system tags to keep the tags consistent with what the user set
+		if IsIgnoredTagKey(val.Key, cfg) {
+			continue
+		}
 		result[val.Key] = val.Value
 	}
 
-	// ignore system tags to keep the tags consistent with what the user set
-	delete(result, "CCE-Dynamic-Provisioning-Node")
-
 	return result
 }
 
@@ -0,0 +1,147 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+type testFlavor struct {
+	Name  string
+	Vcpus string
+	Ram   int64
+	Spec  testFlavorSpec
+	Tags  map[string]string
+}
+
+type testFlavorSpec struct {
+	Generation string
+}
+
+var testFlavors = []testFlavor{
+	{Name: "s6.small.1", Vcpus: "1", Ram: 1024, Spec: testFlavorSpec{"s6"}, Tags: map[string]string{"env": "dev"}},
+	{Name: "s6.large.2", Vcpus: "2", Ram: 4096, Spec: testFlavorSpec{"s6"}, Tags: map[string]string{"env": "prod", "kubernetes.io/cluster": "cce"}},
+	{Name: "c7.xlarge.2", Vcpus: "4", Ram: 8192, Spec: testFlavorSpec{"c7"}},
+	{Name: "c7.2xlarge.4", Vcpus: "8", Ram: 32768, Spec: testFlavorSpec{"c7"}},
+}
+
+func filterFlavorNames(t *testing.T, rules []utils.FilterRule) []string {
+	result, err := utils.FilterSliceWithRules(testFlavors, rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := make([]string, len(result))
+	for i, item := range result {
+		names[i] = item.(testFlavor).Name
+	}
+	return names
+}
+
+func checkNames(t *testing.T, actual []string, expected ...string) {
+	if len(actual) != len(expected) {
+		t.Fatalf("expect %v, but got %v", expected, actual)
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			t.Fatalf("expect %v, but got %v", expected, actual)
+		}
+	}
+}
+
+func TestFilterSliceWithRules_exactAndSet(t *testing.T) {
+	names := filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Spec.Generation", Values: []string{"c7"}},
+	})
+	checkNames(t, names, "c7.xlarge.2", "c7.2xlarge.4")
+
+	names = filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Vcpus", Values: []string{"1", "8"}, MatchBy: utils.FilterMatchByExact},
+	})
+	checkNames(t, names, "s6.small.1", "c7.2xlarge.4")
+}
+
+func TestFilterSliceWithRules_regexAndNegate(t *testing.T) {
+	names := filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Name", Values: []string{"^s6\\."}, MatchBy: utils.FilterMatchByRegex},
+	})
+	checkNames(t, names, "s6.small.1", "s6.large.2")
+
+	names = filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Name", Values: []string{"large"}, MatchBy: utils.FilterMatchByRegex, Negate: true},
+	})
+	checkNames(t, names, "s6.small.1")
+}
+
+func TestFilterSliceWithRules_numericRange(t *testing.T) {
+	names := filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Vcpus", Values: []string{"2"}, MatchBy: utils.FilterMatchByGe},
+		{Field: "Vcpus", Values: []string{"8"}, MatchBy: utils.FilterMatchByLt},
+	})
+	checkNames(t, names, "s6.large.2", "c7.xlarge.2")
+
+	names = filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Ram", Values: []string{"8192"}, MatchBy: utils.FilterMatchByGt},
+	})
+	checkNames(t, names, "c7.2xlarge.4")
+
+	names = filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Ram", Values: []string{"4096.0"}, MatchBy: utils.FilterMatchByEq},
+	})
+	checkNames(t, names, "s6.large.2")
+}
+
+func TestFilterSliceWithRules_tags(t *testing.T) {
+	names := filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Tags.env", Values: []string{"prod"}},
+	})
+	checkNames(t, names, "s6.large.2")
+
+	// the objects without the tag match the negated rule
+	names = filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Tags.env", Values: []string{"prod"}, Negate: true},
+	})
+	checkNames(t, names, "s6.small.1", "c7.xlarge.2", "c7.2xlarge.4")
+
+	// the tag key contains dots
+	names = filterFlavorNames(t, []utils.FilterRule{
+		{Field: "Tags.kubernetes.io/cluster", Values: []string{"cce"}},
+	})
+	checkNames(t, names, "s6.large.2")
+}
+
+func TestFilterSliceWithRules_maps(t *testing.T) {
+	all := []interface{}{
+		map[string]interface{}{"id": "vpc-1", "cidr": "192.168.0.0/16", "tags": map[string]interface{}{"foo": "bar"}},
+		map[string]interface{}{"id": "vpc-2", "cidr": "172.16.0.0/12", "zones": []string{"az1", "az2"}},
+	}
+
+	result, err := utils.FilterSliceWithRules(all, []utils.FilterRule{
+		{Field: "tags.foo", Values: []string{"bar"}},
+	})
+	if err != nil || len(result) != 1 || result[0].(map[string]interface{})["id"] != "vpc-1" {
+		t.Fatalf("unexpected result of tags filter: %v, %v", result, err)
+	}
+
+	result, err = utils.FilterSliceWithRules(all, []utils.FilterRule{
+		{Field: "zones", Values: []string{"az2"}},
+	})
+	if err != nil || len(result) != 1 || result[0].(map[string]interface{})["id"] != "vpc-2" {
+		t.Fatalf("unexpected result of list filter: %v, %v", result, err)
+	}
+}
+
+func TestFilterSliceWithRules_invalid(t *testing.T) {
+	invalidRules := [][]utils.FilterRule{
+		{{Field: "Name", Values: []string{"("}, MatchBy: utils.FilterMatchByRegex}},
+		{{Field: "Vcpus", Values: []string{"two"}, MatchBy: utils.FilterMatchByGt}},
+		{{Field: "Name", Values: []string{"foo"}, MatchBy: "like"}},
+		{{Field: "NotExist", Values: []string{"foo"}}},
+	}
+
+	for _, rules := range invalidRules {
+		if _, err := utils.FilterSliceWithRules(testFlavors, rules); err == nil {
+			t.Errorf("expect an error of rules %v", rules)
+		}
+	}
+}
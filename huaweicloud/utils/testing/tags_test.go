@@ -0,0 +1,139 @@
+package testing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chnsz/golangsdk/openstack/common/tags"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/utils"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	cfg := &utils.TagsConfig{
+		DefaultTags: map[string]string{
+			"owner": "terraform",
+			"env":   "test",
+		},
+	}
+
+	rawTags := map[string]interface{}{
+		"env":  "prod",
+		"name": "demo",
+	}
+	expected := map[string]interface{}{
+		"owner": "terraform",
+		"env":   "prod",
+		"name":  "demo",
+	}
+
+	if merged := utils.MergeDefaultTags(rawTags, cfg); !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("expected %v, but got %v", expected, merged)
+	}
+	if merged := utils.MergeDefaultTags(rawTags, nil); !reflect.DeepEqual(merged, rawTags) {
+		t.Fatalf("expected %v, but got %v", rawTags, merged)
+	}
+}
+
+func TestTagsToMap(t *testing.T) {
+	resourceTags := []tags.ResourceTag{
+		{Key: "owner", Value: "terraform"},
+		{Key: "created_by", Value: "cts"},
+		{Key: "CCE-Dynamic-Provisioning-Node", Value: "node-1"},
+	}
+	expected := map[string]string{
+		"owner":      "terraform",
+		"created_by": "cts",
+	}
+
+	if result := utils.TagsToMap(resourceTags, nil); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, but got %v", expected, result)
+	}
+
+	// the tags ignored by the provider are not returned, but the default tags are kept
+	cfg := &utils.TagsConfig{
+		DefaultTags: map[string]string{"owner": "terraform"},
+		IgnoreKeys:  []string{"created_by"},
+	}
+	expected = map[string]string{"owner": "terraform"}
+	if result := utils.TagsToMap(resourceTags, cfg); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, but got %v", expected, result)
+	}
+}
+
+func TestFlattenResourceTags(t *testing.T) {
+	cfg := &utils.TagsConfig{
+		DefaultTags: map[string]string{
+			"owner": "terraform",
+			"env":   "test",
+			"team":  "dev",
+		},
+		IgnoreKeys:        []string{"created_by"},
+		IgnoreKeyPrefixes: []string{"kubernetes.io/"},
+	}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"tags": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}, map[string]interface{}{
+		// the tags which are specified in the configuration are kept even if they equal the default tags
+		"tags": map[string]interface{}{
+			"team":       "dev",
+			"created_by": "user",
+		},
+	})
+
+	resourceTags := []tags.ResourceTag{
+		{Key: "owner", Value: "terraform"},
+		{Key: "env", Value: "prod"},
+		{Key: "team", Value: "dev"},
+		{Key: "name", Value: "demo"},
+		{Key: "created_by", Value: "user"},
+		{Key: "kubernetes.io/cluster", Value: "owned"},
+		{Key: "CCE-Dynamic-Provisioning-Node", Value: "node-1"},
+	}
+	expected := map[string]string{
+		"env":        "prod",
+		"team":       "dev",
+		"name":       "demo",
+		"created_by": "user",
+	}
+
+	if result := utils.FlattenResourceTags(d, resourceTags, cfg); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, but got %v", expected, result)
+	}
+
+	tagmap := make(map[string]string, len(resourceTags))
+	for _, tag := range resourceTags {
+		tagmap[tag.Key] = tag.Value
+	}
+	if result := utils.FlattenTagsMap(d, tagmap, cfg); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, but got %v", expected, result)
+	}
+}
+
+func TestIsIgnoredTagKey(t *testing.T) {
+	cfg := &utils.TagsConfig{
+		IgnoreKeys:        []string{"created_by"},
+		IgnoreKeyPrefixes: []string{"kubernetes.io/"},
+	}
+
+	cases := map[string]bool{
+		"created_by":                    true,
+		"kubernetes.io/cluster":         true,
+		"CCE-Dynamic-Provisioning-Node": true,
+		"created":                       false,
+		"kubernetes":                    false,
+	}
+	for key, expected := range cases {
+		if result := utils.IsIgnoredTagKey(key, cfg); result != expected {
+			t.Errorf("the ignore result of %s should be %v, but got %v", key, expected, result)
+		}
+	}
+	if utils.IsIgnoredTagKey("created_by", nil) {
+		t.Errorf("the key created_by should not be ignored without the tags configuration")
+	}
+}
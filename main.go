@@ -1,11 +1,27 @@
 package main
 
 import (
+	"os"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/generate"
+	"github.com/huaweicloud/terraform-provider-huaweicloud/huaweicloud/migrate"
 )
 
 func main() {
+	// the plugin is started by Terraform without arguments
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "generate":
+			os.Exit(generate.Main(os.Args[2:], os.Stdout, os.Stderr))
+		case "migrate-state":
+			os.Exit(migrate.Main(os.Args[2:], os.Stdout, os.Stderr))
+		case "deprecation-report":
+			os.Exit(migrate.ReportMain(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: huaweicloud.Provider})
 }